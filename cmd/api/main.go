@@ -37,14 +37,43 @@ func main() {
 	// Create Gin router
 	r := gin.New()
 
+	// Only honour X-Forwarded-For from configured proxies; with none, the
+	// client IP used for API key allowlists and login lockouts is the peer
+	// address and cannot be spoofed by a header
+	if err := r.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+		log.Fatalf("Invalid trusted proxies: %v", err)
+	}
+
 	// Setup routes with middleware
 	router.SetupRoutes(
 		r,
 		container.Logger,
-		cfg.JWT.Secret,
+		container.TokenService,
+		container.CompanyService,
+		container.DriverService,
+		container.PlatformService,
+		container.RBACService,
+		container.SessionService,
+		container.APIKeyService,
+		container.RateLimitService,
+		container.ModuleService,
 		container.AdminCompanyHandler,
 		container.AdminDriverHandler,
 		container.AdminModuleHandler,
+		container.AdminStoreHandler,
+		container.AdminVehicleHandler,
+		container.AdminMaintenanceHandler,
+		container.AdminClientHandler,
+		container.PlatformAuthHandler,
+		container.AdminRoleHandler,
+		container.DriverHandler,
+		container.SessionHandler,
+		container.JWKSHandler,
+		container.AdminAccountHandler,
+		container.AdminMFAHandler,
+		container.AdminAPIKeyHandler,
+		container.AdminNotificationHandler,
+		container.PlanHandler,
 	)
 
 	// Create HTTP server
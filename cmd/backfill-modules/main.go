@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"strings"
+
+	"my-go-driver/internal/config"
+	"my-go-driver/internal/domain/company"
+	"my-go-driver/internal/domain/module"
+	"my-go-driver/internal/domain/rbac"
+	"my-go-driver/internal/repository"
+	"my-go-driver/internal/service"
+	"my-go-driver/internal/tenant"
+)
+
+// errDryRun rolls back a company's transaction in dry-run mode
+var errDryRun = errors.New("dry run")
+
+// backfill-modules provisions the default modules of each company's plan for
+// companies created before modules were provisioned automatically. Modules a
+// company already has are left untouched, so it is safe to run repeatedly.
+//
+//	go run ./cmd/backfill-modules [-company 42] [-dry-run]
+func main() {
+	companyID := flag.Uint64("company", 0, "only backfill this company")
+	dryRun := flag.Bool("dry-run", false, "report the modules that would be provisioned without saving them")
+	flag.Parse()
+
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	db, err := config.NewDatabase(cfg.Database)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+
+	companyRepo := repository.NewCompanyRepository(db)
+	moduleRepo := repository.NewModuleRepository(db)
+	planService := service.NewPlanService(companyRepo, moduleRepo)
+	transactor := repository.NewTransactor(db)
+	storeRepo := repository.NewStoreRepository(db)
+	moduleService := service.NewModuleService(moduleRepo, companyRepo, storeRepo, planService, transactor)
+
+	ctx := rbac.WithAllPermissions(tenant.WithPlatform(context.Background()))
+	query := company.ListCompaniesQuery{Page: 1, Limit: 100, ID: *companyID}
+	companies, total := 0, 0
+
+	for {
+		page, _, err := companyRepo.List(ctx, query)
+		if err != nil {
+			log.Fatalf("Failed to list companies: %v", err)
+		}
+
+		for _, c := range page {
+			var provisioned []module.CompanyModuleResponse
+			err := transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+				var err error
+				provisioned, err = moduleService.ProvisionDefaultModules(ctx, c.ID, c.Plan)
+				if err == nil && *dryRun {
+					return errDryRun
+				}
+				return err
+			})
+			if err != nil && !errors.Is(err, errDryRun) {
+				log.Fatalf("Failed to backfill company %d: %v", c.ID, err)
+			}
+
+			if len(provisioned) > 0 {
+				keys := make([]string, len(provisioned))
+				for i, m := range provisioned {
+					keys[i] = m.Module.ModuleKey
+				}
+				log.Printf("Company %d (%s plan): %s", c.ID, c.Plan, strings.Join(keys, ", "))
+				companies++
+				total += len(provisioned)
+			}
+		}
+
+		if len(page) < query.Limit {
+			break
+		}
+		query.Page++
+	}
+
+	if *dryRun {
+		log.Printf("Dry run: %d modules would be provisioned for %d companies", total, companies)
+		return
+	}
+	log.Printf("Provisioned %d modules for %d companies", total, companies)
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+
+	"my-go-driver/internal/config"
+	"my-go-driver/internal/domain/company"
+	"my-go-driver/internal/domain/module"
+	"my-go-driver/internal/domain/rbac"
+	"my-go-driver/internal/repository"
+	"my-go-driver/internal/service"
+	"my-go-driver/internal/tenant"
+)
+
+// check-maintenance flags the vehicles that are due for a service, moves them
+// to maintenance where a rule says so and sends reminders, for every company
+// with vehicle management enabled. Reminders are only sent for newly due
+// services, so it is meant to run periodically, e.g. daily from cron.
+//
+//	go run ./cmd/check-maintenance [-company 42]
+func main() {
+	companyID := flag.Uint64("company", 0, "only check this company")
+	flag.Parse()
+
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	db, err := config.NewDatabase(cfg.Database)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+
+	companyRepo := repository.NewCompanyRepository(db)
+	moduleRepo := repository.NewModuleRepository(db)
+	storeRepo := repository.NewStoreRepository(db)
+	vehicleRepo := repository.NewVehicleRepository(db)
+	transactor := repository.NewTransactor(db)
+	planService := service.NewPlanService(companyRepo, moduleRepo)
+	moduleService := service.NewModuleService(moduleRepo, companyRepo, storeRepo, planService, transactor)
+	storeService := service.NewStoreService(storeRepo)
+	vehicleService := service.NewVehicleService(vehicleRepo, repository.NewDriverRepository(db), storeService, transactor)
+	notificationService := service.NewNotificationService(repository.NewNotificationRepository(db), moduleService)
+	maintenanceService := service.NewMaintenanceService(repository.NewMaintenanceRepository(db), vehicleRepo, vehicleService, repository.NewShiftRepository(db), notificationService, transactor)
+
+	ctx := rbac.WithAllPermissions(tenant.WithPlatform(context.Background()))
+	query := company.ListCompaniesQuery{Page: 1, Limit: 100, ID: *companyID}
+	checked, due, moved, reminders, failed := 0, 0, 0, 0, 0
+
+	for {
+		page, _, err := companyRepo.List(ctx, query)
+		if err != nil {
+			log.Fatalf("Failed to list companies: %v", err)
+		}
+
+		for _, c := range page {
+			enabled, err := moduleService.IsEnabled(ctx, c.ID, module.KeyVehicleManagement)
+			if err != nil {
+				log.Fatalf("Failed to check modules of company %d: %v", c.ID, err)
+			}
+			if !enabled {
+				continue
+			}
+
+			// Keep going so one company cannot hold up the others' reminders
+			result, err := maintenanceService.CheckCompany(ctx, c.ID)
+			if err != nil {
+				log.Printf("Failed to check company %d: %v", c.ID, err)
+				failed++
+				continue
+			}
+
+			if len(result.VehiclesDue) > 0 {
+				log.Printf("Company %d: %d of %d vehicles due, %d moved to maintenance, %d reminders",
+					c.ID, len(result.VehiclesDue), result.VehiclesChecked, len(result.MovedToMaintenance), result.RemindersSent)
+			}
+			checked += result.VehiclesChecked
+			due += len(result.VehiclesDue)
+			moved += len(result.MovedToMaintenance)
+			reminders += result.RemindersSent
+		}
+
+		if len(page) < query.Limit {
+			break
+		}
+		query.Page++
+	}
+
+	log.Printf("Checked %d vehicles: %d due, %d moved to maintenance, %d reminders sent", checked, due, moved, reminders)
+	if failed > 0 {
+		log.Printf("%d companies could not be checked", failed)
+		os.Exit(1)
+	}
+}
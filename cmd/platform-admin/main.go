@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+
+	"my-go-driver/internal/app"
+	"my-go-driver/internal/config"
+	"my-go-driver/internal/domain/platform"
+	"my-go-driver/internal/domain/rbac"
+	"my-go-driver/internal/repository"
+	"my-go-driver/internal/service"
+	"my-go-driver/internal/tenant"
+)
+
+// platform-admin bootstraps a platform operator account.
+//
+//	go run ./cmd/platform-admin -name "Ops" -email ops@example.com -password secret123
+func main() {
+	name := flag.String("name", "", "operator name")
+	email := flag.String("email", "", "operator email")
+	password := flag.String("password", "", "operator password (min 8 characters)")
+	flag.Parse()
+
+	if *name == "" || *email == "" || len(*password) < 8 {
+		flag.Usage()
+		log.Fatal("name, email and a password of at least 8 characters are required")
+	}
+
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	db, err := config.NewDatabase(cfg.Database)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+
+	tokenService, err := app.NewTokenService(cfg.JWT)
+	if err != nil {
+		log.Fatalf("Failed to initialize token service: %v", err)
+	}
+
+	sessionService := service.NewSessionService(repository.NewSessionRepository(db), repository.NewCompanyRepository(db), tokenService, cfg.JWT.RefreshExpiration)
+	loginGuard, err := app.NewLoginGuard(cfg.LoginThrottle, db)
+	if err != nil {
+		log.Fatalf("Failed to initialize login guard: %v", err)
+	}
+	platformService := service.NewPlatformService(repository.NewPlatformRepository(db), sessionService, loginGuard)
+
+	admin, err := platformService.CreateAdmin(rbac.WithAllPermissions(tenant.WithPlatform(context.Background())), platform.CreateAdminRequest{
+		Name:     *name,
+		Email:    *email,
+		Password: *password,
+	})
+	if err != nil {
+		log.Fatalf("Failed to create platform admin: %v", err)
+	}
+
+	log.Printf("Platform admin %s created with ID %d", admin.Email, admin.ID)
+}
@@ -1,23 +1,78 @@
 package app
 
 import (
+	"fmt"
+
 	"my-go-driver/internal/config"
+	"my-go-driver/internal/domain/account"
+	"my-go-driver/internal/domain/apikey"
+	"my-go-driver/internal/domain/client"
+	"my-go-driver/internal/domain/company"
+	"my-go-driver/internal/domain/driver"
+	"my-go-driver/internal/domain/loginguard"
+	"my-go-driver/internal/domain/maintenance"
+	"my-go-driver/internal/domain/mfa"
+	"my-go-driver/internal/domain/module"
+	"my-go-driver/internal/domain/notification"
+	"my-go-driver/internal/domain/plan"
+	"my-go-driver/internal/domain/platform"
+	"my-go-driver/internal/domain/ratelimit"
+	"my-go-driver/internal/domain/rbac"
+	"my-go-driver/internal/domain/session"
+	"my-go-driver/internal/domain/store"
+	"my-go-driver/internal/domain/vehicle"
 	"my-go-driver/internal/handler"
 	"my-go-driver/internal/repository"
 	"my-go-driver/internal/service"
+	"my-go-driver/pkg/geocode"
+	"my-go-driver/pkg/jwt"
 	"my-go-driver/pkg/logger"
+	"my-go-driver/pkg/mail"
+	"my-go-driver/pkg/throttle"
+	"my-go-driver/pkg/tokenbucket"
 
 	"gorm.io/gorm"
 )
 
 // Container holds all application dependencies
 type Container struct {
-	Config              *config.Config
-	DB                  *gorm.DB
-	Logger              *logger.Logger
-	AdminCompanyHandler *handler.AdminCompanyHandler
-	AdminDriverHandler  *handler.AdminDriverHandler
-	AdminModuleHandler  *handler.AdminModuleHandler
+	Config                   *config.Config
+	DB                       *gorm.DB
+	Logger                   *logger.Logger
+	TokenService             *jwt.Service
+	CompanyService           company.Service
+	DriverService            driver.Service
+	PlatformService          platform.Service
+	RBACService              rbac.Service
+	SessionService           session.Service
+	AccountService           account.Service
+	MFAService               mfa.Service
+	APIKeyService            apikey.Service
+	PlanService              plan.Service
+	RateLimitService         ratelimit.Service
+	ModuleService            module.Service
+	StoreService             store.Service
+	VehicleService           vehicle.Service
+	MaintenanceService       maintenance.Service
+	ClientService            client.Service
+	NotificationService      notification.Service
+	AdminCompanyHandler      *handler.AdminCompanyHandler
+	AdminDriverHandler       *handler.AdminDriverHandler
+	AdminModuleHandler       *handler.AdminModuleHandler
+	AdminStoreHandler        *handler.AdminStoreHandler
+	AdminVehicleHandler      *handler.AdminVehicleHandler
+	AdminMaintenanceHandler  *handler.AdminMaintenanceHandler
+	AdminClientHandler       *handler.AdminClientHandler
+	PlatformAuthHandler      *handler.PlatformAuthHandler
+	AdminRoleHandler         *handler.AdminRoleHandler
+	DriverHandler            *handler.DriverHandler
+	SessionHandler           *handler.SessionHandler
+	JWKSHandler              *handler.JWKSHandler
+	AdminAccountHandler      *handler.AdminAccountHandler
+	AdminMFAHandler          *handler.AdminMFAHandler
+	AdminAPIKeyHandler       *handler.AdminAPIKeyHandler
+	AdminNotificationHandler *handler.AdminNotificationHandler
+	PlanHandler              *handler.PlanHandler
 }
 
 // NewContainer creates a new dependency injection container
@@ -45,24 +100,253 @@ func NewContainer(cfg *config.Config) (*Container, error) {
 	driverRepo := repository.NewDriverRepository(db)
 	shiftRepo := repository.NewShiftRepository(db)
 	moduleRepo := repository.NewModuleRepository(db)
+	storeRepo := repository.NewStoreRepository(db)
+	vehicleRepo := repository.NewVehicleRepository(db)
+	maintenanceRepo := repository.NewMaintenanceRepository(db)
+	notificationRepo := repository.NewNotificationRepository(db)
+	clientRepo := repository.NewClientRepository(db)
+	platformRepo := repository.NewPlatformRepository(db)
+	rbacRepo := repository.NewRBACRepository(db)
+	sessionRepo := repository.NewSessionRepository(db)
+	accountRepo := repository.NewAccountRepository(db)
+	mfaRepo := repository.NewMFARepository(db)
+	apiKeyRepo := repository.NewAPIKeyRepository(db)
+	quotaRepo := repository.NewQuotaRepository(db)
+	transactor := repository.NewTransactor(db)
 
 	// Service layer
-	companyService := service.NewCompanyService(companyRepo, cfg.JWT.Secret)
-	driverService := service.NewDriverService(driverRepo, shiftRepo)
-	shiftService := service.NewShiftService(shiftRepo)
-	moduleService := service.NewModuleService(moduleRepo)
+	tokenService, err := NewTokenService(cfg.JWT)
+	if err != nil {
+		return nil, err
+	}
+	sessionService := service.NewSessionService(sessionRepo, companyRepo, tokenService, cfg.JWT.RefreshExpiration)
+	loginGuard, err := NewLoginGuard(cfg.LoginThrottle, db)
+	if err != nil {
+		return nil, err
+	}
+	mfaService := service.NewMFAService(mfaRepo, companyRepo, cfg.MFA.Issuer)
+	quotaService := service.NewQuotaService(quotaRepo, companyRepo)
+	planService := service.NewPlanService(companyRepo, moduleRepo)
+	moduleService := service.NewModuleService(moduleRepo, companyRepo, storeRepo, planService, transactor)
+	companyService := service.NewCompanyService(companyRepo, sessionService, mfaService, loginGuard, quotaService, planService, moduleService, transactor)
+	storeService := service.NewStoreService(storeRepo)
+	vehicleService := service.NewVehicleService(vehicleRepo, driverRepo, storeService, transactor)
+	driverService := service.NewDriverService(driverRepo, companyRepo, shiftRepo, sessionService, loginGuard, quotaService, storeService, vehicleService, transactor)
+	shiftService := service.NewShiftService(shiftRepo, driverRepo, companyRepo, moduleService, vehicleService, transactor)
+	notificationService := service.NewNotificationService(notificationRepo, moduleService)
+	maintenanceService := service.NewMaintenanceService(maintenanceRepo, vehicleRepo, vehicleService, shiftRepo, notificationService, transactor)
+	geocoder, err := NewGeocoder(cfg.Geocoder)
+	if err != nil {
+		return nil, err
+	}
+	clientService := service.NewClientService(clientRepo, geocoder, transactor)
+	platformService := service.NewPlatformService(platformRepo, sessionService, loginGuard)
+	rbacService := service.NewRBACService(rbacRepo, companyRepo, moduleService)
+	apiKeyService := service.NewAPIKeyService(apiKeyRepo, companyRepo, moduleService)
+	rateLimitStore, err := newRateLimitStore(cfg.RateLimit, db)
+	if err != nil {
+		return nil, err
+	}
+	rateLimitService := service.NewRateLimitService(rateLimitStore, companyRepo, cfg.RateLimit.Window, cfg.RateLimit.APIKeyLimit, cfg.RateLimit.DriverLimit)
+
+	mailer, err := NewMailSender(cfg.Mail)
+	if err != nil {
+		return nil, err
+	}
+	resetEmails, resetIPs, err := newPasswordResetLimiters(cfg.LoginThrottle, db)
+	if err != nil {
+		return nil, err
+	}
+	accountService := service.NewAccountService(accountRepo, companyRepo, companyService, sessionService, quotaService, mailer, transactor, cfg.Mail.LinkBaseURL, resetEmails, resetIPs)
 
 	// Handler layer
 	adminCompanyHandler := handler.NewAdminCompanyHandler(companyService)
 	adminDriverHandler := handler.NewAdminDriverHandler(driverService, shiftService)
 	adminModuleHandler := handler.NewAdminModuleHandler(moduleService)
+	adminStoreHandler := handler.NewAdminStoreHandler(storeService)
+	adminVehicleHandler := handler.NewAdminVehicleHandler(vehicleService)
+	adminMaintenanceHandler := handler.NewAdminMaintenanceHandler(maintenanceService)
+	adminClientHandler := handler.NewAdminClientHandler(clientService)
+	platformAuthHandler := handler.NewPlatformAuthHandler(platformService)
+	adminRoleHandler := handler.NewAdminRoleHandler(rbacService)
+	driverHandler := handler.NewDriverHandler(driverService, shiftService)
+	sessionHandler := handler.NewSessionHandler(sessionService)
+	jwksHandler := handler.NewJWKSHandler(tokenService)
+	adminAccountHandler := handler.NewAdminAccountHandler(accountService)
+	adminMFAHandler := handler.NewAdminMFAHandler(mfaService)
+	adminAPIKeyHandler := handler.NewAdminAPIKeyHandler(apiKeyService)
+	adminNotificationHandler := handler.NewAdminNotificationHandler(notificationService)
+	planHandler := handler.NewPlanHandler(planService)
 
 	return &Container{
-		Config:              cfg,
-		DB:                  db,
-		Logger:              log,
-		AdminCompanyHandler: adminCompanyHandler,
-		AdminDriverHandler:  adminDriverHandler,
-		AdminModuleHandler:  adminModuleHandler,
+		Config:                   cfg,
+		DB:                       db,
+		Logger:                   log,
+		TokenService:             tokenService,
+		CompanyService:           companyService,
+		DriverService:            driverService,
+		PlatformService:          platformService,
+		RBACService:              rbacService,
+		SessionService:           sessionService,
+		AccountService:           accountService,
+		MFAService:               mfaService,
+		APIKeyService:            apiKeyService,
+		PlanService:              planService,
+		RateLimitService:         rateLimitService,
+		ModuleService:            moduleService,
+		StoreService:             storeService,
+		VehicleService:           vehicleService,
+		MaintenanceService:       maintenanceService,
+		ClientService:            clientService,
+		NotificationService:      notificationService,
+		AdminCompanyHandler:      adminCompanyHandler,
+		AdminDriverHandler:       adminDriverHandler,
+		AdminModuleHandler:       adminModuleHandler,
+		AdminStoreHandler:        adminStoreHandler,
+		AdminVehicleHandler:      adminVehicleHandler,
+		AdminMaintenanceHandler:  adminMaintenanceHandler,
+		AdminClientHandler:       adminClientHandler,
+		PlatformAuthHandler:      platformAuthHandler,
+		AdminRoleHandler:         adminRoleHandler,
+		DriverHandler:            driverHandler,
+		SessionHandler:           sessionHandler,
+		JWKSHandler:              jwksHandler,
+		AdminAccountHandler:      adminAccountHandler,
+		AdminMFAHandler:          adminMFAHandler,
+		AdminAPIKeyHandler:       adminAPIKeyHandler,
+		AdminNotificationHandler: adminNotificationHandler,
+		PlanHandler:              planHandler,
 	}, nil
 }
+
+// NewTokenService builds the access token service from JWT configuration,
+// loading the signing and retired keys when asymmetric signing is configured
+func NewTokenService(cfg config.JWTConfig) (*jwt.Service, error) {
+	tokenCfg := jwt.Config{
+		Secret:      cfg.Secret,
+		Issuer:      cfg.Issuer,
+		Expiration:  cfg.Expiration,
+		GracePeriod: cfg.KeyGracePeriod,
+	}
+
+	if cfg.SigningKey != "" {
+		key, err := jwt.LoadKey(cfg.SigningKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load JWT signing key: %w", err)
+		}
+		tokenCfg.SigningKey = key
+	}
+
+	for _, spec := range cfg.RetiredKeys {
+		key, err := jwt.LoadKey(spec)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load retired JWT key: %w", err)
+		}
+		tokenCfg.RetiredKeys = append(tokenCfg.RetiredKeys, key)
+	}
+
+	return jwt.NewService(tokenCfg)
+}
+
+// NewMailSender builds the outgoing mail sender selected by configuration
+func NewMailSender(cfg config.MailConfig) (mail.Sender, error) {
+	switch cfg.Driver {
+	case "smtp":
+		return mail.NewSMTPSender(mail.SMTPConfig{
+			Host:     cfg.SMTPHost,
+			Port:     cfg.SMTPPort,
+			Username: cfg.SMTPUsername,
+			Password: cfg.SMTPPassword,
+			From:     cfg.From,
+		}), nil
+	case "file":
+		return mail.NewFileSender(cfg.FileDir, cfg.From)
+	case "memory":
+		return mail.NewMemorySender(), nil
+	default:
+		return nil, fmt.Errorf("unknown mail driver %q", cfg.Driver)
+	}
+}
+
+// NewGeocoder builds the address geocoder selected by configuration
+func NewGeocoder(cfg config.GeocoderConfig) (geocode.Geocoder, error) {
+	switch cfg.Driver {
+	case "file":
+		return geocode.NewFileGeocoder(cfg.File)
+	case "memory":
+		return geocode.NewMemoryGeocoder(), nil
+	default:
+		return nil, fmt.Errorf("unknown geocoder driver %q", cfg.Driver)
+	}
+}
+
+// NewLoginGuard builds the login brute-force guard, keeping failure counters
+// in memory or in the database as configured
+func NewLoginGuard(cfg config.LoginThrottleConfig, db *gorm.DB) (loginguard.Service, error) {
+	store, err := newThrottleStore(cfg, db)
+	if err != nil {
+		return nil, err
+	}
+
+	accounts := throttle.NewLimiter(store, throttle.Policy{
+		Threshold: cfg.MaxFailures,
+		BaseDelay: cfg.LockoutBase,
+		MaxDelay:  cfg.LockoutMax,
+		Window:    cfg.FailureWindow,
+	})
+	ips := throttle.NewLimiter(store, throttle.Policy{
+		Threshold: cfg.IPMaxFailures,
+		BaseDelay: cfg.LockoutBase,
+		MaxDelay:  cfg.LockoutMax,
+		Window:    cfg.FailureWindow,
+	})
+
+	return service.NewLoginGuardService(accounts, ips, repository.NewActivityRepository(db)), nil
+}
+
+// newPasswordResetLimiters builds the limiters of password reset requests per
+// email and per client IP address, in the login throttle store
+func newPasswordResetLimiters(cfg config.LoginThrottleConfig, db *gorm.DB) (*throttle.Limiter, *throttle.Limiter, error) {
+	store, err := newThrottleStore(cfg, db)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	emails := throttle.NewLimiter(store, throttle.Policy{
+		Threshold: cfg.ResetMaxRequests,
+		BaseDelay: cfg.LockoutBase,
+		MaxDelay:  cfg.LockoutMax,
+		Window:    cfg.FailureWindow,
+	})
+	ips := throttle.NewLimiter(store, throttle.Policy{
+		Threshold: cfg.ResetIPMaxRequests,
+		BaseDelay: cfg.LockoutBase,
+		MaxDelay:  cfg.LockoutMax,
+		Window:    cfg.FailureWindow,
+	})
+	return emails, ips, nil
+}
+
+// newThrottleStore builds the failure counter store selected by configuration
+func newThrottleStore(cfg config.LoginThrottleConfig, db *gorm.DB) (throttle.Store, error) {
+	switch cfg.Store {
+	case "memory":
+		return throttle.NewMemoryStore(), nil
+	case "sql":
+		return repository.NewThrottleStore(db), nil
+	default:
+		return nil, fmt.Errorf("unknown login throttle store %q", cfg.Store)
+	}
+}
+
+// newRateLimitStore builds the API rate limit bucket store selected by configuration
+func newRateLimitStore(cfg config.RateLimitConfig, db *gorm.DB) (tokenbucket.Store, error) {
+	switch cfg.Store {
+	case "memory":
+		return tokenbucket.NewMemoryStore(), nil
+	case "sql":
+		return repository.NewRateLimitStore(db), nil
+	default:
+		return nil, fmt.Errorf("unknown rate limit store %q", cfg.Store)
+	}
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/spf13/viper"
@@ -13,6 +14,14 @@ type Config struct {
 	Server   ServerConfig
 	Database DatabaseConfig
 	JWT      JWTConfig
+	Mail     MailConfig
+	MFA      MFAConfig
+	// Geocoder configures how client addresses are resolved to coordinates
+	Geocoder GeocoderConfig
+	// LoginThrottle configures brute-force protection of login endpoints
+	LoginThrottle LoginThrottleConfig
+	// RateLimit configures per-company API rate limiting
+	RateLimit RateLimitConfig
 }
 
 // ServerConfig holds server configuration
@@ -21,6 +30,9 @@ type ServerConfig struct {
 	Environment  string
 	ReadTimeout  time.Duration
 	WriteTimeout time.Duration
+	// TrustedProxies are the proxy addresses or CIDRs whose X-Forwarded-For
+	// headers are honoured. When empty, the client IP is the peer address.
+	TrustedProxies []string
 }
 
 // DatabaseConfig holds database configuration
@@ -33,8 +45,77 @@ type DatabaseConfig struct {
 
 // JWTConfig holds JWT configuration
 type JWTConfig struct {
-	Secret     string
-	Expiration time.Duration
+	Secret            string
+	Issuer            string
+	Expiration        time.Duration
+	RefreshExpiration time.Duration
+	// SigningKey is a "kid=/path/to/key.pem" spec; when set, tokens are signed
+	// with RS256/EdDSA instead of HS256 with Secret
+	SigningKey string
+	// RetiredKeys are "kid=/path/to/key.pem@<RFC3339 retirement time>" specs
+	RetiredKeys    []string
+	KeyGracePeriod time.Duration
+}
+
+// MailConfig holds outgoing mail configuration
+type MailConfig struct {
+	// Driver selects the sender: smtp, file or memory
+	Driver       string
+	From         string
+	SMTPHost     string
+	SMTPPort     int
+	SMTPUsername string
+	SMTPPassword string
+	// FileDir is where the file driver writes .eml files
+	FileDir string
+	// LinkBaseURL is the admin dashboard URL used in emailed links
+	LinkBaseURL string
+}
+
+// MFAConfig holds two-factor authentication configuration
+type MFAConfig struct {
+	// Issuer is the account name shown in authenticator apps
+	Issuer string
+}
+
+// GeocoderConfig holds address geocoding configuration
+type GeocoderConfig struct {
+	// Driver selects the geocoder: file or memory
+	Driver string
+	// File is the JSON list of known addresses read by the file driver
+	File string
+}
+
+// LoginThrottleConfig holds login failure counting and lockout configuration
+type LoginThrottleConfig struct {
+	// Store selects where failure counters live: memory (single instance) or sql
+	Store string
+	// MaxFailures is the number of consecutive failures that locks an account
+	MaxFailures int
+	// IPMaxFailures is the number of failures that locks a client IP address
+	IPMaxFailures int
+	// LockoutBase is the first lockout; every further failure doubles it up to LockoutMax
+	LockoutBase time.Duration
+	LockoutMax  time.Duration
+	// FailureWindow is how long failures are remembered after the last one
+	FailureWindow time.Duration
+	// ResetMaxRequests and ResetIPMaxRequests are the number of password
+	// reset requests per email and per client IP address before further
+	// requests are dropped, with the same lockout and window as failures
+	ResetMaxRequests   int
+	ResetIPMaxRequests int
+}
+
+// RateLimitConfig holds API rate limiting configuration. Company limits are
+// set per company (api_rate_limit) and count requests per Window.
+type RateLimitConfig struct {
+	// Store selects where buckets live: memory (single instance) or sql
+	Store  string
+	Window time.Duration
+	// APIKeyLimit and DriverLimit cap a single API key or driver per Window;
+	// zero leaves them bound by the company limit only
+	APIKeyLimit int
+	DriverLimit int
 }
 
 // Load loads configuration from environment variables and .env file
@@ -50,7 +131,30 @@ func Load() (*Config, error) {
 	viper.SetDefault("DB_MAX_OPEN_CONNS", 25)
 	viper.SetDefault("DB_MAX_IDLE_CONNS", 5)
 	viper.SetDefault("DB_CONN_MAX_LIFETIME", 5*time.Minute)
-	viper.SetDefault("JWT_EXPIRATION", 24*time.Hour)
+	viper.SetDefault("JWT_ISSUER", "twa-driver-api")
+	viper.SetDefault("JWT_EXPIRATION", 15*time.Minute)
+	viper.SetDefault("JWT_REFRESH_EXPIRATION", 30*24*time.Hour)
+	viper.SetDefault("JWT_KEY_GRACE_PERIOD", 24*time.Hour)
+	viper.SetDefault("MAIL_DRIVER", "file")
+	viper.SetDefault("MAIL_FROM", "TWA Driver <no-reply@localhost>")
+	viper.SetDefault("MAIL_SMTP_PORT", 587)
+	viper.SetDefault("MAIL_FILE_DIR", "tmp/mail")
+	viper.SetDefault("MAIL_LINK_BASE_URL", "http://localhost:3000")
+	viper.SetDefault("MFA_ISSUER", "TWA Driver")
+	viper.SetDefault("GEOCODER_DRIVER", "file")
+	viper.SetDefault("GEOCODER_FILE", "data/geocode.json")
+	viper.SetDefault("LOGIN_THROTTLE_STORE", "memory")
+	viper.SetDefault("LOGIN_MAX_FAILURES", 5)
+	viper.SetDefault("LOGIN_IP_MAX_FAILURES", 50)
+	viper.SetDefault("LOGIN_LOCKOUT_BASE", time.Minute)
+	viper.SetDefault("LOGIN_LOCKOUT_MAX", time.Hour)
+	viper.SetDefault("LOGIN_FAILURE_WINDOW", 15*time.Minute)
+	viper.SetDefault("LOGIN_RESET_MAX_REQUESTS", 3)
+	viper.SetDefault("LOGIN_RESET_IP_MAX_REQUESTS", 20)
+	viper.SetDefault("RATE_LIMIT_STORE", "memory")
+	viper.SetDefault("RATE_LIMIT_WINDOW", time.Minute)
+	viper.SetDefault("RATE_LIMIT_API_KEY", 600)
+	viper.SetDefault("RATE_LIMIT_DRIVER", 120)
 
 	// Read config file (not mandatory)
 	if err := viper.ReadInConfig(); err != nil {
@@ -59,10 +163,11 @@ func Load() (*Config, error) {
 
 	config := &Config{
 		Server: ServerConfig{
-			Port:         viper.GetString("SERVER_PORT"),
-			Environment:  viper.GetString("SERVER_ENVIRONMENT"),
-			ReadTimeout:  viper.GetDuration("SERVER_READ_TIMEOUT"),
-			WriteTimeout: viper.GetDuration("SERVER_WRITE_TIMEOUT"),
+			Port:           viper.GetString("SERVER_PORT"),
+			Environment:    viper.GetString("SERVER_ENVIRONMENT"),
+			ReadTimeout:    viper.GetDuration("SERVER_READ_TIMEOUT"),
+			WriteTimeout:   viper.GetDuration("SERVER_WRITE_TIMEOUT"),
+			TrustedProxies: splitList(viper.GetString("SERVER_TRUSTED_PROXIES")),
 		},
 		Database: DatabaseConfig{
 			DSN:             viper.GetString("DB_DSN"),
@@ -71,8 +176,46 @@ func Load() (*Config, error) {
 			ConnMaxLifetime: viper.GetDuration("DB_CONN_MAX_LIFETIME"),
 		},
 		JWT: JWTConfig{
-			Secret:     viper.GetString("JWT_SECRET"),
-			Expiration: viper.GetDuration("JWT_EXPIRATION"),
+			Secret:            viper.GetString("JWT_SECRET"),
+			Issuer:            viper.GetString("JWT_ISSUER"),
+			Expiration:        viper.GetDuration("JWT_EXPIRATION"),
+			RefreshExpiration: viper.GetDuration("JWT_REFRESH_EXPIRATION"),
+			SigningKey:        viper.GetString("JWT_SIGNING_KEY"),
+			RetiredKeys:       splitList(viper.GetString("JWT_RETIRED_KEYS")),
+			KeyGracePeriod:    viper.GetDuration("JWT_KEY_GRACE_PERIOD"),
+		},
+		Mail: MailConfig{
+			Driver:       viper.GetString("MAIL_DRIVER"),
+			From:         viper.GetString("MAIL_FROM"),
+			SMTPHost:     viper.GetString("MAIL_SMTP_HOST"),
+			SMTPPort:     viper.GetInt("MAIL_SMTP_PORT"),
+			SMTPUsername: viper.GetString("MAIL_SMTP_USERNAME"),
+			SMTPPassword: viper.GetString("MAIL_SMTP_PASSWORD"),
+			FileDir:      viper.GetString("MAIL_FILE_DIR"),
+			LinkBaseURL:  viper.GetString("MAIL_LINK_BASE_URL"),
+		},
+		MFA: MFAConfig{
+			Issuer: viper.GetString("MFA_ISSUER"),
+		},
+		Geocoder: GeocoderConfig{
+			Driver: viper.GetString("GEOCODER_DRIVER"),
+			File:   viper.GetString("GEOCODER_FILE"),
+		},
+		LoginThrottle: LoginThrottleConfig{
+			Store:              viper.GetString("LOGIN_THROTTLE_STORE"),
+			MaxFailures:        viper.GetInt("LOGIN_MAX_FAILURES"),
+			IPMaxFailures:      viper.GetInt("LOGIN_IP_MAX_FAILURES"),
+			LockoutBase:        viper.GetDuration("LOGIN_LOCKOUT_BASE"),
+			LockoutMax:         viper.GetDuration("LOGIN_LOCKOUT_MAX"),
+			FailureWindow:      viper.GetDuration("LOGIN_FAILURE_WINDOW"),
+			ResetMaxRequests:   viper.GetInt("LOGIN_RESET_MAX_REQUESTS"),
+			ResetIPMaxRequests: viper.GetInt("LOGIN_RESET_IP_MAX_REQUESTS"),
+		},
+		RateLimit: RateLimitConfig{
+			Store:       viper.GetString("RATE_LIMIT_STORE"),
+			Window:      viper.GetDuration("RATE_LIMIT_WINDOW"),
+			APIKeyLimit: viper.GetInt("RATE_LIMIT_API_KEY"),
+			DriverLimit: viper.GetInt("RATE_LIMIT_DRIVER"),
 		},
 	}
 
@@ -80,9 +223,23 @@ func Load() (*Config, error) {
 	if config.Database.DSN == "" {
 		return nil, fmt.Errorf("DB_DSN is required")
 	}
-	if config.JWT.Secret == "" {
-		return nil, fmt.Errorf("JWT_SECRET is required")
+	if config.JWT.Secret == "" && config.JWT.SigningKey == "" {
+		return nil, fmt.Errorf("JWT_SECRET or JWT_SIGNING_KEY is required")
+	}
+	if config.RateLimit.Window <= 0 {
+		return nil, fmt.Errorf("RATE_LIMIT_WINDOW must be positive")
 	}
 
 	return config, nil
 }
+
+// splitList splits a comma separated value, dropping empty entries
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,38 @@
+package account
+
+import "my-go-driver/internal/domain/company"
+
+// InviteAdminRequest represents a request to invite a new company admin.
+// The invitee chooses their own password when accepting the invite.
+type InviteAdminRequest struct {
+	CompanyID uint64            `json:"company_id" binding:"required"`
+	FullName  string            `json:"full_name" binding:"required,min=2,max=255"`
+	Email     string            `json:"email" binding:"required,email"`
+	Phone     string            `json:"phone" binding:"omitempty,max=50"`
+	Role      company.AdminRole `json:"role" binding:"required,oneof=owner manager"`
+}
+
+// AcceptInviteRequest represents a request to accept an invite and set a password
+type AcceptInviteRequest struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required,min=8"`
+}
+
+// ForgotPasswordRequest represents a request to email a password reset link
+type ForgotPasswordRequest struct {
+	Email string `json:"email" binding:"required,email"`
+
+	// Client IP address, set by the handler and never bound
+	IPAddress string `json:"-"`
+}
+
+// ResetPasswordRequest represents a request to set a new password with a reset token
+type ResetPasswordRequest struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required,min=8"`
+}
+
+// VerifyEmailRequest represents a request to confirm an email address
+type VerifyEmailRequest struct {
+	Token string `json:"token" binding:"required"`
+}
@@ -0,0 +1,40 @@
+package account
+
+import "time"
+
+// Purpose identifies what a company admin account token may be used for
+type Purpose string
+
+const (
+	PurposeInvite            Purpose = "invite"
+	PurposePasswordReset     Purpose = "password_reset"
+	PurposeEmailVerification Purpose = "email_verification"
+)
+
+// TTL returns how long a token of this purpose stays valid
+func (p Purpose) TTL() time.Duration {
+	switch p {
+	case PurposeInvite:
+		return 72 * time.Hour
+	case PurposePasswordReset:
+		return time.Hour
+	default:
+		return 48 * time.Hour
+	}
+}
+
+// Token is a single-use, expiring token emailed to a company admin.
+// Only the SHA-256 hash of the token is stored.
+type Token struct {
+	ID        uint64     `json:"id" gorm:"primaryKey"`
+	AdminID   uint64     `json:"admin_id" gorm:"not null;index"`
+	Purpose   Purpose    `json:"purpose" gorm:"type:varchar(30);not null"`
+	TokenHash string     `json:"-" gorm:"size:64;not null;uniqueIndex"`
+	ExpiresAt time.Time  `json:"expires_at" gorm:"not null"`
+	UsedAt    *time.Time `json:"used_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+func (Token) TableName() string {
+	return "admin_tokens"
+}
@@ -0,0 +1,8 @@
+package account
+
+import "errors"
+
+var (
+	ErrInvalidToken         = errors.New("invalid or expired token")
+	ErrEmailAlreadyVerified = errors.New("email address is already verified")
+)
@@ -0,0 +1,13 @@
+package account
+
+import "context"
+
+// Repository defines the interface for admin account token data access
+type Repository interface {
+	Create(ctx context.Context, token *Token) error
+	GetByHash(ctx context.Context, tokenHash string) (*Token, error)
+	// MarkUsed marks the token as used, returning false if it had already been used
+	MarkUsed(ctx context.Context, id uint64) (bool, error)
+	// InvalidateUnused marks all unused tokens of the admin for the purpose as used
+	InvalidateUnused(ctx context.Context, adminID uint64, purpose Purpose) error
+}
@@ -0,0 +1,18 @@
+package account
+
+import (
+	"context"
+
+	"my-go-driver/internal/domain/company"
+)
+
+// Service defines the interface for company admin invitations, password
+// recovery and email verification
+type Service interface {
+	InviteAdmin(ctx context.Context, req InviteAdminRequest) (*company.CompanyAdminResponse, error)
+	AcceptInvite(ctx context.Context, req AcceptInviteRequest) error
+	ForgotPassword(ctx context.Context, req ForgotPasswordRequest) error
+	ResetPassword(ctx context.Context, req ResetPasswordRequest) error
+	SendEmailVerification(ctx context.Context, adminID uint64) error
+	VerifyEmail(ctx context.Context, req VerifyEmailRequest) error
+}
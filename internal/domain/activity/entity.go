@@ -0,0 +1,33 @@
+package activity
+
+import (
+	"time"
+
+	"my-go-driver/internal/domain/company"
+)
+
+// Actions recorded in the activity log
+const (
+	ActionLoginLockout = "auth.login_lockout"
+)
+
+// Log is an entry in the activity_logs audit trail. CompanyID is nil for
+// events that cannot be attributed to a company, such as a lockout of an IP
+// address.
+type Log struct {
+	ID         uint64          `json:"id" gorm:"primaryKey"`
+	CompanyID  *uint64         `json:"company_id"`
+	UserID     *uint64         `json:"user_id"`
+	DriverID   *uint64         `json:"driver_id"`
+	Action     string          `json:"action" gorm:"not null"`
+	EntityType string          `json:"entity_type"`
+	EntityID   *uint64         `json:"entity_id"`
+	Details    company.JSONMap `json:"details" gorm:"type:json"`
+	IPAddress  string          `json:"ip_address"`
+	UserAgent  string          `json:"user_agent" gorm:"type:text"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+func (Log) TableName() string {
+	return "activity_logs"
+}
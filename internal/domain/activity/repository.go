@@ -0,0 +1,8 @@
+package activity
+
+import "context"
+
+// Repository defines the interface for activity log data access
+type Repository interface {
+	Create(ctx context.Context, log *Log) error
+}
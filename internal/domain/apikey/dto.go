@@ -0,0 +1,39 @@
+package apikey
+
+import (
+	"time"
+
+	"my-go-driver/internal/domain/rbac"
+)
+
+// CreateAPIKeyRequest represents request to create an API key
+type CreateAPIKeyRequest struct {
+	Name   string            `json:"name" binding:"required,min=2,max=100"`
+	Scopes []rbac.Permission `json:"scopes" binding:"required,min=1"`
+	// AllowedIPs lists IP addresses or CIDR ranges; empty allows any address
+	AllowedIPs []string   `json:"allowed_ips" binding:"omitempty"`
+	ExpiresAt  *time.Time `json:"expires_at" binding:"omitempty"`
+}
+
+// APIKeyResponse represents an API key without its secret
+type APIKeyResponse struct {
+	ID         uint64            `json:"id"`
+	CompanyID  uint64            `json:"company_id"`
+	Name       string            `json:"name"`
+	Prefix     string            `json:"prefix"`
+	Scopes     []rbac.Permission `json:"scopes"`
+	AllowedIPs []string          `json:"allowed_ips"`
+	ExpiresAt  *time.Time        `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time        `json:"last_used_at,omitempty"`
+	LastUsedIP string            `json:"last_used_ip,omitempty"`
+	CreatedBy  *uint64           `json:"created_by,omitempty"`
+	RevokedAt  *time.Time        `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time         `json:"created_at"`
+}
+
+// CreatedAPIKeyResponse carries a newly created key. The key itself is only
+// ever returned here.
+type CreatedAPIKeyResponse struct {
+	APIKeyResponse
+	Key string `json:"key"`
+}
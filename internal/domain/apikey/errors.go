@@ -0,0 +1,12 @@
+package apikey
+
+import "errors"
+
+var (
+	ErrAPIKeyNotFound    = errors.New("api key not found")
+	ErrInvalidAPIKey     = errors.New("invalid or expired api key")
+	ErrIPNotAllowed      = errors.New("api key is not allowed from this ip address")
+	ErrInvalidAllowedIP  = errors.New("allowed_ips entries must be ip addresses or cidr ranges")
+	ErrExpiryInPast      = errors.New("expires_at must be in the future")
+	ErrAPIAccessDisabled = errors.New("api keys require the api_access module")
+)
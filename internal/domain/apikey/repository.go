@@ -0,0 +1,16 @@
+package apikey
+
+import (
+	"context"
+	"time"
+)
+
+// Repository defines the interface for API key data access
+type Repository interface {
+	Create(ctx context.Context, key *APIKey) error
+	GetByID(ctx context.Context, id uint64) (*APIKey, error)
+	GetByHash(ctx context.Context, keyHash string) (*APIKey, error)
+	ListByCompany(ctx context.Context, companyID uint64) ([]APIKey, error)
+	Revoke(ctx context.Context, id uint64) error
+	TouchLastUsed(ctx context.Context, id uint64, at time.Time, ip string) error
+}
@@ -0,0 +1,15 @@
+package apikey
+
+import "context"
+
+// Service defines the interface for API key business logic
+type Service interface {
+	CreateKey(ctx context.Context, companyID, createdBy uint64, req CreateAPIKeyRequest) (*CreatedAPIKeyResponse, error)
+	ListKeys(ctx context.Context, companyID uint64) ([]APIKeyResponse, error)
+	RevokeKey(ctx context.Context, companyID, keyID uint64) error
+
+	// Authenticate resolves a raw key presented from clientIP. It fails when
+	// the key is unknown, revoked or expired, used from an address outside
+	// its allowlist, or when its company no longer has the api_access module.
+	Authenticate(ctx context.Context, rawKey, clientIP string) (*APIKey, error)
+}
@@ -0,0 +1,92 @@
+package client
+
+import "time"
+
+// AddressRequest represents a saved address of a client. The address is
+// geocoded when no coordinates are given.
+type AddressRequest struct {
+	Label     string   `json:"label" binding:"omitempty,max=100"`
+	Address   string   `json:"address" binding:"required"`
+	Latitude  *float64 `json:"latitude" binding:"omitempty,min=-90,max=90"`
+	Longitude *float64 `json:"longitude" binding:"omitempty,min=-180,max=180"`
+	IsDefault bool     `json:"is_default"`
+}
+
+// UpdateAddressRequest represents request to update a saved address. A new
+// address without coordinates is geocoded again.
+type UpdateAddressRequest struct {
+	Label     string   `json:"label" binding:"omitempty,max=100"`
+	Address   string   `json:"address"`
+	Latitude  *float64 `json:"latitude" binding:"omitempty,min=-90,max=90"`
+	Longitude *float64 `json:"longitude" binding:"omitempty,min=-180,max=180"`
+	IsDefault bool     `json:"is_default"`
+}
+
+// CreateClientRequest represents request to create a new client. The first
+// address marked default, or else the first address, becomes the default.
+type CreateClientRequest struct {
+	CompanyID uint64           `json:"company_id" binding:"required"`
+	Name      string           `json:"name" binding:"required,min=2,max=255"`
+	Phone     string           `json:"phone" binding:"required,max=50"`
+	Email     string           `json:"email" binding:"omitempty,email"`
+	Addresses []AddressRequest `json:"addresses" binding:"omitempty,dive"`
+}
+
+// UpdateClientRequest represents request to update a client
+type UpdateClientRequest struct {
+	Name  string `json:"name" binding:"omitempty,min=2,max=255"`
+	Phone string `json:"phone" binding:"omitempty,max=50"`
+	Email string `json:"email" binding:"omitempty,email"`
+}
+
+// MergeClientsRequest represents request to merge duplicate clients into one
+type MergeClientsRequest struct {
+	DuplicateIDs []uint64 `json:"duplicate_ids" binding:"required,min=1,max=50"`
+}
+
+// AddressResponse represents saved address response
+type AddressResponse struct {
+	ID        uint64    `json:"id"`
+	ClientID  uint64    `json:"client_id"`
+	Label     string    `json:"label"`
+	Address   string    `json:"address"`
+	Latitude  *float64  `json:"latitude"`
+	Longitude *float64  `json:"longitude"`
+	IsDefault bool      `json:"is_default"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ClientResponse represents client response
+type ClientResponse struct {
+	ID        uint64            `json:"id"`
+	CompanyID uint64            `json:"company_id"`
+	Name      string            `json:"name"`
+	Phone     string            `json:"phone"`
+	Email     string            `json:"email"`
+	Address   string            `json:"address"`
+	Latitude  *float64          `json:"latitude"`
+	Longitude *float64          `json:"longitude"`
+	Addresses []AddressResponse `json:"addresses"`
+	CreatedAt time.Time         `json:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at"`
+}
+
+// ListClientsQuery represents query parameters for listing clients. Search
+// matches the name, email or phone number.
+type ListClientsQuery struct {
+	Page      int    `form:"page" binding:"omitempty,min=1"`
+	Limit     int    `form:"limit" binding:"omitempty,min=1,max=100"`
+	CompanyID uint64 `form:"company_id" binding:"omitempty"`
+	Phone     string `form:"phone" binding:"omitempty"`
+	Search    string `form:"search" binding:"omitempty"`
+}
+
+// PaginatedClientsResponse represents paginated clients response
+type PaginatedClientsResponse struct {
+	Clients    []ClientResponse `json:"clients"`
+	TotalCount int64            `json:"total_count"`
+	Page       int              `json:"page"`
+	Limit      int              `json:"limit"`
+	TotalPages int              `json:"total_pages"`
+}
@@ -0,0 +1,73 @@
+package client
+
+import (
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Client is a customer of a company that orders are delivered to. Address
+// and coordinates mirror the client's default saved address.
+type Client struct {
+	ID        uint64    `json:"id" gorm:"primaryKey"`
+	CompanyID uint64    `json:"company_id" gorm:"not null"`
+	Name      string    `json:"name" gorm:"not null"`
+	Phone     string    `json:"phone" gorm:"not null"`
+	Email     string    `json:"email"`
+	Address   string    `json:"address" gorm:"type:text"`
+	Latitude  *float64  `json:"latitude" gorm:"type:decimal(10,8)"`
+	Longitude *float64  `json:"longitude" gorm:"type:decimal(11,8)"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (Client) TableName() string {
+	return "clients"
+}
+
+// Address is a saved delivery address of a client. Coordinates are left
+// empty when the address could not be geocoded.
+type Address struct {
+	ID        uint64    `json:"id" gorm:"primaryKey"`
+	ClientID  uint64    `json:"client_id" gorm:"not null"`
+	Label     string    `json:"label"`
+	Address   string    `json:"address" gorm:"type:text;not null"`
+	Latitude  *float64  `json:"latitude" gorm:"type:decimal(10,8)"`
+	Longitude *float64  `json:"longitude" gorm:"type:decimal(11,8)"`
+	IsDefault bool      `json:"is_default" gorm:"default:false"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (Address) TableName() string {
+	return "client_addresses"
+}
+
+// SameAs reports whether both addresses read the same, ignoring case and
+// spacing
+func (a *Address) SameAs(other *Address) bool {
+	return strings.EqualFold(strings.Join(strings.Fields(a.Address), " "), strings.Join(strings.Fields(other.Address), " "))
+}
+
+// NormalizePhone keeps the digits of a phone number and its leading "+", so
+// the same number is stored and searched one way. It returns "" when the
+// number has no digits.
+func NormalizePhone(phone string) string {
+	phone = strings.TrimSpace(phone)
+
+	var b strings.Builder
+	if strings.HasPrefix(phone, "+") {
+		b.WriteByte('+')
+	}
+	digits := 0
+	for _, r := range phone {
+		if unicode.IsDigit(r) {
+			b.WriteRune(r)
+			digits++
+		}
+	}
+	if digits == 0 {
+		return ""
+	}
+	return b.String()
+}
@@ -0,0 +1,13 @@
+package client
+
+import "errors"
+
+var (
+	ErrClientNotFound        = errors.New("client not found")
+	ErrAddressNotFound       = errors.New("client address not found")
+	ErrPhoneTaken            = errors.New("phone number already used by another client")
+	ErrInvalidPhone          = errors.New("phone number must contain digits")
+	ErrIncompleteCoordinates = errors.New("latitude and longitude must be set together")
+	ErrClientHasOrders       = errors.New("client has orders; merge it into another client instead")
+	ErrMergeIntoSelf         = errors.New("cannot merge a client into itself")
+)
@@ -0,0 +1,28 @@
+package client
+
+import "context"
+
+// Repository defines the interface for client data access
+type Repository interface {
+	Create(ctx context.Context, client *Client) error
+	GetByID(ctx context.Context, id uint64) (*Client, error)
+	GetByIDForUpdate(ctx context.Context, id uint64) (*Client, error)
+	GetByPhone(ctx context.Context, phone string, companyID uint64) (*Client, error)
+	Update(ctx context.Context, client *Client) error
+	Delete(ctx context.Context, id uint64) error
+	List(ctx context.Context, query ListClientsQuery) ([]Client, int64, error)
+
+	// CountOrders returns the number of orders placed by the client
+	CountOrders(ctx context.Context, clientID uint64) (int64, error)
+	// ReassignOrders moves the orders of the clients in fromIDs to toID
+	ReassignOrders(ctx context.Context, fromIDs []uint64, toID uint64) error
+
+	CreateAddress(ctx context.Context, address *Address) error
+	GetAddress(ctx context.Context, id uint64) (*Address, error)
+	// ListAddresses returns the addresses of the given clients, default first
+	ListAddresses(ctx context.Context, clientIDs []uint64) ([]Address, error)
+	UpdateAddress(ctx context.Context, address *Address) error
+	DeleteAddress(ctx context.Context, id uint64) error
+	// SetDefaultAddress makes addressID the only default address of the client
+	SetDefaultAddress(ctx context.Context, clientID, addressID uint64) error
+}
@@ -0,0 +1,21 @@
+package client
+
+import "context"
+
+// Service defines the interface for client business logic
+type Service interface {
+	CreateClient(ctx context.Context, req CreateClientRequest) (*ClientResponse, error)
+	GetClient(ctx context.Context, id uint64) (*ClientResponse, error)
+	UpdateClient(ctx context.Context, id uint64, req UpdateClientRequest) (*ClientResponse, error)
+	DeleteClient(ctx context.Context, id uint64) error
+	ListClients(ctx context.Context, query ListClientsQuery) (*PaginatedClientsResponse, error)
+
+	// MergeClients merges duplicate clients into the client id: their orders
+	// and addresses move to it and the duplicates are deleted
+	MergeClients(ctx context.Context, id uint64, req MergeClientsRequest) (*ClientResponse, error)
+
+	AddAddress(ctx context.Context, clientID uint64, req AddressRequest) (*AddressResponse, error)
+	ListAddresses(ctx context.Context, clientID uint64) ([]AddressResponse, error)
+	UpdateAddress(ctx context.Context, clientID, addressID uint64, req UpdateAddressRequest) (*AddressResponse, error)
+	DeleteAddress(ctx context.Context, clientID, addressID uint64) error
+}
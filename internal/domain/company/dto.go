@@ -73,6 +73,9 @@ type UpdateCompanyRequest struct {
 	BillingCycle BillingCycle  `json:"billing_cycle" binding:"omitempty,oneof=monthly yearly"`
 	SeatsLimit   *int          `json:"seats_limit" binding:"omitempty,min=1"`
 	APIRateLimit *int          `json:"api_rate_limit" binding:"omitempty,min=0"`
+
+	// Security
+	RequireOwnerMFA *bool `json:"require_owner_mfa" binding:"omitempty"`
 }
 
 // UpdateBrandingRequest represents request to update company branding
@@ -143,10 +146,35 @@ type CompanyResponse struct {
 	SeatsLimit   int           `json:"seats_limit"`
 	APIRateLimit int           `json:"api_rate_limit"`
 
+	// Security
+	RequireOwnerMFA bool `json:"require_owner_mfa"`
+
 	// Status & Audit
 	Status    CompanyStatus `json:"status"`
 	CreatedAt time.Time     `json:"created_at"`
 	UpdatedAt time.Time     `json:"updated_at"`
+
+	// PlanChange is set when an update moved the company to another plan
+	PlanChange *PlanChange `json:"plan_change,omitempty"`
+}
+
+// PlanChange describes the module entitlements affected by a plan change
+type PlanChange struct {
+	From BillingPlan `json:"from"`
+	To   BillingPlan `json:"to"`
+	// ModulesToRemove are assigned modules the new plan does not include
+	ModulesToRemove []string `json:"modules_to_remove"`
+	// ModulesUnlocked are modules the new plan includes and the old one did not
+	ModulesUnlocked []string `json:"modules_unlocked"`
+	// QuotaChanges are the quotas moved from the old plan's defaults to the new plan's
+	QuotaChanges []QuotaChange `json:"quota_changes"`
+}
+
+// QuotaChange describes a quota changed by a plan change
+type QuotaChange struct {
+	Quota string `json:"quota"`
+	From  int    `json:"from"`
+	To    int    `json:"to"`
 }
 
 // CompanyWithOwnerResponse includes the owner information
@@ -157,15 +185,17 @@ type CompanyWithOwnerResponse struct {
 
 // CompanyAdminResponse represents company admin response
 type CompanyAdminResponse struct {
-	ID        uint64    `json:"id"`
-	CompanyID uint64    `json:"company_id"`
-	FullName  string    `json:"full_name"`
-	Email     string    `json:"email"`
-	Phone     string    `json:"phone"`
-	Role      AdminRole `json:"role"`
-	IsActive  bool      `json:"is_active"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID              uint64     `json:"id"`
+	CompanyID       uint64     `json:"company_id"`
+	FullName        string     `json:"full_name"`
+	Email           string     `json:"email"`
+	Phone           string     `json:"phone"`
+	Role            AdminRole  `json:"role"`
+	CustomRoleID    *uint64    `json:"custom_role_id,omitempty"`
+	IsActive        bool       `json:"is_active"`
+	EmailVerifiedAt *time.Time `json:"email_verified_at"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
 }
 
 // ListCompaniesQuery represents query parameters for listing companies
@@ -174,6 +204,9 @@ type ListCompaniesQuery struct {
 	Limit  int           `form:"limit" binding:"omitempty,min=1,max=100"`
 	Status CompanyStatus `form:"status" binding:"omitempty,oneof=active suspended"`
 	Search string        `form:"search" binding:"omitempty"`
+
+	// ID restricts the listing to a single company (set by the service, never bound)
+	ID uint64 `form:"-"`
 }
 
 // PaginatedCompaniesResponse represents paginated companies response
@@ -199,10 +232,41 @@ type CreateAdminRequest struct {
 type LoginRequest struct {
 	Email    string `json:"email" binding:"required,email"`
 	Password string `json:"password" binding:"required"`
+
+	// Client details, set by the handler and never bound
+	IPAddress string `json:"-"`
+	UserAgent string `json:"-"`
 }
 
-// LoginResponse represents login response with token
+// LoginResponse represents login response with token. When a second factor
+// is needed, only MFA is set and the login is completed with MFALoginRequest.
 type LoginResponse struct {
-	Admin CompanyAdminResponse `json:"admin"`
-	Token string               `json:"token"`
+	Admin        *CompanyAdminResponse `json:"admin,omitempty"`
+	Token        string                `json:"token,omitempty"`
+	RefreshToken string                `json:"refresh_token,omitempty"`
+	ExpiresAt    *time.Time            `json:"expires_at,omitempty"`
+	MFARequired  bool                  `json:"mfa_required"`
+	MFA          *MFAChallenge         `json:"mfa,omitempty"`
+	// RecoveryCodes are returned once when MFA enrollment is completed during login
+	RecoveryCodes []string `json:"recovery_codes,omitempty"`
+}
+
+// MFAChallenge is the short-lived token a login returns in place of tokens
+// when the admin must still pass a second factor
+type MFAChallenge struct {
+	Token     string    `json:"mfa_token"`
+	ExpiresAt time.Time `json:"expires_at"`
+	// EnrollmentRequired is set when the company requires MFA but the admin
+	// has not enrolled yet; enrollment is completed as part of the login
+	EnrollmentRequired bool `json:"enrollment_required"`
+}
+
+// MFALoginRequest completes a login with a TOTP code or a recovery code
+type MFALoginRequest struct {
+	MFAToken string `json:"mfa_token" binding:"required"`
+	Code     string `json:"code" binding:"required"`
+
+	// Client details, set by the handler and never bound
+	IPAddress string `json:"-"`
+	UserAgent string `json:"-"`
 }
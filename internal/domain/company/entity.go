@@ -196,6 +196,9 @@ type Company struct {
 	SeatsLimit   int          `json:"seats_limit" gorm:"default:10"`
 	APIRateLimit int          `json:"api_rate_limit" gorm:"default:1000"`
 
+	// Security
+	RequireOwnerMFA bool `json:"require_owner_mfa" gorm:"column:require_owner_mfa;default:false"`
+
 	// Status & Audit
 	Status    CompanyStatus `json:"status" gorm:"type:enum('active','suspended');default:active"`
 	CreatedAt time.Time     `json:"created_at"`
@@ -216,10 +219,13 @@ type CompanyAdmin struct {
 	Phone        string    `json:"phone"`
 	PasswordHash string    `json:"-" gorm:"not null"`
 	Role         AdminRole `json:"role" gorm:"type:enum('owner','manager');default:manager"`
+	CustomRoleID *uint64   `json:"custom_role_id"`
 	IsActive     bool       `json:"is_active" gorm:"default:true"`
-	CreatedAt    time.Time  `json:"created_at"`
-	UpdatedAt    time.Time  `json:"updated_at"`
-	DeletedAt    *time.Time `json:"deleted_at,omitempty" gorm:"index"`
+	// EmailVerifiedAt is set once the admin proves ownership of their email
+	EmailVerifiedAt *time.Time `json:"email_verified_at"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+	DeletedAt       *time.Time `json:"deleted_at,omitempty" gorm:"index"`
 
 	// Relations
 	Company *Company `json:"company,omitempty" gorm:"foreignKey:CompanyID"`
@@ -0,0 +1,9 @@
+package company
+
+import "errors"
+
+var (
+	ErrCompanyNotFound  = errors.New("company not found")
+	ErrAdminNotFound    = errors.New("admin not found")
+	ErrCompanySuspended = errors.New("company is suspended")
+)
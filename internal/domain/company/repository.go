@@ -7,6 +7,8 @@ type Repository interface {
 	// Company operations
 	Create(ctx context.Context, company *Company) error
 	GetByID(ctx context.Context, id uint64) (*Company, error)
+	// GetByIDForUpdate loads a company and locks it until the transaction ends
+	GetByIDForUpdate(ctx context.Context, id uint64) (*Company, error)
 	Update(ctx context.Context, company *Company) error
 	Delete(ctx context.Context, id uint64) error
 	List(ctx context.Context, query ListCompaniesQuery) ([]Company, int64, error)
@@ -17,5 +19,6 @@ type Repository interface {
 	CreateAdmin(ctx context.Context, admin *CompanyAdmin) error
 	GetAdminByEmail(ctx context.Context, email string) (*CompanyAdmin, error)
 	GetAdminByID(ctx context.Context, id uint64) (*CompanyAdmin, error)
+	UpdateAdmin(ctx context.Context, admin *CompanyAdmin) error
 	ListAdmins(ctx context.Context, companyID uint64) ([]CompanyAdmin, error)
 }
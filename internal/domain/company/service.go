@@ -14,8 +14,15 @@ type Service interface {
 	SuspendCompany(ctx context.Context, id uint64) error
 	ActivateCompany(ctx context.Context, id uint64) error
 
+	// CheckCompanyActive returns ErrCompanySuspended unless the company is
+	// active. Its admins and drivers cannot sign in or use their sessions.
+	CheckCompanyActive(ctx context.Context, id uint64) error
+
 	// Company Admin operations
 	CreateAdmin(ctx context.Context, req CreateAdminRequest) (*CompanyAdminResponse, error)
 	LoginAdmin(ctx context.Context, req LoginRequest) (*LoginResponse, error)
+	CompleteMFALogin(ctx context.Context, req MFALoginRequest) (*LoginResponse, error)
 	GetAdminProfile(ctx context.Context, adminID uint64) (*CompanyAdminResponse, error)
+	DeactivateAdmin(ctx context.Context, adminID uint64) (*CompanyAdminResponse, error)
+	ActivateAdmin(ctx context.Context, adminID uint64) (*CompanyAdminResponse, error)
 }
@@ -28,6 +28,32 @@ type AssignDriverToCompanyRequest struct {
 	StoreID   *uint64 `json:"store_id"`
 }
 
+// LoginRequest represents driver login request
+type LoginRequest struct {
+	CompanyID uint64 `json:"company_id" binding:"required"`
+	Phone     string `json:"phone" binding:"required,max=50"`
+	Password  string `json:"password" binding:"required"`
+
+	// Client details, set by the handler and never bound
+	IPAddress string `json:"-"`
+	UserAgent string `json:"-"`
+}
+
+// LoginResponse represents driver login response with token
+type LoginResponse struct {
+	Driver       DriverResponse `json:"driver"`
+	Token        string         `json:"token"`
+	RefreshToken string         `json:"refresh_token"`
+	ExpiresAt    time.Time      `json:"expires_at"`
+}
+
+// UpdateProfileRequest represents a driver's self-service profile update
+type UpdateProfileRequest struct {
+	FullName     string `json:"full_name" binding:"omitempty,min=2,max=255"`
+	Email        string `json:"email" binding:"omitempty,email"`
+	ProfilePhoto string `json:"profile_photo" binding:"omitempty,url"`
+}
+
 // DriverResponse represents driver response
 type DriverResponse struct {
 	ID           uint64       `json:"id"`
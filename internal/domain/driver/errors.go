@@ -0,0 +1,8 @@
+package driver
+
+import "errors"
+
+var (
+	ErrDriverNotFound  = errors.New("driver not found")
+	ErrDriverSuspended = errors.New("driver account is suspended")
+)
@@ -6,10 +6,13 @@ import "context"
 type Repository interface {
 	Create(ctx context.Context, driver *Driver) error
 	GetByID(ctx context.Context, id uint64) (*Driver, error)
+	// GetByIDForUpdate loads the driver, locking it until the transaction ends
+	GetByIDForUpdate(ctx context.Context, id uint64) (*Driver, error)
 	GetByPhone(ctx context.Context, phone string, companyID uint64) (*Driver, error)
 	Update(ctx context.Context, driver *Driver) error
 	Delete(ctx context.Context, id uint64) error
 	List(ctx context.Context, query ListDriversQuery) ([]Driver, int64, error)
 	UpdateStatus(ctx context.Context, id uint64, status DriverStatus) error
+	UpdateOnlineStatus(ctx context.Context, id uint64, status OnlineStatus) error
 	GetPerformance(ctx context.Context, driverID uint64) (*DriverPerformance, error)
 }
@@ -13,4 +13,9 @@ type Service interface {
 	BlockDriver(ctx context.Context, driverID uint64) error
 	UnblockDriver(ctx context.Context, driverID uint64) error
 	GetDriverPerformance(ctx context.Context, driverID uint64) (*DriverPerformance, error)
+
+	// Driver-facing operations
+	LoginDriver(ctx context.Context, req LoginRequest) (*LoginResponse, error)
+	UpdateProfile(ctx context.Context, driverID uint64, req UpdateProfileRequest) (*DriverResponse, error)
+	SetOnlineStatus(ctx context.Context, driverID uint64, status OnlineStatus) (*DriverResponse, error)
 }
@@ -0,0 +1,16 @@
+package loginguard
+
+import "my-go-driver/pkg/jwt"
+
+// Attempt describes a login attempt. CompanyID and SubjectID are set once
+// the credentials have been matched to an account.
+type Attempt struct {
+	SubjectType jwt.SubjectType
+	// Identifier is what the caller logs in with: an email, or a company
+	// scoped phone number for drivers
+	Identifier string
+	IPAddress  string
+	UserAgent  string
+	CompanyID  *uint64
+	SubjectID  *uint64
+}
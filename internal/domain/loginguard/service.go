@@ -0,0 +1,16 @@
+package loginguard
+
+import "context"
+
+// Service guards login endpoints against brute force by counting failures
+// per account and per IP address
+type Service interface {
+	// Check returns a *throttle.LockedError when the account or the IP
+	// address is locked out
+	Check(ctx context.Context, attempt Attempt) error
+	// Failed records a failed attempt, locking out the account or IP
+	// address once it fails too often
+	Failed(ctx context.Context, attempt Attempt) error
+	// Succeeded clears the account's failures
+	Succeeded(ctx context.Context, attempt Attempt) error
+}
@@ -0,0 +1,101 @@
+package maintenance
+
+import "time"
+
+// CreateRuleRequest represents request to create a maintenance rule
+type CreateRuleRequest struct {
+	CompanyID        uint64   `json:"company_id" binding:"required"`
+	ServiceType      string   `json:"service_type" binding:"required,max=100"`
+	IntervalDays     *int     `json:"interval_days" binding:"omitempty,min=1"`
+	IntervalDistance *float64 `json:"interval_distance" binding:"omitempty,gt=0"`
+	Action           Action   `json:"action" binding:"omitempty,oneof=flag maintenance"`
+}
+
+// UpdateRuleRequest represents request to update a maintenance rule. An
+// interval of 0 removes it.
+type UpdateRuleRequest struct {
+	IntervalDays     *int     `json:"interval_days" binding:"omitempty,min=0"`
+	IntervalDistance *float64 `json:"interval_distance" binding:"omitempty,min=0"`
+	Action           Action   `json:"action" binding:"omitempty,oneof=flag maintenance"`
+}
+
+// RuleResponse represents maintenance rule response
+type RuleResponse struct {
+	ID               uint64    `json:"id"`
+	CompanyID        uint64    `json:"company_id"`
+	ServiceType      string    `json:"service_type"`
+	IntervalDays     *int      `json:"interval_days"`
+	IntervalDistance *float64  `json:"interval_distance"`
+	Action           Action    `json:"action"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+// ListRulesQuery represents query parameters for listing maintenance rules
+type ListRulesQuery struct {
+	CompanyID uint64 `form:"company_id" binding:"omitempty"`
+}
+
+// CreateRecordRequest represents request to record a service of a vehicle
+type CreateRecordRequest struct {
+	ServiceType string   `json:"service_type" binding:"required,max=100"`
+	Odometer    *float64 `json:"odometer" binding:"omitempty,min=0"`
+	Cost        *float64 `json:"cost" binding:"omitempty,min=0"`
+	ServicedAt  string   `json:"serviced_at" binding:"required,datetime=2006-01-02"`
+	Notes       string   `json:"notes" binding:"omitempty"`
+}
+
+// RecordResponse represents maintenance record response
+type RecordResponse struct {
+	ID          uint64    `json:"id"`
+	VehicleID   uint64    `json:"vehicle_id"`
+	ServiceType string    `json:"service_type"`
+	Odometer    *float64  `json:"odometer"`
+	Cost        *float64  `json:"cost"`
+	ServicedAt  string    `json:"serviced_at"`
+	Notes       string    `json:"notes"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// ListRecordsQuery represents query parameters for listing a vehicle's
+// maintenance records. VehicleID is taken from the path.
+type ListRecordsQuery struct {
+	Page        int    `form:"page" binding:"omitempty,min=1"`
+	Limit       int    `form:"limit" binding:"omitempty,min=1,max=100"`
+	VehicleID   uint64 `form:"-"`
+	ServiceType string `form:"service_type" binding:"omitempty"`
+}
+
+// PaginatedRecordsResponse represents paginated maintenance records response
+type PaginatedRecordsResponse struct {
+	Records    []RecordResponse `json:"records"`
+	TotalCount int64            `json:"total_count"`
+	Page       int              `json:"page"`
+	Limit      int              `json:"limit"`
+	TotalPages int              `json:"total_pages"`
+}
+
+// ScheduleItem is where a vehicle stands against one maintenance rule
+type ScheduleItem struct {
+	ServiceType    string   `json:"service_type"`
+	Action         Action   `json:"action"`
+	LastServicedAt *string  `json:"last_serviced_at"`
+	DistanceSince  float64  `json:"distance_since"`
+	NextDueDate    *string  `json:"next_due_date"`
+	DistanceLeft   *float64 `json:"distance_left"`
+	IsDue          bool     `json:"is_due"`
+}
+
+// RunCheckRequest represents request to run the maintenance check for a company
+type RunCheckRequest struct {
+	CompanyID uint64 `json:"company_id" binding:"required"`
+}
+
+// CheckResult summarizes a maintenance check of a company's fleet
+type CheckResult struct {
+	CompanyID          uint64   `json:"company_id"`
+	VehiclesChecked    int      `json:"vehicles_checked"`
+	VehiclesDue        []uint64 `json:"vehicles_due"`
+	MovedToMaintenance []uint64 `json:"moved_to_maintenance"`
+	RemindersSent      int      `json:"reminders_sent"`
+}
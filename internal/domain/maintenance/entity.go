@@ -0,0 +1,71 @@
+package maintenance
+
+import "time"
+
+// Action is what the maintenance check does with a vehicle due for a service
+type Action string
+
+const (
+	// ActionFlag lists the service in the vehicle's maintenance_due
+	ActionFlag Action = "flag"
+	// ActionMaintenance also moves the vehicle to maintenance, once its driver
+	// is off shift
+	ActionMaintenance Action = "maintenance"
+)
+
+// ServiceTypeOilChange is the service type whose records also set the
+// vehicle's last oil change
+const ServiceTypeOilChange = "oil_change"
+
+// Rule schedules a service for every vehicle of a company, every IntervalDays
+// days or IntervalDistance km driven, whichever comes first
+type Rule struct {
+	ID               uint64    `json:"id" gorm:"primaryKey"`
+	CompanyID        uint64    `json:"company_id" gorm:"not null"`
+	ServiceType      string    `json:"service_type" gorm:"not null"`
+	IntervalDays     *int      `json:"interval_days"`
+	IntervalDistance *float64  `json:"interval_distance" gorm:"type:decimal(10,2)"`
+	Action           Action    `json:"action" gorm:"type:enum('flag','maintenance');default:flag"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+func (Rule) TableName() string {
+	return "vehicle_maintenance_rules"
+}
+
+// NextDueDate returns when a vehicle last serviced at lastService is due by
+// time, or nil when the rule has no day interval
+func (r Rule) NextDueDate(lastService time.Time) *time.Time {
+	if r.IntervalDays == nil {
+		return nil
+	}
+	due := lastService.AddDate(0, 0, *r.IntervalDays)
+	return &due
+}
+
+// IsDue reports whether a vehicle last serviced at lastService, having driven
+// distance since, is due for the service at now
+func (r Rule) IsDue(lastService time.Time, distance float64, now time.Time) bool {
+	if due := r.NextDueDate(lastService); due != nil && !now.Before(*due) {
+		return true
+	}
+	return r.IntervalDistance != nil && distance >= *r.IntervalDistance
+}
+
+// Record is a service carried out on a vehicle
+type Record struct {
+	ID          uint64    `json:"id" gorm:"primaryKey"`
+	CompanyID   uint64    `json:"company_id" gorm:"not null"`
+	VehicleID   uint64    `json:"vehicle_id" gorm:"not null"`
+	ServiceType string    `json:"service_type" gorm:"not null"`
+	Odometer    *float64  `json:"odometer" gorm:"type:decimal(12,2)"`
+	Cost        *float64  `json:"cost" gorm:"type:decimal(10,2)"`
+	ServicedAt  time.Time `json:"serviced_at" gorm:"type:date;not null"`
+	Notes       string    `json:"notes" gorm:"type:text"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func (Record) TableName() string {
+	return "vehicle_maintenance_records"
+}
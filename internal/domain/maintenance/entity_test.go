@@ -0,0 +1,112 @@
+package maintenance
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRuleNextDueDate(t *testing.T) {
+	days := 90
+	lastService := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		rule Rule
+		want *time.Time
+	}{
+		{
+			name: "day interval",
+			rule: Rule{IntervalDays: &days},
+			want: ptrTime(time.Date(2024, 4, 14, 0, 0, 0, 0, time.UTC)),
+		},
+		{
+			name: "distance interval only",
+			rule: Rule{IntervalDistance: ptrFloat(5000)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.rule.NextDueDate(lastService)
+			if (got == nil) != (tt.want == nil) || (got != nil && !got.Equal(*tt.want)) {
+				t.Fatalf("NextDueDate = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRuleIsDue(t *testing.T) {
+	days := 30
+	lastService := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	beforeDue := lastService.AddDate(0, 0, 29)
+	onDue := lastService.AddDate(0, 0, 30)
+
+	tests := []struct {
+		name     string
+		rule     Rule
+		distance float64
+		now      time.Time
+		want     bool
+	}{
+		{
+			name: "days not yet elapsed",
+			rule: Rule{IntervalDays: &days},
+			now:  beforeDue,
+		},
+		{
+			name: "due on the day the interval ends",
+			rule: Rule{IntervalDays: &days},
+			now:  onDue,
+			want: true,
+		},
+		{
+			name:     "distance not yet driven",
+			rule:     Rule{IntervalDistance: ptrFloat(1000)},
+			distance: 999.5,
+			now:      onDue,
+		},
+		{
+			name:     "distance driven",
+			rule:     Rule{IntervalDistance: ptrFloat(1000)},
+			distance: 1000,
+			now:      beforeDue,
+			want:     true,
+		},
+		{
+			name:     "neither interval reached",
+			rule:     Rule{IntervalDays: &days, IntervalDistance: ptrFloat(1000)},
+			distance: 500,
+			now:      beforeDue,
+		},
+		{
+			name:     "distance reached before the days",
+			rule:     Rule{IntervalDays: &days, IntervalDistance: ptrFloat(1000)},
+			distance: 1200,
+			now:      beforeDue,
+			want:     true,
+		},
+		{
+			name:     "days reached before the distance",
+			rule:     Rule{IntervalDays: &days, IntervalDistance: ptrFloat(1000)},
+			distance: 500,
+			now:      onDue,
+			want:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rule.IsDue(lastService, tt.distance, tt.now); got != tt.want {
+				t.Fatalf("IsDue = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func ptrTime(t time.Time) *time.Time {
+	return &t
+}
+
+func ptrFloat(f float64) *float64 {
+	return &f
+}
@@ -0,0 +1,10 @@
+package maintenance
+
+import "errors"
+
+var (
+	ErrRuleNotFound        = errors.New("maintenance rule not found")
+	ErrRuleExists          = errors.New("a maintenance rule for this service type already exists")
+	ErrNoInterval          = errors.New("a maintenance rule needs a day or distance interval")
+	ErrServiceDateInFuture = errors.New("service date cannot be in the future")
+)
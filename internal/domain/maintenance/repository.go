@@ -0,0 +1,22 @@
+package maintenance
+
+import (
+	"context"
+	"time"
+)
+
+// Repository defines the interface for maintenance data access
+type Repository interface {
+	CreateRule(ctx context.Context, rule *Rule) error
+	GetRuleByID(ctx context.Context, id uint64) (*Rule, error)
+	GetRuleByServiceType(ctx context.Context, companyID uint64, serviceType string) (*Rule, error)
+	UpdateRule(ctx context.Context, rule *Rule) error
+	DeleteRule(ctx context.Context, id uint64) error
+	ListRules(ctx context.Context, companyID uint64) ([]Rule, error)
+
+	CreateRecord(ctx context.Context, record *Record) error
+	ListRecords(ctx context.Context, query ListRecordsQuery) ([]Record, int64, error)
+	// LastServiceDates returns the date of the latest record of each service
+	// type for the vehicle
+	LastServiceDates(ctx context.Context, vehicleID uint64) (map[string]time.Time, error)
+}
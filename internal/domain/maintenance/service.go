@@ -0,0 +1,23 @@
+package maintenance
+
+import "context"
+
+// Service defines the interface for vehicle maintenance business logic
+type Service interface {
+	CreateRule(ctx context.Context, req CreateRuleRequest) (*RuleResponse, error)
+	ListRules(ctx context.Context, query ListRulesQuery) ([]RuleResponse, error)
+	UpdateRule(ctx context.Context, id uint64, req UpdateRuleRequest) (*RuleResponse, error)
+	DeleteRule(ctx context.Context, id uint64) error
+
+	// RecordService logs a service of the vehicle and clears it from the
+	// vehicle's due services
+	RecordService(ctx context.Context, vehicleID uint64, req CreateRecordRequest) (*RecordResponse, error)
+	ListRecords(ctx context.Context, query ListRecordsQuery) (*PaginatedRecordsResponse, error)
+	// GetSchedule evaluates the company's rules for the vehicle
+	GetSchedule(ctx context.Context, vehicleID uint64) ([]ScheduleItem, error)
+
+	// CheckCompany flags the company's vehicles that are due for a service,
+	// moves them to maintenance where a rule says so, and sends a reminder
+	// for each vehicle with newly due services
+	CheckCompany(ctx context.Context, companyID uint64) (*CheckResult, error)
+}
@@ -0,0 +1,34 @@
+package mfa
+
+import "time"
+
+// CodeRequest carries a TOTP code, or a recovery code where accepted
+type CodeRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+// ChallengeEnrollRequest starts enrollment during a login that requires MFA
+type ChallengeEnrollRequest struct {
+	MFAToken string `json:"mfa_token" binding:"required"`
+}
+
+// EnrollResponse carries the secret to add to an authenticator app
+type EnrollResponse struct {
+	Secret string `json:"secret"`
+	// ProvisioningURI is the otpauth:// URI to render as a QR code
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+// RecoveryCodesResponse carries freshly issued recovery codes. They are only
+// ever shown once.
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// StatusResponse describes an admin's MFA state
+type StatusResponse struct {
+	Enabled                bool       `json:"enabled"`
+	ConfirmedAt            *time.Time `json:"confirmed_at,omitempty"`
+	Required               bool       `json:"required"`
+	RecoveryCodesRemaining int64      `json:"recovery_codes_remaining"`
+}
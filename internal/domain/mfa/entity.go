@@ -0,0 +1,69 @@
+package mfa
+
+import "time"
+
+const (
+	// ChallengeTTL is how long a login challenge token stays valid
+	ChallengeTTL = 5 * time.Minute
+	// MaxChallengeAttempts is the number of wrong codes a challenge tolerates
+	MaxChallengeAttempts = 5
+	// RecoveryCodeCount is the number of recovery codes issued at a time
+	RecoveryCodeCount = 10
+)
+
+// Enrollment holds a company admin's TOTP secret. It only protects logins
+// once confirmed with a first valid code.
+type Enrollment struct {
+	AdminID     uint64     `json:"admin_id" gorm:"primaryKey;autoIncrement:false"`
+	Secret      string     `json:"-" gorm:"size:64;not null"`
+	ConfirmedAt *time.Time `json:"confirmed_at"`
+	// LastUsedStep is the TOTP time step of the last accepted code, so a code
+	// cannot be replayed within its validity window
+	LastUsedStep uint64    `json:"-" gorm:"not null;default:0"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+func (Enrollment) TableName() string {
+	return "admin_mfa"
+}
+
+// IsEnabled reports whether the enrollment has been confirmed
+func (e *Enrollment) IsEnabled() bool {
+	return e != nil && e.ConfirmedAt != nil
+}
+
+// RecoveryCode is a single-use code that can replace a TOTP code, for when
+// the admin loses their authenticator. Only its SHA-256 hash is stored.
+type RecoveryCode struct {
+	ID        uint64     `json:"id" gorm:"primaryKey"`
+	AdminID   uint64     `json:"admin_id" gorm:"not null;index"`
+	CodeHash  string     `json:"-" gorm:"size:64;not null"`
+	UsedAt    *time.Time `json:"used_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+func (RecoveryCode) TableName() string {
+	return "admin_recovery_codes"
+}
+
+// Challenge is the short-lived token returned by a password login that still
+// needs a second factor. Only its SHA-256 hash is stored.
+type Challenge struct {
+	ID        uint64     `json:"id" gorm:"primaryKey"`
+	AdminID   uint64     `json:"admin_id" gorm:"not null;index"`
+	TokenHash string     `json:"-" gorm:"size:64;not null;uniqueIndex"`
+	Attempts  int        `json:"attempts" gorm:"not null;default:0"`
+	ExpiresAt time.Time  `json:"expires_at" gorm:"not null"`
+	UsedAt    *time.Time `json:"used_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+func (Challenge) TableName() string {
+	return "mfa_challenges"
+}
+
+// IsUsable reports whether the challenge can still be answered
+func (c *Challenge) IsUsable(now time.Time) bool {
+	return c.UsedAt == nil && now.Before(c.ExpiresAt) && c.Attempts < MaxChallengeAttempts
+}
@@ -0,0 +1,11 @@
+package mfa
+
+import "errors"
+
+var (
+	ErrNotEnrolled      = errors.New("two-factor authentication is not enabled")
+	ErrAlreadyEnabled   = errors.New("two-factor authentication is already enabled")
+	ErrInvalidCode      = errors.New("invalid two-factor code")
+	ErrInvalidChallenge = errors.New("invalid or expired mfa token")
+	ErrMFARequired      = errors.New("two-factor authentication is required for this account")
+)
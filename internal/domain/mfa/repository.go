@@ -0,0 +1,31 @@
+package mfa
+
+import (
+	"context"
+	"time"
+)
+
+// Repository defines the interface for MFA data access
+type Repository interface {
+	GetEnrollment(ctx context.Context, adminID uint64) (*Enrollment, error)
+	CreateEnrollment(ctx context.Context, e *Enrollment) error
+	ConfirmEnrollment(ctx context.Context, adminID uint64, confirmedAt time.Time) error
+	// DeleteEnrollment removes the enrollment together with its recovery codes
+	DeleteEnrollment(ctx context.Context, adminID uint64) error
+	// AdvanceLastUsedStep records step as used, returning false if an equal or
+	// later step was already accepted
+	AdvanceLastUsedStep(ctx context.Context, adminID uint64, step uint64) (bool, error)
+
+	// ReplaceRecoveryCodes discards the admin's recovery codes and stores codes instead
+	ReplaceRecoveryCodes(ctx context.Context, adminID uint64, codes []RecoveryCode) error
+	// UseRecoveryCode marks a matching unused code as used, returning false if none matched
+	UseRecoveryCode(ctx context.Context, adminID uint64, codeHash string) (bool, error)
+	CountUnusedRecoveryCodes(ctx context.Context, adminID uint64) (int64, error)
+
+	CreateChallenge(ctx context.Context, c *Challenge) error
+	GetChallengeByHash(ctx context.Context, tokenHash string) (*Challenge, error)
+	IncrementChallengeAttempts(ctx context.Context, id uint64) error
+	// MarkChallengeUsed marks the challenge as used, returning false if it
+	// had already been used by a concurrent request
+	MarkChallengeUsed(ctx context.Context, id uint64) (bool, error)
+}
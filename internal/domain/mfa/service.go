@@ -0,0 +1,31 @@
+package mfa
+
+import (
+	"context"
+
+	"my-go-driver/internal/domain/company"
+)
+
+// Service defines the interface for TOTP two-factor authentication of company admins
+type Service interface {
+	GetStatus(ctx context.Context, adminID uint64) (*StatusResponse, error)
+	// Enroll starts (or restarts) enrollment with a new secret
+	Enroll(ctx context.Context, adminID uint64) (*EnrollResponse, error)
+	// Confirm enables MFA with a first valid code and issues recovery codes
+	Confirm(ctx context.Context, adminID uint64, req CodeRequest) (*RecoveryCodesResponse, error)
+	Disable(ctx context.Context, adminID uint64, req CodeRequest) error
+	RegenerateRecoveryCodes(ctx context.Context, adminID uint64, req CodeRequest) (*RecoveryCodesResponse, error)
+
+	// BeginLogin returns a challenge when the admin must pass a second factor
+	// after their password, or nil when the password alone is enough
+	BeginLogin(ctx context.Context, admin *company.CompanyAdmin) (*company.MFAChallenge, error)
+	// EnrollWithChallenge starts enrollment for an admin whose login requires
+	// MFA but who has not enrolled yet
+	EnrollWithChallenge(ctx context.Context, req ChallengeEnrollRequest) (*EnrollResponse, error)
+	// ChallengeAdmin returns the ID of the admin a usable login challenge belongs to
+	ChallengeAdmin(ctx context.Context, token string) (uint64, error)
+	// CompleteChallenge verifies the code for a login challenge. When the
+	// login also completed enrollment, the newly issued recovery codes are
+	// returned.
+	CompleteChallenge(ctx context.Context, token, code string) ([]string, error)
+}
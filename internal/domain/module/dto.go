@@ -1,16 +1,24 @@
 package module
 
-import "time"
+import (
+	"time"
+
+	"my-go-driver/pkg/jsonschema"
+)
 
 // ModuleResponse represents module response
 type ModuleResponse struct {
-	ID             uint64    `json:"id"`
-	ModuleKey      string    `json:"module_key"`
-	Name           string    `json:"name"`
-	Category       string    `json:"category"`
-	Description    string    `json:"description"`
-	DefaultEnabled bool      `json:"default_enabled"`
-	CreatedAt      time.Time `json:"created_at"`
+	ID             uint64             `json:"id"`
+	ModuleKey      string             `json:"module_key"`
+	Name           string             `json:"name"`
+	Category       string             `json:"category"`
+	Description    string             `json:"description"`
+	DefaultEnabled bool               `json:"default_enabled"`
+	ConfigSchema   *jsonschema.Schema `json:"config_schema,omitempty"`
+	DefaultConfig  ModuleConfig       `json:"default_config"`
+	Requires       ModuleKeys         `json:"requires"`
+	Conflicts      ModuleKeys         `json:"conflicts"`
+	CreatedAt      time.Time          `json:"created_at"`
 }
 
 // CompanyModuleResponse represents company module response
@@ -22,11 +30,80 @@ type CompanyModuleResponse struct {
 	Config    ModuleConfig   `json:"config"`
 	CreatedAt time.Time      `json:"created_at"`
 	UpdatedAt time.Time      `json:"updated_at"`
+	// EnabledDependencies lists the required modules enabled along with this one
+	EnabledDependencies []string `json:"enabled_dependencies,omitempty"`
 }
 
-// AssignModuleRequest represents request to assign module
+// AssignModuleRequest represents request to assign module. Config is merged
+// over the module's default config. With AutoEnableDependencies, required
+// modules the company lacks are assigned or enabled along with the module
+// instead of failing the request.
 type AssignModuleRequest struct {
-	ModuleID  uint64       `json:"module_id" binding:"required"`
+	ModuleID               uint64       `json:"module_id" binding:"required"`
+	IsEnabled              bool         `json:"is_enabled"`
+	Config                 ModuleConfig `json:"config"`
+	AutoEnableDependencies bool         `json:"auto_enable_dependencies"`
+}
+
+// DesiredModule represents a module in a company's desired module set.
+// Config is merged over the module's default config.
+type DesiredModule struct {
+	ModuleKey string       `json:"module_key" binding:"required"`
+	IsEnabled bool         `json:"is_enabled"`
+	Config    ModuleConfig `json:"config"`
+}
+
+// SetCompanyModulesRequest represents request to replace a company's modules
+// with the given set. Assigned modules missing from the set are removed.
+type SetCompanyModulesRequest struct {
+	Modules []DesiredModule `json:"modules" binding:"required,dive"`
+	DryRun  bool            `json:"dry_run"`
+}
+
+// ModuleChange represents a company module added or updated by a
+// declarative module assignment, with its new settings
+type ModuleChange struct {
+	ModuleKey string       `json:"module_key"`
+	IsEnabled bool         `json:"is_enabled"`
+	Config    ModuleConfig `json:"config"`
+}
+
+// SetCompanyModulesResponse represents the changes a declarative module
+// assignment makes and, unless it is a dry run, the resulting company modules
+type SetCompanyModulesResponse struct {
+	DryRun  bool                    `json:"dry_run"`
+	Added   []ModuleChange          `json:"added"`
+	Updated []ModuleChange          `json:"updated"`
+	Removed []string                `json:"removed"`
+	Modules []CompanyModuleResponse `json:"modules,omitempty"`
+}
+
+// UpdateModuleConfigRequest represents request to change a company module's
+// config. Config is a JSON merge patch (RFC 7386): keys set to null are
+// removed and nested objects are merged.
+type UpdateModuleConfigRequest struct {
+	Config ModuleConfig `json:"config" binding:"required"`
+}
+
+// SetStoreModuleRequest represents request to override a company module for a
+// store. Config is a JSON merge patch applied over the company config.
+type SetStoreModuleRequest struct {
+	IsEnabled *bool        `json:"is_enabled" binding:"required"`
+	Config    ModuleConfig `json:"config"`
+}
+
+// StoreModuleOverride represents a store's override of a company module
+type StoreModuleOverride struct {
 	IsEnabled bool         `json:"is_enabled"`
 	Config    ModuleConfig `json:"config"`
+	UpdatedAt time.Time    `json:"updated_at"`
+}
+
+// EffectiveModuleResponse represents a module as it applies to a store: the
+// company settings with the store's override, if any, layered on top
+type EffectiveModuleResponse struct {
+	Module    ModuleResponse       `json:"module"`
+	IsEnabled bool                 `json:"is_enabled"`
+	Config    ModuleConfig         `json:"config"`
+	Override  *StoreModuleOverride `json:"override,omitempty"`
 }
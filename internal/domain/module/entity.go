@@ -4,17 +4,35 @@ import (
 	"database/sql/driver"
 	"encoding/json"
 	"time"
+
+	"my-go-driver/pkg/jsonschema"
+)
+
+// Module keys referenced from code
+const (
+	KeyStoreManagement   = "store_management"
+	KeyDriverManagement  = "driver_management"
+	KeyVehicleManagement = "vehicle_management"
+	KeyClientManagement  = "client_management"
+	KeyShiftManagement   = "shift_management"
+	KeyRolesPermissions  = "roles_permissions"
+	KeyAPIAccess         = "api_access"
+	KeyNotifications     = "notifications"
 )
 
 // ModuleMaster represents a master module definition
 type ModuleMaster struct {
-	ID             uint64    `json:"id" gorm:"primaryKey"`
-	ModuleKey      string    `json:"module_key" gorm:"not null;uniqueIndex"`
-	Name           string    `json:"name" gorm:"not null"`
-	Category       string    `json:"category"`
-	Description    string    `json:"description" gorm:"type:text"`
-	DefaultEnabled bool      `json:"default_enabled" gorm:"default:false"`
-	CreatedAt      time.Time `json:"created_at"`
+	ID             uint64             `json:"id" gorm:"primaryKey"`
+	ModuleKey      string             `json:"module_key" gorm:"not null;uniqueIndex"`
+	Name           string             `json:"name" gorm:"not null"`
+	Category       string             `json:"category"`
+	Description    string             `json:"description" gorm:"type:text"`
+	DefaultEnabled bool               `json:"default_enabled" gorm:"default:false"`
+	ConfigSchema   *jsonschema.Schema `json:"config_schema,omitempty" gorm:"type:json"`
+	DefaultConfig  ModuleConfig       `json:"default_config" gorm:"type:json"`
+	Requires       ModuleKeys         `json:"requires" gorm:"type:json"`
+	Conflicts      ModuleKeys         `json:"conflicts" gorm:"type:json"`
+	CreatedAt      time.Time          `json:"created_at"`
 }
 
 func (ModuleMaster) TableName() string {
@@ -44,6 +62,39 @@ func (mc ModuleConfig) Value() (driver.Value, error) {
 	return json.Marshal(mc)
 }
 
+// ModuleKeys is a JSON list of module keys
+type ModuleKeys []string
+
+// Scan implements sql.Scanner interface
+func (k *ModuleKeys) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return nil
+	}
+	return json.Unmarshal(bytes, k)
+}
+
+// Value implements driver.Valuer interface
+func (k ModuleKeys) Value() (driver.Value, error) {
+	if k == nil {
+		return nil, nil
+	}
+	return json.Marshal(k)
+}
+
+// Contains reports whether the list holds the key
+func (k ModuleKeys) Contains(key string) bool {
+	for _, existing := range k {
+		if existing == key {
+			return true
+		}
+	}
+	return false
+}
+
 // CompanyModule represents a module assigned to a company
 type CompanyModule struct {
 	ID        uint64       `json:"id" gorm:"primaryKey"`
@@ -62,3 +113,23 @@ type CompanyModule struct {
 func (CompanyModule) TableName() string {
 	return "company_modules"
 }
+
+// StoreModule overrides a company module for one store. The override can turn
+// the module off for the store and patch its config; it cannot turn on a
+// module the company does not have enabled.
+type StoreModule struct {
+	ID        uint64       `json:"id" gorm:"primaryKey"`
+	StoreID   uint64       `json:"store_id" gorm:"not null"`
+	ModuleID  uint64       `json:"module_id" gorm:"not null"`
+	IsEnabled bool         `json:"is_enabled"`
+	Config    ModuleConfig `json:"config" gorm:"type:json"`
+	CreatedAt time.Time    `json:"created_at"`
+	UpdatedAt time.Time    `json:"updated_at"`
+
+	// Relations
+	Module *ModuleMaster `json:"module,omitempty" gorm:"foreignKey:ModuleID"`
+}
+
+func (StoreModule) TableName() string {
+	return "store_modules"
+}
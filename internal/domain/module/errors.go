@@ -0,0 +1,112 @@
+package module
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"my-go-driver/pkg/jsonschema"
+)
+
+var (
+	ErrModuleNotFound        = errors.New("module not found")
+	ErrModuleNotAssigned     = errors.New("module not assigned to company")
+	ErrModuleAlreadyAssigned = errors.New("module already assigned to company")
+	ErrDuplicateModule       = errors.New("module listed more than once")
+	ErrModuleDisabled        = errors.New("module is not enabled for the company")
+	ErrInvalidConfig         = errors.New("module config is invalid")
+	ErrNoStoreOverride       = errors.New("store has no override for the module")
+	ErrModuleNotEnabled      = errors.New("module is disabled for the company and cannot be enabled for a store")
+
+	ErrMissingDependencies = errors.New("module requires modules that are not enabled")
+	ErrModuleConflict      = errors.New("module conflicts with an enabled module")
+	ErrDependencyCycle     = errors.New("module dependencies form a cycle")
+	ErrModuleRequired      = errors.New("module is required by enabled modules")
+)
+
+// Error codes reported in DependencyError.Code
+const (
+	CodeMissingDependencies = "missing_dependencies"
+	CodeModuleConflict      = "module_conflict"
+	CodeDependencyCycle     = "dependency_cycle"
+	CodeModuleRequired      = "module_required"
+)
+
+// DependencyError is a structured module dependency error, returned to API
+// clients as is. Modules lists the missing dependencies, the conflicting
+// modules, the modules forming the cycle or the modules that still require
+// Module, depending on Code.
+type DependencyError struct {
+	Code    string   `json:"code"`
+	Module  string   `json:"module"`
+	Modules []string `json:"modules"`
+}
+
+func (e *DependencyError) Error() string {
+	modules := strings.Join(e.Modules, ", ")
+	switch e.Code {
+	case CodeMissingDependencies:
+		return fmt.Sprintf("%s requires modules that are not enabled: %s", e.Module, modules)
+	case CodeModuleConflict:
+		return fmt.Sprintf("%s conflicts with enabled modules: %s", e.Module, modules)
+	case CodeDependencyCycle:
+		return fmt.Sprintf("%s has a dependency cycle: %s", e.Module, modules)
+	default:
+		return fmt.Sprintf("%s is required by enabled modules: %s", e.Module, modules)
+	}
+}
+
+// Is makes errors.Is match the sentinel for the error's code
+func (e *DependencyError) Is(target error) bool {
+	switch target {
+	case ErrMissingDependencies:
+		return e.Code == CodeMissingDependencies
+	case ErrModuleConflict:
+		return e.Code == CodeModuleConflict
+	case ErrDependencyCycle:
+		return e.Code == CodeDependencyCycle
+	case ErrModuleRequired:
+		return e.Code == CodeModuleRequired
+	default:
+		return false
+	}
+}
+
+// ConfigError lists the fields of a module config that do not match the
+// module's config schema. Field paths are relative to the request body.
+type ConfigError struct {
+	Errors []jsonschema.FieldError
+}
+
+func (e *ConfigError) Error() string {
+	return fmt.Sprintf("module config is invalid: %d field errors", len(e.Errors))
+}
+
+// Is makes errors.Is(err, ErrInvalidConfig) match
+func (e *ConfigError) Is(target error) bool {
+	return target == ErrInvalidConfig
+}
+
+// CodeModuleDisabled is reported in DisabledError.Code
+const CodeModuleDisabled = "module_disabled"
+
+// DisabledError is returned when a feature is used whose module is not
+// enabled for the company. It is returned to API clients as is.
+type DisabledError struct {
+	Code   string `json:"code"`
+	Module string `json:"module"`
+}
+
+// NewDisabledError creates the error reported for a disabled module
+func NewDisabledError(moduleKey string) *DisabledError {
+	return &DisabledError{Code: CodeModuleDisabled, Module: moduleKey}
+}
+
+func (e *DisabledError) Error() string {
+	return fmt.Sprintf("the %s module is not enabled for this company", e.Module)
+}
+
+// Is makes errors.Is(err, ErrModuleDisabled) match
+func (e *DisabledError) Is(target error) bool {
+	return target == ErrModuleDisabled
+}
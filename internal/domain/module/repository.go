@@ -6,12 +6,25 @@ import "context"
 type Repository interface {
 	// Module master operations
 	ListAllModules(ctx context.Context) ([]ModuleMaster, error)
+	ListDefaultModules(ctx context.Context) ([]ModuleMaster, error)
 	GetModuleByID(ctx context.Context, id uint64) (*ModuleMaster, error)
 
 	// Company module operations
 	AssignModule(ctx context.Context, companyModule *CompanyModule) error
 	GetCompanyModules(ctx context.Context, companyID uint64) ([]CompanyModule, error)
 	GetCompanyModule(ctx context.Context, companyID, moduleID uint64) (*CompanyModule, error)
+	GetCompanyModuleByKey(ctx context.Context, companyID uint64, moduleKey string) (*CompanyModule, error)
+	ListEnabledModuleKeys(ctx context.Context, companyID uint64) ([]string, error)
 	UpdateModuleConfig(ctx context.Context, id uint64, config ModuleConfig) error
+	SetModuleEnabled(ctx context.Context, id uint64, enabled bool) error
+	UpdateCompanyModule(ctx context.Context, id uint64, enabled bool, config ModuleConfig) error
 	RemoveModule(ctx context.Context, companyID, moduleID uint64) error
+
+	// Store module operations
+	ListStoreModules(ctx context.Context, storeID uint64) ([]StoreModule, error)
+	GetStoreModule(ctx context.Context, storeID, moduleID uint64) (*StoreModule, error)
+	SaveStoreModule(ctx context.Context, storeModule *StoreModule) error
+	RemoveStoreModule(ctx context.Context, storeID, moduleID uint64) error
+	RemoveCompanyStoreModules(ctx context.Context, companyID, moduleID uint64) error
+	ListStoreDisabledModuleKeys(ctx context.Context, storeID uint64) ([]string, error)
 }
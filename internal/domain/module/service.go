@@ -1,6 +1,10 @@
 package module
 
-import "context"
+import (
+	"context"
+
+	"my-go-driver/internal/domain/company"
+)
 
 // Service defines the interface for module business logic
 type Service interface {
@@ -8,4 +12,28 @@ type Service interface {
 	AssignModuleToCompany(ctx context.Context, companyID uint64, req AssignModuleRequest) (*CompanyModuleResponse, error)
 	GetCompanyModules(ctx context.Context, companyID uint64) ([]CompanyModuleResponse, error)
 	RemoveModuleFromCompany(ctx context.Context, companyID, moduleID uint64) error
+	UpdateModuleConfig(ctx context.Context, companyID, moduleID uint64, req UpdateModuleConfigRequest) (*CompanyModuleResponse, error)
+	// SetCompanyModules makes the company's modules match the requested set
+	// in one transaction, or only reports the changes for a dry run
+	SetCompanyModules(ctx context.Context, companyID uint64, req SetCompanyModulesRequest) (*SetCompanyModulesResponse, error)
+	// ProvisionDefaultModules enables the default modules included in the
+	// plan that the company does not have yet, returning the ones added
+	ProvisionDefaultModules(ctx context.Context, companyID uint64, billingPlan company.BillingPlan) ([]CompanyModuleResponse, error)
+
+	// IsEnabled reports whether the module is assigned and enabled for the company
+	IsEnabled(ctx context.Context, companyID uint64, moduleKey string) (bool, error)
+	// RequireEnabled returns a *DisabledError when the module is not enabled for the company
+	RequireEnabled(ctx context.Context, companyID uint64, moduleKey string) error
+
+	// GetStoreModules resolves the company's modules for a store, applying
+	// the store's overrides
+	GetStoreModules(ctx context.Context, companyID, storeID uint64) ([]EffectiveModuleResponse, error)
+	SetStoreModule(ctx context.Context, companyID, storeID, moduleID uint64, req SetStoreModuleRequest) (*EffectiveModuleResponse, error)
+	// RemoveStoreModule drops the store's override so the company settings apply again
+	RemoveStoreModule(ctx context.Context, companyID, storeID, moduleID uint64) error
+	// IsEnabledForStore reports whether the module is enabled for the company
+	// and not turned off for the store
+	IsEnabledForStore(ctx context.Context, companyID, storeID uint64, moduleKey string) (bool, error)
+	// RequireEnabledForStore returns a *DisabledError when the module is not enabled for the store
+	RequireEnabledForStore(ctx context.Context, companyID, storeID uint64, moduleKey string) error
 }
@@ -0,0 +1,40 @@
+package notification
+
+import (
+	"time"
+
+	"my-go-driver/internal/domain/company"
+)
+
+// NotificationResponse represents notification response
+type NotificationResponse struct {
+	ID        uint64          `json:"id"`
+	CompanyID uint64          `json:"company_id"`
+	UserID    *uint64         `json:"user_id"`
+	Type      string          `json:"type"`
+	Title     string          `json:"title"`
+	Body      string          `json:"body"`
+	Data      company.JSONMap `json:"data"`
+	IsRead    bool            `json:"is_read"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// ListNotificationsQuery represents query parameters for listing an admin's
+// notifications. UserID is the admin, taken from the session.
+type ListNotificationsQuery struct {
+	Page      int    `form:"page" binding:"omitempty,min=1"`
+	Limit     int    `form:"limit" binding:"omitempty,min=1,max=100"`
+	CompanyID uint64 `form:"company_id" binding:"omitempty"`
+	UserID    uint64 `form:"-"`
+	Type      string `form:"type" binding:"omitempty"`
+	IsRead    *bool  `form:"is_read" binding:"omitempty"`
+}
+
+// PaginatedNotificationsResponse represents paginated notifications response
+type PaginatedNotificationsResponse struct {
+	Notifications []NotificationResponse `json:"notifications"`
+	TotalCount    int64                  `json:"total_count"`
+	Page          int                    `json:"page"`
+	Limit         int                    `json:"limit"`
+	TotalPages    int                    `json:"total_pages"`
+}
@@ -0,0 +1,44 @@
+package notification
+
+import (
+	"time"
+
+	"my-go-driver/internal/domain/company"
+)
+
+// Notification types
+const (
+	TypeMaintenanceDue = "vehicle.maintenance_due"
+)
+
+// Notification is an in-app message to a company. UserID or DriverID address
+// it to one admin or driver; without either it is for every admin of the
+// company. Admins each keep their own read state, as Reads; IsRead is the
+// shared state seen by requests made without an admin, such as API keys.
+type Notification struct {
+	ID        uint64          `json:"id" gorm:"primaryKey"`
+	CompanyID uint64          `json:"company_id" gorm:"not null"`
+	UserID    *uint64         `json:"user_id"`
+	DriverID  *uint64         `json:"driver_id"`
+	Type      string          `json:"type" gorm:"not null"`
+	Title     string          `json:"title" gorm:"not null"`
+	Body      string          `json:"body" gorm:"type:text;not null"`
+	Data      company.JSONMap `json:"data" gorm:"type:json"`
+	IsRead    bool            `json:"is_read" gorm:"default:false"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+func (Notification) TableName() string {
+	return "notifications"
+}
+
+// Read records that an admin has read a notification
+type Read struct {
+	NotificationID uint64    `gorm:"primaryKey"`
+	AdminID        uint64    `gorm:"primaryKey"`
+	ReadAt         time.Time `gorm:"autoCreateTime"`
+}
+
+func (Read) TableName() string {
+	return "notification_reads"
+}
@@ -0,0 +1,15 @@
+package notification
+
+import "context"
+
+// Repository defines the interface for notification data access
+type Repository interface {
+	Create(ctx context.Context, notification *Notification) error
+	GetByID(ctx context.Context, id uint64) (*Notification, error)
+	// List returns the notifications of the admin query.UserID: those for
+	// the whole company and those addressed to the admin. IsRead and the
+	// is_read filter apply to the admin's read state.
+	List(ctx context.Context, query ListNotificationsQuery) ([]Notification, int64, error)
+	// MarkRead marks the notification as read for the admin
+	MarkRead(ctx context.Context, id, adminID uint64) error
+}
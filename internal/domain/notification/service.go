@@ -0,0 +1,13 @@
+package notification
+
+import "context"
+
+// Service defines the interface for notification business logic
+type Service interface {
+	// Notify stores the notification when the notifications module is
+	// enabled for its company, and drops it otherwise. It reports whether
+	// the notification was stored.
+	Notify(ctx context.Context, notification *Notification) (bool, error)
+	ListNotifications(ctx context.Context, query ListNotificationsQuery) (*PaginatedNotificationsResponse, error)
+	MarkRead(ctx context.Context, id, userID uint64) (*NotificationResponse, error)
+}
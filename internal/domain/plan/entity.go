@@ -0,0 +1,122 @@
+package plan
+
+import "my-go-driver/internal/domain/company"
+
+// Plan declares what a billing plan includes: the modules a company on the
+// plan may be given and the quotas a company starts with on the plan
+type Plan struct {
+	Key               company.BillingPlan `json:"key"`
+	Name              string              `json:"name"`
+	Modules           []string            `json:"modules"`
+	MaxAllowedDrivers int                 `json:"max_allowed_drivers"`
+	SeatsLimit        int                 `json:"seats_limit"`
+	APIRateLimit      int                 `json:"api_rate_limit"`
+}
+
+// Includes reports whether the plan includes the module
+func (p Plan) Includes(moduleKey string) bool {
+	for _, key := range p.Modules {
+		if key == moduleKey {
+			return true
+		}
+	}
+	return false
+}
+
+// Modules of each tier. Every plan includes the modules of the plans below it.
+var (
+	freeModules = []string{
+		"store_management",
+		"driver_management",
+		"vehicle_management",
+		"order_management",
+		"client_management",
+		"proof_of_delivery",
+		"task_scheduling",
+		"shift_management",
+		"gps_tracking",
+		"notifications",
+		"signature_pod",
+		"photo_pod",
+		"broadcast_messaging",
+	}
+	basicModules = with(freeModules,
+		"reports_analytics",
+		"product_inventory",
+		"cash_handling",
+		"chat_communication",
+		"zone_territory",
+		"incident_reporting",
+		"otp_qr_delivery",
+		"task_checklist",
+		"return_to_depot",
+		"cash_collection",
+		"driver_admin_chat",
+		"issue_reporting",
+	)
+	proModules = with(basicModules,
+		"route_optimization",
+		"auto_assignment",
+		"multi_depot",
+		"vehicle_load_planning",
+		"driver_efficiency",
+		"delivery_sla",
+		"document_scan_pod",
+		"vehicle_stock",
+		"warehouse_stock",
+		"stock_movement",
+		"realtime_driver_inventory",
+		"eod_stock_return",
+		"auto_reorder",
+		"online_payment",
+		"cash_discrepancy",
+		"driver_reconciliation",
+		"client_billing",
+		"invoice_generation",
+		"sos_emergency",
+		"api_access",
+		"webhooks",
+		"custom_branding",
+		"roles_permissions",
+		"audit_logs",
+	)
+	enterpriseModules = with(proModules,
+		"third_party_delivery",
+		"accounting_integration",
+		"erp_integration",
+		"crm_integration",
+		"whitelabel_app",
+		"ai_driver_assignment",
+		"ai_route_planning",
+	)
+)
+
+// catalog lists every plan, cheapest first
+var catalog = []Plan{
+	{Key: company.PlanFree, Name: "Free", Modules: freeModules, MaxAllowedDrivers: 10, SeatsLimit: 10, APIRateLimit: 1000},
+	{Key: company.PlanBasic, Name: "Basic", Modules: basicModules, MaxAllowedDrivers: 50, SeatsLimit: 25, APIRateLimit: 3000},
+	{Key: company.PlanPro, Name: "Pro", Modules: proModules, MaxAllowedDrivers: 250, SeatsLimit: 100, APIRateLimit: 10000},
+	{Key: company.PlanEnterprise, Name: "Enterprise", Modules: enterpriseModules, MaxAllowedDrivers: 1000, SeatsLimit: 500, APIRateLimit: 50000},
+}
+
+// All returns every plan in the catalog, cheapest first
+func All() []Plan {
+	return catalog
+}
+
+// Get returns the plan with the given key
+func Get(key company.BillingPlan) (Plan, bool) {
+	for _, p := range catalog {
+		if p.Key == key {
+			return p, true
+		}
+	}
+	return Plan{}, false
+}
+
+// with returns a copy of base extended with keys
+func with(base []string, keys ...string) []string {
+	modules := make([]string, 0, len(base)+len(keys))
+	modules = append(modules, base...)
+	return append(modules, keys...)
+}
@@ -0,0 +1,68 @@
+package plan
+
+import (
+	"os"
+	"regexp"
+	"testing"
+)
+
+// seededModuleKeys returns the module keys inserted by the modules_master
+// seed migration
+func seededModuleKeys(t *testing.T) []string {
+	seed, err := os.ReadFile("../../../migrations/000016_populate_modules_master.up.sql")
+	if err != nil {
+		t.Fatalf("read seed migration: %v", err)
+	}
+
+	var keys []string
+	for _, match := range regexp.MustCompile(`(?m)^\('([a-z_]+)',`).FindAllSubmatch(seed, -1) {
+		keys = append(keys, string(match[1]))
+	}
+	if len(keys) == 0 {
+		t.Fatal("seed migration lists no modules")
+	}
+	return keys
+}
+
+func TestCatalogCoversSeededModules(t *testing.T) {
+	seeded := make(map[string]bool)
+	for _, key := range seededModuleKeys(t) {
+		seeded[key] = true
+	}
+
+	// Each module is introduced by exactly one plan and kept by the plans above it
+	introducedBy := make(map[string]Plan)
+	var previous *Plan
+	for i, p := range All() {
+		listed := make(map[string]bool, len(p.Modules))
+		for _, key := range p.Modules {
+			if listed[key] {
+				t.Errorf("%s lists %s twice", p.Key, key)
+			}
+			listed[key] = true
+			if !seeded[key] {
+				t.Errorf("%s includes %s, which is not a seeded module", p.Key, key)
+			}
+			if previous == nil || !previous.Includes(key) {
+				if first, ok := introducedBy[key]; ok {
+					t.Errorf("%s is introduced by both %s and %s", key, first.Key, p.Key)
+				}
+				introducedBy[key] = p
+			}
+		}
+		if previous != nil {
+			for _, key := range previous.Modules {
+				if !p.Includes(key) {
+					t.Errorf("%s does not include %s from %s", p.Key, key, previous.Key)
+				}
+			}
+		}
+		previous = &All()[i]
+	}
+
+	for key := range seeded {
+		if _, ok := introducedBy[key]; !ok {
+			t.Errorf("seeded module %s is in no plan", key)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package plan
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"my-go-driver/internal/domain/company"
+)
+
+var (
+	ErrUnknownPlan      = errors.New("unknown plan")
+	ErrModuleNotInPlan  = errors.New("module is not included in the company's plan")
+	ErrModulesNotInPlan = errors.New("assigned modules are not included in the new plan")
+)
+
+// CodeModulesNotInPlan is reported in ModulesNotInPlanError.Code
+const CodeModulesNotInPlan = "modules_not_in_plan"
+
+// ModulesNotInPlanError is returned when a plan change is refused because
+// the company has modules the new plan does not include. It is returned to
+// API clients as is.
+type ModulesNotInPlanError struct {
+	Code            string              `json:"code"`
+	Plan            company.BillingPlan `json:"plan"`
+	ModulesToRemove []string            `json:"modules_to_remove"`
+}
+
+func (e *ModulesNotInPlanError) Error() string {
+	return fmt.Sprintf("the %s plan does not include %s; remove them before changing plan", e.Plan, strings.Join(e.ModulesToRemove, ", "))
+}
+
+// Is makes errors.Is(err, ErrModulesNotInPlan) match
+func (e *ModulesNotInPlanError) Is(target error) bool {
+	return target == ErrModulesNotInPlan
+}
@@ -0,0 +1,17 @@
+package plan
+
+import (
+	"context"
+
+	"my-go-driver/internal/domain/company"
+)
+
+// Service validates module entitlements against the plan catalog
+type Service interface {
+	ListPlans(ctx context.Context) ([]Plan, error)
+	// CheckModule returns ErrModuleNotInPlan when the company's plan does not include the module
+	CheckModule(ctx context.Context, companyID uint64, moduleKey string) error
+	// PreviewChange reports the modules the company must give up and the
+	// modules it would unlock by moving from one plan to another
+	PreviewChange(ctx context.Context, companyID uint64, from, to company.BillingPlan) (*company.PlanChange, error)
+}
@@ -0,0 +1,38 @@
+package platform
+
+import "time"
+
+// CreateAdminRequest represents request to create a platform operator
+type CreateAdminRequest struct {
+	Name     string `json:"name" binding:"required,min=2,max=100"`
+	Email    string `json:"email" binding:"required,email"`
+	Password string `json:"password" binding:"required,min=8"`
+}
+
+// AdminResponse represents platform operator response
+type AdminResponse struct {
+	ID        uint64    `json:"id"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email"`
+	IsActive  bool      `json:"is_active"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// LoginRequest represents platform operator login request
+type LoginRequest struct {
+	Email    string `json:"email" binding:"required,email"`
+	Password string `json:"password" binding:"required"`
+
+	// Client details, set by the handler and never bound
+	IPAddress string `json:"-"`
+	UserAgent string `json:"-"`
+}
+
+// LoginResponse represents login response with token
+type LoginResponse struct {
+	Admin        AdminResponse `json:"admin"`
+	Token        string        `json:"token"`
+	RefreshToken string        `json:"refresh_token"`
+	ExpiresAt    time.Time     `json:"expires_at"`
+}
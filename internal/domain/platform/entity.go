@@ -0,0 +1,20 @@
+package platform
+
+import "time"
+
+// Admin represents a platform operator. Operators are stored in the users
+// table and manage tenants, plans and module entitlements across companies.
+type Admin struct {
+	ID           uint64     `json:"id" gorm:"primaryKey"`
+	Name         string     `json:"name" gorm:"not null"`
+	Email        string     `json:"email" gorm:"not null;uniqueIndex"`
+	PasswordHash string     `json:"-" gorm:"column:password;not null"`
+	IsActive     bool       `json:"is_active" gorm:"default:true"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+	DeletedAt    *time.Time `json:"deleted_at,omitempty" gorm:"index"`
+}
+
+func (Admin) TableName() string {
+	return "users"
+}
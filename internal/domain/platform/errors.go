@@ -0,0 +1,7 @@
+package platform
+
+import "errors"
+
+var (
+	ErrAdminNotFound = errors.New("platform admin not found")
+)
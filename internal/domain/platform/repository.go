@@ -0,0 +1,10 @@
+package platform
+
+import "context"
+
+// Repository defines the interface for platform operator data access
+type Repository interface {
+	Create(ctx context.Context, admin *Admin) error
+	GetByEmail(ctx context.Context, email string) (*Admin, error)
+	GetByID(ctx context.Context, id uint64) (*Admin, error)
+}
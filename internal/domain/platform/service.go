@@ -0,0 +1,10 @@
+package platform
+
+import "context"
+
+// Service defines the interface for platform operator business logic
+type Service interface {
+	CreateAdmin(ctx context.Context, req CreateAdminRequest) (*AdminResponse, error)
+	Login(ctx context.Context, req LoginRequest) (*LoginResponse, error)
+	GetProfile(ctx context.Context, adminID uint64) (*AdminResponse, error)
+}
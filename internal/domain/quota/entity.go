@@ -0,0 +1,11 @@
+package quota
+
+// Resource is a company resource limited by its plan
+type Resource string
+
+const (
+	// ResourceDrivers is limited by Company.MaxAllowedDrivers
+	ResourceDrivers Resource = "drivers"
+	// ResourceSeats is the number of company admins, limited by Company.SeatsLimit
+	ResourceSeats Resource = "seats"
+)
@@ -0,0 +1,44 @@
+package quota
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrQuotaExceeded   = errors.New("quota exceeded")
+	ErrLimitBelowUsage = errors.New("limit is below current usage")
+)
+
+// Error codes reported in Error.Code
+const (
+	CodeQuotaExceeded   = "quota_exceeded"
+	CodeLimitBelowUsage = "limit_below_usage"
+)
+
+// Error is a structured quota error, returned to API clients as is
+type Error struct {
+	Code     string   `json:"code"`
+	Resource Resource `json:"resource"`
+	Limit    int      `json:"limit"`
+	Used     int64    `json:"used"`
+}
+
+func (e *Error) Error() string {
+	if e.Code == CodeLimitBelowUsage {
+		return fmt.Sprintf("%s limit %d is below current usage of %d", e.Resource, e.Limit, e.Used)
+	}
+	return fmt.Sprintf("%s quota exceeded: %d of %d used", e.Resource, e.Used, e.Limit)
+}
+
+// Is makes errors.Is match ErrQuotaExceeded or ErrLimitBelowUsage by code
+func (e *Error) Is(target error) bool {
+	switch target {
+	case ErrQuotaExceeded:
+		return e.Code == CodeQuotaExceeded
+	case ErrLimitBelowUsage:
+		return e.Code == CodeLimitBelowUsage
+	default:
+		return false
+	}
+}
@@ -0,0 +1,11 @@
+package quota
+
+import "context"
+
+// Repository counts the resources a company uses
+type Repository interface {
+	// CountActiveDrivers counts the company's drivers that are not suspended or deleted
+	CountActiveDrivers(ctx context.Context, companyID uint64) (int64, error)
+	// CountActiveAdmins counts the company's active, non-deleted admins
+	CountActiveAdmins(ctx context.Context, companyID uint64) (int64, error)
+}
@@ -0,0 +1,17 @@
+package quota
+
+import "context"
+
+// Service enforces the driver and seat limits of a company's plan. Checks
+// return an *Error when the limit would be exceeded; a limit of zero is
+// unlimited. They lock the company
+// until the transaction ends, so they must run in the transaction that adds
+// the drivers or admins for concurrent additions to be counted in turn.
+type Service interface {
+	// CheckDrivers checks that the company can take on adding more drivers
+	CheckDrivers(ctx context.Context, companyID uint64, adding int) error
+	// CheckSeats checks that the company can take on adding more admins
+	CheckSeats(ctx context.Context, companyID uint64, adding int) error
+	// CheckLimits checks that new limits are not below the company's current usage
+	CheckLimits(ctx context.Context, companyID uint64, maxDrivers, seats int) error
+}
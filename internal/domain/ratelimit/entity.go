@@ -0,0 +1,10 @@
+package ratelimit
+
+// Request identifies who an API request is counted against. Every request
+// counts against its company; requests made with an API key or by a driver
+// also count against that key or driver.
+type Request struct {
+	CompanyID uint64
+	APIKeyID  *uint64
+	DriverID  *uint64
+}
@@ -0,0 +1,15 @@
+package ratelimit
+
+import (
+	"context"
+
+	"my-go-driver/pkg/tokenbucket"
+)
+
+// Service enforces per-company API rate limits
+type Service interface {
+	// Allow takes one request from every bucket the request counts against.
+	// The result reports the most restrictive bucket; a nil result means the
+	// company has no limit.
+	Allow(ctx context.Context, req Request) (*tokenbucket.Result, error)
+}
@@ -0,0 +1,63 @@
+package rbac
+
+import (
+	"context"
+	"sort"
+)
+
+// PermissionSet is the resolved set of permissions held by a caller
+type PermissionSet map[Permission]struct{}
+
+// NewPermissionSet builds a set from a list of permissions
+func NewPermissionSet(perms []Permission) PermissionSet {
+	set := make(PermissionSet, len(perms))
+	for _, p := range perms {
+		set[p] = struct{}{}
+	}
+	return set
+}
+
+// Has reports whether the set contains p
+func (s PermissionSet) Has(p Permission) bool {
+	_, ok := s[p]
+	return ok
+}
+
+// List returns the permissions in the set in a stable order
+func (s PermissionSet) List() []Permission {
+	list := make([]Permission, 0, len(s))
+	for p := range s {
+		list = append(list, p)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i] < list[j] })
+	return list
+}
+
+type contextKey struct{}
+
+// WithPermissions returns a copy of ctx carrying the caller's permissions
+func WithPermissions(ctx context.Context, set PermissionSet) context.Context {
+	return context.WithValue(ctx, contextKey{}, set)
+}
+
+// WithAllPermissions returns a copy of ctx holding every permission, for
+// platform operators and operational commands
+func WithAllPermissions(ctx context.Context) context.Context {
+	return WithPermissions(ctx, NewPermissionSet(AllPermissions))
+}
+
+// PermissionsFromContext returns the caller's permissions, if resolved
+func PermissionsFromContext(ctx context.Context) (PermissionSet, bool) {
+	set, ok := ctx.Value(contextKey{}).(PermissionSet)
+	return set, ok
+}
+
+// Check returns ErrPermissionDenied unless the context carries a permission
+// set that holds perm. Contexts without permissions are denied everything.
+func Check(ctx context.Context, perm Permission) error {
+	set, ok := PermissionsFromContext(ctx)
+	if !ok || !set.Has(perm) {
+		return ErrPermissionDenied
+	}
+	return nil
+}
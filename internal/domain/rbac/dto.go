@@ -0,0 +1,35 @@
+package rbac
+
+import "time"
+
+// CreateRoleRequest represents request to create a custom role
+type CreateRoleRequest struct {
+	Name        string       `json:"name" binding:"required,min=2,max=100"`
+	Description string       `json:"description" binding:"omitempty"`
+	Permissions []Permission `json:"permissions" binding:"required,min=1"`
+}
+
+// UpdateRoleRequest represents request to update a custom role
+type UpdateRoleRequest struct {
+	Name        string       `json:"name" binding:"omitempty,min=2,max=100"`
+	Description *string      `json:"description" binding:"omitempty"`
+	Permissions []Permission `json:"permissions" binding:"omitempty,min=1"`
+}
+
+// AssignRoleRequest represents request to assign a custom role to an admin.
+// A null role_id returns the admin to their built-in role.
+type AssignRoleRequest struct {
+	RoleID *uint64 `json:"role_id"`
+}
+
+// RoleResponse represents a built-in or custom role
+type RoleResponse struct {
+	ID          uint64       `json:"id,omitempty"`
+	CompanyID   uint64       `json:"company_id,omitempty"`
+	Name        string       `json:"name"`
+	Description string       `json:"description,omitempty"`
+	Permissions []Permission `json:"permissions"`
+	IsBuiltin   bool         `json:"is_builtin"`
+	CreatedAt   *time.Time   `json:"created_at,omitempty"`
+	UpdatedAt   *time.Time   `json:"updated_at,omitempty"`
+}
@@ -0,0 +1,126 @@
+package rbac
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"time"
+
+	"my-go-driver/internal/domain/company"
+)
+
+// Permission is a named capability a company admin may be granted
+type Permission string
+
+const (
+	PermCompaniesRead     Permission = "companies.read"
+	PermCompaniesWrite    Permission = "companies.write"
+	PermCompaniesBilling  Permission = "companies.billing"
+	PermAdminsWrite       Permission = "admins.write"
+	PermRolesManage       Permission = "roles.manage"
+	PermDriversRead       Permission = "drivers.read"
+	PermDriversWrite      Permission = "drivers.write"
+	PermShiftsRead        Permission = "shifts.read"
+	PermStoresRead        Permission = "stores.read"
+	PermStoresWrite       Permission = "stores.write"
+	PermVehiclesRead      Permission = "vehicles.read"
+	PermVehiclesWrite     Permission = "vehicles.write"
+	PermClientsRead       Permission = "clients.read"
+	PermClientsWrite      Permission = "clients.write"
+	PermModulesRead       Permission = "modules.read"
+	PermModulesAssign     Permission = "modules.assign"
+	PermAPIKeysManage     Permission = "api_keys.manage"
+	PermNotificationsRead Permission = "notifications.read"
+)
+
+// AllPermissions lists every permission known to the system
+var AllPermissions = []Permission{
+	PermCompaniesRead,
+	PermCompaniesWrite,
+	PermCompaniesBilling,
+	PermAdminsWrite,
+	PermRolesManage,
+	PermDriversRead,
+	PermDriversWrite,
+	PermShiftsRead,
+	PermStoresRead,
+	PermStoresWrite,
+	PermVehiclesRead,
+	PermVehiclesWrite,
+	PermClientsRead,
+	PermClientsWrite,
+	PermModulesRead,
+	PermModulesAssign,
+	PermAPIKeysManage,
+	PermNotificationsRead,
+}
+
+// builtinRoles maps the built-in admin roles to their permissions
+var builtinRoles = map[company.AdminRole][]Permission{
+	company.AdminRoleOwner: AllPermissions,
+	company.AdminRoleManager: {
+		PermCompaniesRead,
+		PermDriversRead,
+		PermDriversWrite,
+		PermShiftsRead,
+		PermStoresRead,
+		PermVehiclesRead,
+		PermVehiclesWrite,
+		PermClientsRead,
+		PermClientsWrite,
+		PermModulesRead,
+		PermNotificationsRead,
+	},
+}
+
+// BuiltinPermissions returns the permissions of a built-in admin role
+func BuiltinPermissions(role company.AdminRole) []Permission {
+	return builtinRoles[role]
+}
+
+// IsKnown reports whether p is a permission known to the system
+func IsKnown(p Permission) bool {
+	for _, known := range AllPermissions {
+		if known == p {
+			return true
+		}
+	}
+	return false
+}
+
+// Permissions represents a JSON list of permissions
+type Permissions []Permission
+
+// Scan implements sql.Scanner interface
+func (p *Permissions) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return nil
+	}
+	return json.Unmarshal(bytes, p)
+}
+
+// Value implements driver.Valuer interface
+func (p Permissions) Value() (driver.Value, error) {
+	if p == nil {
+		return json.Marshal([]Permission{})
+	}
+	return json.Marshal(p)
+}
+
+// Role represents a company-defined custom role
+type Role struct {
+	ID          uint64      `json:"id" gorm:"primaryKey"`
+	CompanyID   uint64      `json:"company_id" gorm:"not null"`
+	Name        string      `json:"name" gorm:"not null"`
+	Description string      `json:"description" gorm:"type:text"`
+	Permissions Permissions `json:"permissions" gorm:"type:json"`
+	CreatedAt   time.Time   `json:"created_at"`
+	UpdatedAt   time.Time   `json:"updated_at"`
+}
+
+func (Role) TableName() string {
+	return "roles"
+}
@@ -0,0 +1,10 @@
+package rbac
+
+import "errors"
+
+var (
+	ErrPermissionDenied    = errors.New("permission denied")
+	ErrRoleNotFound        = errors.New("role not found")
+	ErrUnknownPermission   = errors.New("unknown permission")
+	ErrCustomRolesDisabled = errors.New("custom roles require the roles_permissions module")
+)
@@ -0,0 +1,13 @@
+package rbac
+
+import "context"
+
+// Repository defines the interface for custom role data access
+type Repository interface {
+	Create(ctx context.Context, role *Role) error
+	GetByID(ctx context.Context, id uint64) (*Role, error)
+	GetByName(ctx context.Context, companyID uint64, name string) (*Role, error)
+	ListByCompany(ctx context.Context, companyID uint64) ([]Role, error)
+	Update(ctx context.Context, role *Role) error
+	Delete(ctx context.Context, id uint64) error
+}
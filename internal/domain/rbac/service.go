@@ -0,0 +1,16 @@
+package rbac
+
+import "context"
+
+// Service defines the interface for role and permission business logic
+type Service interface {
+	ListPermissions(ctx context.Context) []Permission
+	ListRoles(ctx context.Context, companyID uint64) ([]RoleResponse, error)
+	CreateRole(ctx context.Context, companyID uint64, req CreateRoleRequest) (*RoleResponse, error)
+	UpdateRole(ctx context.Context, companyID, roleID uint64, req UpdateRoleRequest) (*RoleResponse, error)
+	DeleteRole(ctx context.Context, companyID, roleID uint64) error
+	AssignAdminRole(ctx context.Context, adminID uint64, req AssignRoleRequest) error
+
+	// ResolvePermissions returns the effective permissions of a company admin
+	ResolvePermissions(ctx context.Context, adminID uint64) (PermissionSet, error)
+}
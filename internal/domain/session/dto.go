@@ -0,0 +1,15 @@
+package session
+
+import "time"
+
+// RefreshRequest represents a refresh token exchange request
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// TokenPair is returned on login and on every refresh
+type TokenPair struct {
+	Token        string    `json:"token"`
+	RefreshToken string    `json:"refresh_token"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
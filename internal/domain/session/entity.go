@@ -0,0 +1,71 @@
+package session
+
+import (
+	"time"
+
+	"my-go-driver/pkg/jwt"
+)
+
+// Revocation reasons recorded on sessions
+const (
+	RevokeReasonLogout           = "logout"
+	RevokeReasonTokenReuse       = "refresh_token_reuse"
+	RevokeReasonDriverBlocked    = "driver_blocked"
+	RevokeReasonCompanySuspended = "company_suspended"
+	RevokeReasonDeactivated      = "account_deactivated"
+	RevokeReasonPasswordReset    = "password_reset"
+)
+
+// Session represents a login session. Access tokens carry the session ID
+// and are rejected once the session is revoked or expired. The role is the
+// subject's role at login and is copied into every access token.
+type Session struct {
+	ID            uint64          `json:"id" gorm:"primaryKey"`
+	SubjectType   jwt.SubjectType `json:"subject_type" gorm:"type:varchar(20);not null"`
+	SubjectID     uint64          `json:"subject_id" gorm:"not null"`
+	CompanyID     *uint64         `json:"company_id"`
+	Role          string          `json:"role" gorm:"size:50"`
+	ExpiresAt     time.Time       `json:"expires_at" gorm:"not null"`
+	RevokedAt     *time.Time      `json:"revoked_at"`
+	RevokedReason string          `json:"revoked_reason" gorm:"size:50"`
+	CreatedAt     time.Time       `json:"created_at"`
+	UpdatedAt     time.Time       `json:"updated_at"`
+}
+
+func (Session) TableName() string {
+	return "auth_sessions"
+}
+
+// IsActive reports whether the session can still be used
+func (s *Session) IsActive(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
+
+// RefreshToken is a single-use token that is rotated on every refresh.
+// Only the SHA-256 hash of the token is stored.
+type RefreshToken struct {
+	ID        uint64     `json:"id" gorm:"primaryKey"`
+	SessionID uint64     `json:"session_id" gorm:"not null;index"`
+	TokenHash string     `json:"-" gorm:"size:64;not null;uniqueIndex"`
+	ExpiresAt time.Time  `json:"expires_at" gorm:"not null"`
+	UsedAt    *time.Time `json:"used_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+func (RefreshToken) TableName() string {
+	return "refresh_tokens"
+}
+
+// Subject returns the token subject for access tokens issued in this session
+func (s *Session) Subject() jwt.Subject {
+	subject := jwt.Subject{
+		Type:      s.SubjectType,
+		ID:        s.SubjectID,
+		Role:      s.Role,
+		SessionID: s.ID,
+	}
+	if s.CompanyID != nil {
+		subject.CompanyID = *s.CompanyID
+	}
+	return subject
+}
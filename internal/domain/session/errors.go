@@ -0,0 +1,9 @@
+package session
+
+import "errors"
+
+var (
+	ErrInvalidRefreshToken = errors.New("invalid or expired refresh token")
+	ErrRefreshTokenReused  = errors.New("refresh token has already been used; session revoked")
+	ErrSessionRevoked      = errors.New("session has been revoked or has expired")
+)
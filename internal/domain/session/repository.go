@@ -0,0 +1,22 @@
+package session
+
+import (
+	"context"
+
+	"my-go-driver/pkg/jwt"
+)
+
+// Repository defines the interface for session data access
+type Repository interface {
+	CreateSession(ctx context.Context, s *Session) error
+	GetSessionByID(ctx context.Context, id uint64) (*Session, error)
+	RevokeSession(ctx context.Context, id uint64, reason string) error
+	RevokeSubjectSessions(ctx context.Context, subjectType jwt.SubjectType, subjectID uint64, reason string) error
+	RevokeCompanySessions(ctx context.Context, companyID uint64, reason string) error
+
+	CreateRefreshToken(ctx context.Context, t *RefreshToken) error
+	GetRefreshTokenByHash(ctx context.Context, tokenHash string) (*RefreshToken, error)
+	// MarkRefreshTokenUsed marks the token as used, returning false if it
+	// had already been used by a concurrent request.
+	MarkRefreshTokenUsed(ctx context.Context, id uint64) (bool, error)
+}
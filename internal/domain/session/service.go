@@ -0,0 +1,18 @@
+package session
+
+import (
+	"context"
+
+	"my-go-driver/pkg/jwt"
+)
+
+// Service defines the interface for session and token lifecycle logic
+type Service interface {
+	Issue(ctx context.Context, subject jwt.Subject) (*TokenPair, error)
+	Refresh(ctx context.Context, subjectType jwt.SubjectType, refreshToken string) (*TokenPair, error)
+	ValidateSession(ctx context.Context, sessionID uint64) error
+
+	Revoke(ctx context.Context, sessionID uint64) error
+	RevokeAll(ctx context.Context, subjectType jwt.SubjectType, subjectID uint64, reason string) error
+	RevokeCompany(ctx context.Context, companyID uint64, reason string) error
+}
@@ -7,6 +7,7 @@ type ShiftResponse struct {
 	ID              uint64      `json:"id"`
 	DriverID        uint64      `json:"driver_id"`
 	CompanyID       uint64      `json:"company_id"`
+	VehicleID       *uint64     `json:"vehicle_id"`
 	ShiftDate       time.Time   `json:"shift_date"`
 	StartTime       *time.Time  `json:"start_time"`
 	EndTime         *time.Time  `json:"end_time"`
@@ -23,6 +24,12 @@ type ShiftResponse struct {
 	UpdatedAt       time.Time   `json:"updated_at"`
 }
 
+// EndShiftRequest represents request to end a shift. The distance driven
+// counts towards the maintenance of the shift's vehicle.
+type EndShiftRequest struct {
+	TotalDistance *float64 `json:"total_distance" binding:"required,min=0"`
+}
+
 // ListShiftsQuery represents query parameters for listing shifts
 type ListShiftsQuery struct {
 	Page      int         `form:"page" binding:"omitempty,min=1"`
@@ -16,6 +16,7 @@ type DriverShift struct {
 	ID              uint64      `json:"id" gorm:"primaryKey"`
 	DriverID        uint64      `json:"driver_id" gorm:"not null"`
 	CompanyID       uint64      `json:"company_id" gorm:"not null"`
+	VehicleID       *uint64     `json:"vehicle_id"`
 	ShiftDate       time.Time   `json:"shift_date" gorm:"type:date;not null"`
 	StartTime       *time.Time  `json:"start_time"`
 	EndTime         *time.Time  `json:"end_time"`
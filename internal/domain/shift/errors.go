@@ -0,0 +1,8 @@
+package shift
+
+import "errors"
+
+var (
+	ErrShiftInProgress = errors.New("driver already has a shift in progress")
+	ErrNoShiftStarted  = errors.New("driver has no shift in progress")
+)
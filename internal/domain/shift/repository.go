@@ -1,8 +1,19 @@
 package shift
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // Repository defines the interface for shift data access
 type Repository interface {
 	GetByDriverID(ctx context.Context, driverID uint64, query ListShiftsQuery) ([]DriverShift, int64, error)
+	Create(ctx context.Context, shift *DriverShift) error
+	// GetOngoing returns the driver's shift in progress, locking it until the
+	// transaction ends
+	GetOngoing(ctx context.Context, driverID uint64) (*DriverShift, error)
+	Update(ctx context.Context, shift *DriverShift) error
+	// SumVehicleDistance totals the distance of the shifts driven with the
+	// vehicle that started at or after since
+	SumVehicleDistance(ctx context.Context, vehicleID uint64, since time.Time) (float64, error)
 }
@@ -5,4 +5,10 @@ import "context"
 // Service defines the interface for shift business logic
 type Service interface {
 	GetDriverShifts(ctx context.Context, driverID uint64, query ListShiftsQuery) (*PaginatedShiftsResponse, error)
+	// StartShift starts a shift for the driver. In the auto vehicle
+	// assignment mode the driver is also given a vehicle of their store.
+	StartShift(ctx context.Context, driverID uint64) (*ShiftResponse, error)
+	// EndShift ends the driver's shift in progress and records the distance
+	// driven, releasing the vehicle if it was assigned for the shift
+	EndShift(ctx context.Context, driverID uint64, req EndShiftRequest) (*ShiftResponse, error)
 }
@@ -0,0 +1,58 @@
+package store
+
+import "time"
+
+// CreateStoreRequest represents request to create a new store
+type CreateStoreRequest struct {
+	CompanyID    uint64       `json:"company_id" binding:"required"`
+	Name         string       `json:"name" binding:"required,min=2,max=255"`
+	Phone        string       `json:"phone" binding:"omitempty,max=50"`
+	Address      string       `json:"address"`
+	Latitude     *float64     `json:"latitude" binding:"omitempty,min=-90,max=90"`
+	Longitude    *float64     `json:"longitude" binding:"omitempty,min=-180,max=180"`
+	OpeningHours OpeningHours `json:"opening_hours"`
+}
+
+// UpdateStoreRequest represents request to update a store. Opening hours
+// replace the current ones when given.
+type UpdateStoreRequest struct {
+	Name         string       `json:"name" binding:"omitempty,min=2,max=255"`
+	Phone        string       `json:"phone" binding:"omitempty,max=50"`
+	Address      string       `json:"address"`
+	Latitude     *float64     `json:"latitude" binding:"omitempty,min=-90,max=90"`
+	Longitude    *float64     `json:"longitude" binding:"omitempty,min=-180,max=180"`
+	OpeningHours OpeningHours `json:"opening_hours"`
+}
+
+// StoreResponse represents store response
+type StoreResponse struct {
+	ID           uint64       `json:"id"`
+	CompanyID    uint64       `json:"company_id"`
+	Name         string       `json:"name"`
+	Phone        string       `json:"phone"`
+	Address      string       `json:"address"`
+	Latitude     *float64     `json:"latitude"`
+	Longitude    *float64     `json:"longitude"`
+	OpeningHours OpeningHours `json:"opening_hours"`
+	Status       StoreStatus  `json:"status"`
+	CreatedAt    time.Time    `json:"created_at"`
+	UpdatedAt    time.Time    `json:"updated_at"`
+}
+
+// ListStoresQuery represents query parameters for listing stores
+type ListStoresQuery struct {
+	Page      int         `form:"page" binding:"omitempty,min=1"`
+	Limit     int         `form:"limit" binding:"omitempty,min=1,max=100"`
+	CompanyID uint64      `form:"company_id" binding:"omitempty"`
+	Status    StoreStatus `form:"status" binding:"omitempty,oneof=active inactive"`
+	Search    string      `form:"search" binding:"omitempty"`
+}
+
+// PaginatedStoresResponse represents paginated stores response
+type PaginatedStoresResponse struct {
+	Stores     []StoreResponse `json:"stores"`
+	TotalCount int64           `json:"total_count"`
+	Page       int             `json:"page"`
+	Limit      int             `json:"limit"`
+	TotalPages int             `json:"total_pages"`
+}
@@ -0,0 +1,139 @@
+package store
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+)
+
+type StoreStatus string
+
+const (
+	StoreStatusActive   StoreStatus = "active"
+	StoreStatusInactive StoreStatus = "inactive"
+)
+
+// Store represents a branch of a company that drivers and orders belong to
+type Store struct {
+	ID           uint64       `json:"id" gorm:"primaryKey"`
+	CompanyID    uint64       `json:"company_id" gorm:"not null"`
+	Name         string       `json:"name" gorm:"not null"`
+	Phone        string       `json:"phone"`
+	Address      string       `json:"address" gorm:"type:text"`
+	Latitude     *float64     `json:"latitude" gorm:"type:decimal(10,8)"`
+	Longitude    *float64     `json:"longitude" gorm:"type:decimal(11,8)"`
+	OpeningHours OpeningHours `json:"opening_hours" gorm:"type:json"`
+	Status       StoreStatus  `json:"status" gorm:"type:enum('active','inactive');default:active"`
+	CreatedAt    time.Time    `json:"created_at"`
+	UpdatedAt    time.Time    `json:"updated_at"`
+}
+
+func (Store) TableName() string {
+	return "stores"
+}
+
+// Period is a time range within a day, as "HH:MM" in the company's timezone.
+// Close may be "24:00" for a store open until midnight.
+type Period struct {
+	Open  string `json:"open"`
+	Close string `json:"close"`
+}
+
+// OpeningHours maps weekdays ("monday" to "sunday") to the periods the store
+// is open that day. Days that are not listed are closed.
+type OpeningHours map[string][]Period
+
+// Scan implements sql.Scanner interface
+func (h *OpeningHours) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return nil
+	}
+	return json.Unmarshal(bytes, h)
+}
+
+// Value implements driver.Valuer interface
+func (h OpeningHours) Value() (driver.Value, error) {
+	if h == nil {
+		return nil, nil
+	}
+	return json.Marshal(h)
+}
+
+// Weekdays lists the keys of OpeningHours, starting on Monday
+var Weekdays = []string{"monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday"}
+
+// Validate checks that every key is a weekday and that each day's periods
+// are well-formed, close after they open and do not overlap
+func (h OpeningHours) Validate() error {
+	for day, periods := range h {
+		if !isWeekday(day) {
+			return fmt.Errorf("%w: unknown day %q", ErrInvalidOpeningHours, day)
+		}
+
+		type minutes struct{ open, close int }
+		ranges := make([]minutes, len(periods))
+		for i, p := range periods {
+			open, ok := parseClock(p.Open)
+			if !ok || open == 24*60 {
+				return fmt.Errorf("%w: %s: invalid opening time %q", ErrInvalidOpeningHours, day, p.Open)
+			}
+			closing, ok := parseClock(p.Close)
+			if !ok {
+				return fmt.Errorf("%w: %s: invalid closing time %q", ErrInvalidOpeningHours, day, p.Close)
+			}
+			if closing <= open {
+				return fmt.Errorf("%w: %s: %s-%s closes before it opens", ErrInvalidOpeningHours, day, p.Open, p.Close)
+			}
+			ranges[i] = minutes{open, closing}
+		}
+
+		sort.Slice(ranges, func(i, j int) bool { return ranges[i].open < ranges[j].open })
+		for i := 1; i < len(ranges); i++ {
+			if ranges[i].open < ranges[i-1].close {
+				return fmt.Errorf("%w: %s: periods overlap", ErrInvalidOpeningHours, day)
+			}
+		}
+	}
+	return nil
+}
+
+func isWeekday(day string) bool {
+	for _, d := range Weekdays {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
+
+// parseClock parses "HH:MM" into minutes after midnight, accepting "24:00"
+func parseClock(s string) (int, bool) {
+	if len(s) != 5 || s[2] != ':' {
+		return 0, false
+	}
+	// Only digits, so signs such as "+1:00" are rejected
+	for _, i := range []int{0, 1, 3, 4} {
+		if s[i] < '0' || s[i] > '9' {
+			return 0, false
+		}
+	}
+	hours, err := strconv.Atoi(s[:2])
+	if err != nil {
+		return 0, false
+	}
+	mins, err := strconv.Atoi(s[3:])
+	if err != nil || mins > 59 {
+		return 0, false
+	}
+	if hours > 24 || (hours == 24 && mins != 0) {
+		return 0, false
+	}
+	return hours*60 + mins, true
+}
@@ -0,0 +1,117 @@
+package store
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestOpeningHoursValidate(t *testing.T) {
+	day := func(periods ...Period) OpeningHours {
+		return OpeningHours{"monday": periods}
+	}
+
+	tests := []struct {
+		name    string
+		hours   OpeningHours
+		wantErr bool
+	}{
+		{
+			name:  "no hours",
+			hours: OpeningHours{},
+		},
+		{
+			name:  "several days and periods",
+			hours: OpeningHours{"monday": {{"08:00", "12:00"}, {"13:00", "18:30"}}, "sunday": {{"10:00", "14:00"}}},
+		},
+		{
+			name:  "closing at midnight",
+			hours: day(Period{"18:00", "24:00"}),
+		},
+		{
+			name:  "touching periods",
+			hours: day(Period{"08:00", "12:00"}, Period{"12:00", "16:00"}),
+		},
+		{
+			name:    "opening at 24:00",
+			hours:   day(Period{"24:00", "24:00"}),
+			wantErr: true,
+		},
+		{
+			name:    "past 24:00",
+			hours:   day(Period{"08:00", "24:01"}),
+			wantErr: true,
+		},
+		{
+			name:    "hour out of range",
+			hours:   day(Period{"25:00", "26:00"}),
+			wantErr: true,
+		},
+		{
+			name:    "minute out of range",
+			hours:   day(Period{"08:60", "09:00"}),
+			wantErr: true,
+		},
+		{
+			name:    "signed hour",
+			hours:   day(Period{"+1:00", "09:00"}),
+			wantErr: true,
+		},
+		{
+			name:    "negative zero",
+			hours:   day(Period{"08:00", "-0:00"}),
+			wantErr: true,
+		},
+		{
+			name:    "signed minute",
+			hours:   day(Period{"08:+5", "09:00"}),
+			wantErr: true,
+		},
+		{
+			name:    "missing leading zero",
+			hours:   day(Period{"8:00", "09:00"}),
+			wantErr: true,
+		},
+		{
+			name:    "wrong separator",
+			hours:   day(Period{"08.00", "09:00"}),
+			wantErr: true,
+		},
+		{
+			name:    "closes before it opens",
+			hours:   day(Period{"18:00", "08:00"}),
+			wantErr: true,
+		},
+		{
+			name:    "closes when it opens",
+			hours:   day(Period{"08:00", "08:00"}),
+			wantErr: true,
+		},
+		{
+			name:    "overlapping periods",
+			hours:   day(Period{"13:00", "18:00"}, Period{"08:00", "13:30"}),
+			wantErr: true,
+		},
+		{
+			name:    "unknown day",
+			hours:   OpeningHours{"funday": {{"08:00", "12:00"}}},
+			wantErr: true,
+		},
+		{
+			name:    "capitalised day",
+			hours:   OpeningHours{"Monday": {{"08:00", "12:00"}}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.hours.Validate()
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("Validate() error = %v, want error %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidOpeningHours) {
+				t.Fatalf("Validate() error = %v, want %v", err, ErrInvalidOpeningHours)
+			}
+		})
+	}
+}
@@ -0,0 +1,11 @@
+package store
+
+import "errors"
+
+var (
+	ErrStoreNotFound         = errors.New("store not found")
+	ErrStoreInactive         = errors.New("store is inactive")
+	ErrInvalidOpeningHours   = errors.New("invalid opening hours")
+	ErrIncompleteCoordinates = errors.New("latitude and longitude must be set together")
+	ErrStoreHasOrders        = errors.New("store has orders; deactivate it instead")
+)
@@ -0,0 +1,15 @@
+package store
+
+import "context"
+
+// Repository defines the interface for store data access
+type Repository interface {
+	Create(ctx context.Context, store *Store) error
+	GetByID(ctx context.Context, id uint64) (*Store, error)
+	Update(ctx context.Context, store *Store) error
+	Delete(ctx context.Context, id uint64) error
+	// HasOrders reports whether any order was placed with the store
+	HasOrders(ctx context.Context, id uint64) (bool, error)
+	List(ctx context.Context, query ListStoresQuery) ([]Store, int64, error)
+	UpdateStatus(ctx context.Context, id uint64, status StoreStatus) error
+}
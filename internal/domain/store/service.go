@@ -0,0 +1,18 @@
+package store
+
+import "context"
+
+// Service defines the interface for store business logic
+type Service interface {
+	CreateStore(ctx context.Context, req CreateStoreRequest) (*StoreResponse, error)
+	GetStore(ctx context.Context, id uint64) (*StoreResponse, error)
+	UpdateStore(ctx context.Context, id uint64, req UpdateStoreRequest) (*StoreResponse, error)
+	DeleteStore(ctx context.Context, id uint64) error
+	ListStores(ctx context.Context, query ListStoresQuery) (*PaginatedStoresResponse, error)
+	ActivateStore(ctx context.Context, id uint64) error
+	DeactivateStore(ctx context.Context, id uint64) error
+
+	// CheckAssignable verifies that the store exists, belongs to the company
+	// and is active, so drivers and other resources can be assigned to it
+	CheckAssignable(ctx context.Context, companyID, storeID uint64) error
+}
@@ -0,0 +1,105 @@
+package vehicle
+
+import "time"
+
+// DateFormat is the layout of vehicle dates in requests and responses
+const DateFormat = "2006-01-02"
+
+// CreateVehicleRequest represents request to create a new vehicle
+type CreateVehicleRequest struct {
+	CompanyID     uint64      `json:"company_id" binding:"required"`
+	StoreID       *uint64     `json:"store_id"`
+	PlateNumber   string      `json:"plate_number" binding:"required,max=50"`
+	Type          VehicleType `json:"type" binding:"required,oneof=bike car van truck"`
+	Capacity      *float64    `json:"capacity" binding:"omitempty,min=0"`
+	FuelType      string      `json:"fuel_type" binding:"omitempty,max=50"`
+	LastOilChange string      `json:"last_oil_change" binding:"omitempty,datetime=2006-01-02"`
+}
+
+// UpdateVehicleRequest represents request to update vehicle
+type UpdateVehicleRequest struct {
+	StoreID       *uint64     `json:"store_id"`
+	PlateNumber   string      `json:"plate_number" binding:"omitempty,max=50"`
+	Type          VehicleType `json:"type" binding:"omitempty,oneof=bike car van truck"`
+	Capacity      *float64    `json:"capacity" binding:"omitempty,min=0"`
+	FuelType      string      `json:"fuel_type" binding:"omitempty,max=50"`
+	LastOilChange string      `json:"last_oil_change" binding:"omitempty,datetime=2006-01-02"`
+}
+
+// ChangeStatusRequest represents request to change a vehicle's status
+type ChangeStatusRequest struct {
+	Status VehicleStatus `json:"status" binding:"required,oneof=active maintenance out_of_service"`
+}
+
+// VehicleResponse represents vehicle response
+type VehicleResponse struct {
+	ID             uint64        `json:"id"`
+	CompanyID      uint64        `json:"company_id"`
+	StoreID        *uint64       `json:"store_id"`
+	PlateNumber    string        `json:"plate_number"`
+	Type           VehicleType   `json:"type"`
+	Capacity       *float64      `json:"capacity"`
+	Status         VehicleStatus `json:"status"`
+	FuelType       string        `json:"fuel_type"`
+	LastOilChange  *string       `json:"last_oil_change"`
+	MaintenanceDue []string      `json:"maintenance_due"`
+	CreatedAt      time.Time     `json:"created_at"`
+	UpdatedAt      time.Time     `json:"updated_at"`
+}
+
+// ListVehiclesQuery represents query parameters for listing vehicles
+type ListVehiclesQuery struct {
+	Page      int           `form:"page" binding:"omitempty,min=1"`
+	Limit     int           `form:"limit" binding:"omitempty,min=1,max=100"`
+	CompanyID uint64        `form:"company_id" binding:"omitempty"`
+	StoreID   uint64        `form:"store_id" binding:"omitempty"`
+	Type      VehicleType   `form:"type" binding:"omitempty,oneof=bike car van truck"`
+	Status    VehicleStatus `form:"status" binding:"omitempty,oneof=active maintenance out_of_service"`
+	// MaintenanceDue filters vehicles by whether they are overdue for a service
+	MaintenanceDue *bool  `form:"maintenance_due" binding:"omitempty"`
+	Search         string `form:"search" binding:"omitempty"`
+}
+
+// PaginatedVehiclesResponse represents paginated vehicles response
+type PaginatedVehiclesResponse struct {
+	Vehicles   []VehicleResponse `json:"vehicles"`
+	TotalCount int64             `json:"total_count"`
+	Page       int               `json:"page"`
+	Limit      int               `json:"limit"`
+	TotalPages int               `json:"total_pages"`
+}
+
+// AssignDriverRequest represents request to assign a vehicle to a driver
+type AssignDriverRequest struct {
+	DriverID uint64 `json:"driver_id" binding:"required"`
+}
+
+// AssignmentResponse represents a driver-vehicle assignment response
+type AssignmentResponse struct {
+	ID           uint64     `json:"id"`
+	DriverID     uint64     `json:"driver_id"`
+	VehicleID    uint64     `json:"vehicle_id"`
+	IsActive     bool       `json:"is_active"`
+	AutoAssigned bool       `json:"auto_assigned"`
+	AssignedAt   time.Time  `json:"assigned_at"`
+	UnassignedAt *time.Time `json:"unassigned_at"`
+}
+
+// ListAssignmentsQuery represents query parameters for listing assignments.
+// DriverID and VehicleID are taken from the path.
+type ListAssignmentsQuery struct {
+	Page      int    `form:"page" binding:"omitempty,min=1"`
+	Limit     int    `form:"limit" binding:"omitempty,min=1,max=100"`
+	DriverID  uint64 `form:"-"`
+	VehicleID uint64 `form:"-"`
+	Active    *bool  `form:"active" binding:"omitempty"`
+}
+
+// PaginatedAssignmentsResponse represents paginated assignments response
+type PaginatedAssignmentsResponse struct {
+	Assignments []AssignmentResponse `json:"assignments"`
+	TotalCount  int64                `json:"total_count"`
+	Page        int                  `json:"page"`
+	Limit       int                  `json:"limit"`
+	TotalPages  int                  `json:"total_pages"`
+}
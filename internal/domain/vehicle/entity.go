@@ -0,0 +1,118 @@
+package vehicle
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+type VehicleType string
+
+const (
+	VehicleTypeBike  VehicleType = "bike"
+	VehicleTypeCar   VehicleType = "car"
+	VehicleTypeVan   VehicleType = "van"
+	VehicleTypeTruck VehicleType = "truck"
+)
+
+type VehicleStatus string
+
+const (
+	VehicleStatusActive       VehicleStatus = "active"
+	VehicleStatusMaintenance  VehicleStatus = "maintenance"
+	VehicleStatusOutOfService VehicleStatus = "out_of_service"
+)
+
+// statusTransitions lists the statuses each status may change to. A vehicle
+// taken out of service goes through maintenance before it is active again.
+var statusTransitions = map[VehicleStatus][]VehicleStatus{
+	VehicleStatusActive:       {VehicleStatusMaintenance, VehicleStatusOutOfService},
+	VehicleStatusMaintenance:  {VehicleStatusActive, VehicleStatusOutOfService},
+	VehicleStatusOutOfService: {VehicleStatusMaintenance},
+}
+
+// CanTransition reports whether a vehicle may change from one status to another
+func CanTransition(from, to VehicleStatus) bool {
+	for _, allowed := range statusTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// NormalizePlate returns the form a plate number is stored and compared in
+func NormalizePlate(plate string) string {
+	return strings.ToUpper(strings.TrimSpace(plate))
+}
+
+// Vehicle represents a vehicle of a company's fleet
+type Vehicle struct {
+	ID            uint64        `json:"id" gorm:"primaryKey"`
+	CompanyID     uint64        `json:"company_id" gorm:"not null"`
+	StoreID       *uint64       `json:"store_id"`
+	PlateNumber   string        `json:"plate_number" gorm:"not null"`
+	Type          VehicleType   `json:"type" gorm:"type:enum('bike','car','van','truck');not null"`
+	Capacity      *float64      `json:"capacity" gorm:"type:decimal(10,2)"`
+	Status        VehicleStatus `json:"status" gorm:"type:enum('active','maintenance','out_of_service');default:active"`
+	FuelType      string        `json:"fuel_type"`
+	LastOilChange *time.Time    `json:"last_oil_change" gorm:"type:date"`
+	// MaintenanceDue lists the service types the vehicle is overdue for
+	MaintenanceDue ServiceTypes `json:"maintenance_due" gorm:"type:json"`
+	CreatedAt      time.Time    `json:"created_at"`
+	UpdatedAt      time.Time    `json:"updated_at"`
+}
+
+func (Vehicle) TableName() string {
+	return "vehicles"
+}
+
+// ServiceTypes is a JSON list of maintenance service types
+type ServiceTypes []string
+
+// Scan implements sql.Scanner interface
+func (t *ServiceTypes) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return nil
+	}
+	return json.Unmarshal(bytes, t)
+}
+
+// Value implements driver.Valuer interface
+func (t ServiceTypes) Value() (driver.Value, error) {
+	if t == nil {
+		return nil, nil
+	}
+	return json.Marshal(t)
+}
+
+// Contains reports whether the list holds the service type
+func (t ServiceTypes) Contains(serviceType string) bool {
+	for _, existing := range t {
+		if existing == serviceType {
+			return true
+		}
+	}
+	return false
+}
+
+// Assignment records a vehicle being assigned to a driver. Ended
+// assignments are kept as history.
+type Assignment struct {
+	ID           uint64     `json:"id" gorm:"primaryKey"`
+	DriverID     uint64     `json:"driver_id" gorm:"not null"`
+	VehicleID    uint64     `json:"vehicle_id" gorm:"not null"`
+	IsActive     bool       `json:"is_active" gorm:"default:true"`
+	AutoAssigned bool       `json:"auto_assigned" gorm:"default:false"`
+	AssignedAt   time.Time  `json:"assigned_at"`
+	UnassignedAt *time.Time `json:"unassigned_at"`
+}
+
+func (Assignment) TableName() string {
+	return "driver_vehicle_assignments"
+}
@@ -0,0 +1,15 @@
+package vehicle
+
+import "errors"
+
+var (
+	ErrVehicleNotFound         = errors.New("vehicle not found")
+	ErrPlateTaken              = errors.New("a vehicle with this plate number already exists in this company")
+	ErrInvalidStatusTransition = errors.New("vehicle status cannot change this way")
+	ErrVehicleUnavailable      = errors.New("only active vehicles can be assigned")
+	ErrVehicleAssigned         = errors.New("vehicle is already assigned to a driver")
+	ErrDriverHasVehicle        = errors.New("driver already has a vehicle assigned")
+	ErrNotAssigned             = errors.New("vehicle is not assigned to a driver")
+	ErrNoVehicleAvailable      = errors.New("no vehicle is available for the driver")
+	ErrVehicleHasHistory       = errors.New("vehicle has assignment, shift or maintenance history; take it out of service instead")
+)
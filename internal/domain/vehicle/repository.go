@@ -0,0 +1,37 @@
+package vehicle
+
+import (
+	"context"
+	"time"
+)
+
+// Repository defines the interface for vehicle data access
+type Repository interface {
+	Create(ctx context.Context, vehicle *Vehicle) error
+	GetByID(ctx context.Context, id uint64) (*Vehicle, error)
+	// GetByIDForUpdate loads a vehicle and locks it until the transaction ends
+	GetByIDForUpdate(ctx context.Context, id uint64) (*Vehicle, error)
+	GetByPlate(ctx context.Context, plateNumber string, companyID uint64) (*Vehicle, error)
+	Update(ctx context.Context, vehicle *Vehicle) error
+	Delete(ctx context.Context, id uint64) error
+	// HasHistory reports whether the vehicle was ever assigned, driven on a
+	// shift or serviced
+	HasHistory(ctx context.Context, id uint64) (bool, error)
+	List(ctx context.Context, query ListVehiclesQuery) ([]Vehicle, int64, error)
+	UpdateStatus(ctx context.Context, id uint64, status VehicleStatus) error
+	// ListByCompany returns every vehicle of the company that is in service
+	ListByCompany(ctx context.Context, companyID uint64) ([]Vehicle, error)
+	SetMaintenanceDue(ctx context.Context, id uint64, due ServiceTypes) error
+	UpdateLastOilChange(ctx context.Context, id uint64, date time.Time) error
+
+	// Assignment operations. The active assignment lookups lock the rows they
+	// read, or the gap where one would be, until the transaction ends.
+	CreateAssignment(ctx context.Context, assignment *Assignment) error
+	GetActiveAssignmentByVehicle(ctx context.Context, vehicleID uint64) (*Assignment, error)
+	GetActiveAssignmentByDriver(ctx context.Context, driverID uint64) (*Assignment, error)
+	EndAssignment(ctx context.Context, id uint64, at time.Time) error
+	ListAssignments(ctx context.Context, query ListAssignmentsQuery) ([]Assignment, int64, error)
+	// FindAvailable returns an active, unassigned vehicle of the company based
+	// at the store, or at no store when storeID is nil
+	FindAvailable(ctx context.Context, companyID uint64, storeID *uint64) (*Vehicle, error)
+}
@@ -0,0 +1,31 @@
+package vehicle
+
+import "context"
+
+// Service defines the interface for vehicle business logic
+type Service interface {
+	CreateVehicle(ctx context.Context, req CreateVehicleRequest) (*VehicleResponse, error)
+	GetVehicle(ctx context.Context, id uint64) (*VehicleResponse, error)
+	UpdateVehicle(ctx context.Context, id uint64, req UpdateVehicleRequest) (*VehicleResponse, error)
+	DeleteVehicle(ctx context.Context, id uint64) error
+	ListVehicles(ctx context.Context, query ListVehiclesQuery) (*PaginatedVehiclesResponse, error)
+	// ChangeStatus moves the vehicle to another status, ending its assignment
+	// when it leaves active service
+	ChangeStatus(ctx context.Context, id uint64, req ChangeStatusRequest) (*VehicleResponse, error)
+
+	AssignDriver(ctx context.Context, vehicleID uint64, req AssignDriverRequest) (*AssignmentResponse, error)
+	// UnassignDriver ends the vehicle's active assignment
+	UnassignDriver(ctx context.Context, vehicleID uint64) (*AssignmentResponse, error)
+	// GetDriverAssignment returns the driver's active assignment, or
+	// ErrNotAssigned when the driver has no vehicle
+	GetDriverAssignment(ctx context.Context, driverID uint64) (*AssignmentResponse, error)
+	ListAssignments(ctx context.Context, query ListAssignmentsQuery) (*PaginatedAssignmentsResponse, error)
+	// AutoAssign assigns an available vehicle of the driver's store to the
+	// driver, keeping the vehicle the driver already has
+	AutoAssign(ctx context.Context, driverID uint64) (*AssignmentResponse, error)
+	// ReleaseDriver ends the driver's active assignment, if any
+	ReleaseDriver(ctx context.Context, driverID uint64) error
+	// ReleaseAutoAssignment ends the driver's active assignment if AutoAssign
+	// made it, leaving assignments made by admins
+	ReleaseAutoAssignment(ctx context.Context, driverID uint64) error
+}
@@ -0,0 +1,159 @@
+package handler
+
+import (
+	"net/http"
+
+	"my-go-driver/internal/domain/account"
+	"my-go-driver/internal/middleware"
+	"my-go-driver/pkg/httputil"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AdminAccountHandler struct {
+	accountService account.Service
+}
+
+func NewAdminAccountHandler(accountService account.Service) *AdminAccountHandler {
+	return &AdminAccountHandler{
+		accountService: accountService,
+	}
+}
+
+// InviteAdmin invites a new company admin by email
+// @Summary Invite company admin
+// @Tags Admin - Auth
+// @Accept json
+// @Produce json
+// @Param request body account.InviteAdminRequest true "Invite request"
+// @Success 201 {object} company.CompanyAdminResponse
+// @Router /api/v1/admin/admins/invite [post]
+func (h *AdminAccountHandler) InviteAdmin(c *gin.Context) {
+	var req account.InviteAdminRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	result, err := h.accountService.InviteAdmin(c.Request.Context(), req)
+	if err != nil {
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to invite admin", errorDetails(err))
+		return
+	}
+
+	httputil.RespondSuccess(c, http.StatusCreated, "Invitation sent successfully", result)
+}
+
+// AcceptInvite sets the invited admin's password
+// @Summary Accept admin invite
+// @Tags Admin - Auth
+// @Accept json
+// @Produce json
+// @Param request body account.AcceptInviteRequest true "Invite token and new password"
+// @Success 200
+// @Router /api/v1/admin/auth/accept-invite [post]
+func (h *AdminAccountHandler) AcceptInvite(c *gin.Context) {
+	var req account.AcceptInviteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	if err := h.accountService.AcceptInvite(c.Request.Context(), req); err != nil {
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to accept invite", err.Error())
+		return
+	}
+
+	httputil.RespondSuccess(c, http.StatusOK, "Invite accepted successfully", nil)
+}
+
+// ForgotPassword emails a password reset link
+// @Summary Forgot password
+// @Tags Admin - Auth
+// @Accept json
+// @Produce json
+// @Param request body account.ForgotPasswordRequest true "Admin email"
+// @Success 200
+// @Router /api/v1/admin/auth/forgot-password [post]
+func (h *AdminAccountHandler) ForgotPassword(c *gin.Context) {
+	var req account.ForgotPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+	req.IPAddress = c.ClientIP()
+
+	if err := h.accountService.ForgotPassword(c.Request.Context(), req); err != nil {
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to request password reset", err.Error())
+		return
+	}
+
+	httputil.RespondSuccess(c, http.StatusOK, "If the email belongs to an account, a reset link has been sent", nil)
+}
+
+// ResetPassword sets a new password using a reset token
+// @Summary Reset password
+// @Tags Admin - Auth
+// @Accept json
+// @Produce json
+// @Param request body account.ResetPasswordRequest true "Reset token and new password"
+// @Success 200
+// @Router /api/v1/admin/auth/reset-password [post]
+func (h *AdminAccountHandler) ResetPassword(c *gin.Context) {
+	var req account.ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	if err := h.accountService.ResetPassword(c.Request.Context(), req); err != nil {
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to reset password", err.Error())
+		return
+	}
+
+	httputil.RespondSuccess(c, http.StatusOK, "Password reset successfully", nil)
+}
+
+// SendEmailVerification emails a verification link to the authenticated admin
+// @Summary Send email verification
+// @Tags Admin - Auth
+// @Produce json
+// @Success 200
+// @Router /api/v1/admin/auth/verify-email/send [post]
+func (h *AdminAccountHandler) SendEmailVerification(c *gin.Context) {
+	adminID, ok := middleware.GetAdminID(c)
+	if !ok {
+		httputil.RespondError(c, http.StatusUnauthorized, "Unauthorized", "User ID not found in context")
+		return
+	}
+
+	if err := h.accountService.SendEmailVerification(c.Request.Context(), adminID); err != nil {
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to send verification email", err.Error())
+		return
+	}
+
+	httputil.RespondSuccess(c, http.StatusOK, "Verification email sent", nil)
+}
+
+// VerifyEmail confirms an admin's email address
+// @Summary Verify email
+// @Tags Admin - Auth
+// @Accept json
+// @Produce json
+// @Param request body account.VerifyEmailRequest true "Verification token"
+// @Success 200
+// @Router /api/v1/admin/auth/verify-email [post]
+func (h *AdminAccountHandler) VerifyEmail(c *gin.Context) {
+	var req account.VerifyEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	if err := h.accountService.VerifyEmail(c.Request.Context(), req); err != nil {
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to verify email", err.Error())
+		return
+	}
+
+	httputil.RespondSuccess(c, http.StatusOK, "Email verified successfully", nil)
+}
@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"my-go-driver/internal/domain/apikey"
+	"my-go-driver/internal/middleware"
+	"my-go-driver/pkg/httputil"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AdminAPIKeyHandler struct {
+	apiKeyService apikey.Service
+}
+
+func NewAdminAPIKeyHandler(apiKeyService apikey.Service) *AdminAPIKeyHandler {
+	return &AdminAPIKeyHandler{
+		apiKeyService: apiKeyService,
+	}
+}
+
+// ListKeys lists the API keys of the admin's company
+// @Summary List API keys
+// @Tags Admin - API Keys
+// @Produce json
+// @Success 200 {array} apikey.APIKeyResponse
+// @Router /api/v1/admin/api-keys [get]
+func (h *AdminAPIKeyHandler) ListKeys(c *gin.Context) {
+	companyID, ok := companyIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.apiKeyService.ListKeys(c.Request.Context(), companyID)
+	if err != nil {
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to list API keys", err.Error())
+		return
+	}
+
+	httputil.RespondSuccess(c, http.StatusOK, "API keys retrieved successfully", result)
+}
+
+// CreateKey creates an API key. The key is only returned in this response.
+// @Summary Create API key
+// @Tags Admin - API Keys
+// @Accept json
+// @Produce json
+// @Param request body apikey.CreateAPIKeyRequest true "API key creation request"
+// @Success 201 {object} apikey.CreatedAPIKeyResponse
+// @Router /api/v1/admin/api-keys [post]
+func (h *AdminAPIKeyHandler) CreateKey(c *gin.Context) {
+	companyID, ok := companyIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	// API keys cannot be used to mint further keys
+	adminID, ok := middleware.GetAdminID(c)
+	if !ok {
+		httputil.RespondError(c, http.StatusForbidden, "API keys can only be created by admins", "")
+		return
+	}
+
+	var req apikey.CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	result, err := h.apiKeyService.CreateKey(c.Request.Context(), companyID, adminID, req)
+	if err != nil {
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to create API key", err.Error())
+		return
+	}
+
+	httputil.RespondSuccess(c, http.StatusCreated, "API key created successfully. Store it now, it will not be shown again", result)
+}
+
+// RevokeKey revokes an API key
+// @Summary Revoke API key
+// @Tags Admin - API Keys
+// @Param id path int true "API key ID"
+// @Success 200 {object} map[string]interface{}
+// @Router /api/v1/admin/api-keys/{id} [delete]
+func (h *AdminAPIKeyHandler) RevokeKey(c *gin.Context) {
+	companyID, ok := companyIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid API key ID", err.Error())
+		return
+	}
+
+	if err := h.apiKeyService.RevokeKey(c.Request.Context(), companyID, id); err != nil {
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to revoke API key", err.Error())
+		return
+	}
+
+	httputil.RespondSuccess(c, http.StatusOK, "API key revoked successfully", nil)
+}
@@ -0,0 +1,298 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"my-go-driver/internal/domain/client"
+	"my-go-driver/pkg/httputil"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AdminClientHandler struct {
+	clientService client.Service
+}
+
+func NewAdminClientHandler(clientService client.Service) *AdminClientHandler {
+	return &AdminClientHandler{
+		clientService: clientService,
+	}
+}
+
+// CreateClient creates a new client
+// @Summary Create client
+// @Tags Admin - Clients
+// @Accept json
+// @Produce json
+// @Param request body client.CreateClientRequest true "Client creation request"
+// @Success 201 {object} client.ClientResponse
+// @Router /api/v1/admin/clients [post]
+func (h *AdminClientHandler) CreateClient(c *gin.Context) {
+	var req client.CreateClientRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	result, err := h.clientService.CreateClient(c.Request.Context(), req)
+	if err != nil {
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to create client", err.Error())
+		return
+	}
+
+	httputil.RespondSuccess(c, http.StatusCreated, "Client created successfully", result)
+}
+
+// GetClient retrieves a client by ID
+// @Summary Get client
+// @Tags Admin - Clients
+// @Produce json
+// @Param id path int true "Client ID"
+// @Success 200 {object} client.ClientResponse
+// @Router /api/v1/admin/clients/{id} [get]
+func (h *AdminClientHandler) GetClient(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid client ID", err.Error())
+		return
+	}
+
+	result, err := h.clientService.GetClient(c.Request.Context(), id)
+	if err != nil {
+		httputil.RespondError(c, errorStatus(err, http.StatusNotFound), "Client not found", err.Error())
+		return
+	}
+
+	httputil.RespondSuccess(c, http.StatusOK, "Client retrieved successfully", result)
+}
+
+// UpdateClient updates a client
+// @Summary Update client
+// @Tags Admin - Clients
+// @Accept json
+// @Produce json
+// @Param id path int true "Client ID"
+// @Param request body client.UpdateClientRequest true "Client update request"
+// @Success 200 {object} client.ClientResponse
+// @Router /api/v1/admin/clients/{id} [put]
+func (h *AdminClientHandler) UpdateClient(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid client ID", err.Error())
+		return
+	}
+
+	var req client.UpdateClientRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	result, err := h.clientService.UpdateClient(c.Request.Context(), id, req)
+	if err != nil {
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to update client", err.Error())
+		return
+	}
+
+	httputil.RespondSuccess(c, http.StatusOK, "Client updated successfully", result)
+}
+
+// DeleteClient deletes a client
+// @Summary Delete client
+// @Tags Admin - Clients
+// @Param id path int true "Client ID"
+// @Success 200 {object} map[string]interface{}
+// @Router /api/v1/admin/clients/{id} [delete]
+func (h *AdminClientHandler) DeleteClient(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid client ID", err.Error())
+		return
+	}
+
+	if err := h.clientService.DeleteClient(c.Request.Context(), id); err != nil {
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to delete client", err.Error())
+		return
+	}
+
+	httputil.RespondSuccess(c, http.StatusOK, "Client deleted successfully", nil)
+}
+
+// ListClients lists clients with pagination
+// @Summary List clients
+// @Tags Admin - Clients
+// @Produce json
+// @Param page query int false "Page number"
+// @Param limit query int false "Items per page"
+// @Param company_id query int false "Company ID"
+// @Param phone query string false "Phone number"
+// @Param search query string false "Search term"
+// @Success 200 {object} client.PaginatedClientsResponse
+// @Router /api/v1/admin/clients [get]
+func (h *AdminClientHandler) ListClients(c *gin.Context) {
+	var query client.ListClientsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid query parameters", err.Error())
+		return
+	}
+
+	result, err := h.clientService.ListClients(c.Request.Context(), query)
+	if err != nil {
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to list clients", err.Error())
+		return
+	}
+
+	httputil.RespondSuccess(c, http.StatusOK, "Clients retrieved successfully", result)
+}
+
+// MergeClients merges duplicate clients into a client
+// @Summary Merge clients
+// @Tags Admin - Clients
+// @Accept json
+// @Produce json
+// @Param id path int true "Client ID to keep"
+// @Param request body client.MergeClientsRequest true "Merge request"
+// @Success 200 {object} client.ClientResponse
+// @Router /api/v1/admin/clients/{id}/merge [post]
+func (h *AdminClientHandler) MergeClients(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid client ID", err.Error())
+		return
+	}
+
+	var req client.MergeClientsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	result, err := h.clientService.MergeClients(c.Request.Context(), id, req)
+	if err != nil {
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to merge clients", err.Error())
+		return
+	}
+
+	httputil.RespondSuccess(c, http.StatusOK, "Clients merged successfully", result)
+}
+
+// AddAddress saves a new address for a client
+// @Summary Add client address
+// @Tags Admin - Clients
+// @Accept json
+// @Produce json
+// @Param id path int true "Client ID"
+// @Param request body client.AddressRequest true "Address request"
+// @Success 201 {object} client.AddressResponse
+// @Router /api/v1/admin/clients/{id}/addresses [post]
+func (h *AdminClientHandler) AddAddress(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid client ID", err.Error())
+		return
+	}
+
+	var req client.AddressRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	result, err := h.clientService.AddAddress(c.Request.Context(), id, req)
+	if err != nil {
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to add client address", err.Error())
+		return
+	}
+
+	httputil.RespondSuccess(c, http.StatusCreated, "Client address added successfully", result)
+}
+
+// ListAddresses lists the saved addresses of a client
+// @Summary List client addresses
+// @Tags Admin - Clients
+// @Produce json
+// @Param id path int true "Client ID"
+// @Success 200 {array} client.AddressResponse
+// @Router /api/v1/admin/clients/{id}/addresses [get]
+func (h *AdminClientHandler) ListAddresses(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid client ID", err.Error())
+		return
+	}
+
+	result, err := h.clientService.ListAddresses(c.Request.Context(), id)
+	if err != nil {
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to list client addresses", err.Error())
+		return
+	}
+
+	httputil.RespondSuccess(c, http.StatusOK, "Client addresses retrieved successfully", result)
+}
+
+// UpdateAddress updates a saved address of a client
+// @Summary Update client address
+// @Tags Admin - Clients
+// @Accept json
+// @Produce json
+// @Param id path int true "Client ID"
+// @Param address_id path int true "Address ID"
+// @Param request body client.UpdateAddressRequest true "Address update request"
+// @Success 200 {object} client.AddressResponse
+// @Router /api/v1/admin/clients/{id}/addresses/{address_id} [put]
+func (h *AdminClientHandler) UpdateAddress(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid client ID", err.Error())
+		return
+	}
+
+	addressID, err := strconv.ParseUint(c.Param("address_id"), 10, 64)
+	if err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid address ID", err.Error())
+		return
+	}
+
+	var req client.UpdateAddressRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	result, err := h.clientService.UpdateAddress(c.Request.Context(), id, addressID, req)
+	if err != nil {
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to update client address", err.Error())
+		return
+	}
+
+	httputil.RespondSuccess(c, http.StatusOK, "Client address updated successfully", result)
+}
+
+// DeleteAddress deletes a saved address of a client
+// @Summary Delete client address
+// @Tags Admin - Clients
+// @Param id path int true "Client ID"
+// @Param address_id path int true "Address ID"
+// @Success 200 {object} map[string]interface{}
+// @Router /api/v1/admin/clients/{id}/addresses/{address_id} [delete]
+func (h *AdminClientHandler) DeleteAddress(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid client ID", err.Error())
+		return
+	}
+
+	addressID, err := strconv.ParseUint(c.Param("address_id"), 10, 64)
+	if err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid address ID", err.Error())
+		return
+	}
+
+	if err := h.clientService.DeleteAddress(c.Request.Context(), id, addressID); err != nil {
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to delete client address", err.Error())
+		return
+	}
+
+	httputil.RespondSuccess(c, http.StatusOK, "Client address deleted successfully", nil)
+}
@@ -5,6 +5,7 @@ import (
 	"strconv"
 
 	"my-go-driver/internal/domain/company"
+	"my-go-driver/internal/middleware"
 	"my-go-driver/pkg/httputil"
 
 	"github.com/gin-gonic/gin"
@@ -37,7 +38,7 @@ func (h *AdminCompanyHandler) CreateCompany(c *gin.Context) {
 
 	result, err := h.companyService.CreateCompany(c.Request.Context(), req)
 	if err != nil {
-		httputil.RespondError(c, http.StatusInternalServerError, "Failed to create company", err.Error())
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to create company", err.Error())
 		return
 	}
 
@@ -60,7 +61,7 @@ func (h *AdminCompanyHandler) GetCompany(c *gin.Context) {
 
 	result, err := h.companyService.GetCompany(c.Request.Context(), id)
 	if err != nil {
-		httputil.RespondError(c, http.StatusNotFound, "Company not found", err.Error())
+		httputil.RespondError(c, errorStatus(err, http.StatusNotFound), "Company not found", err.Error())
 		return
 	}
 
@@ -91,7 +92,7 @@ func (h *AdminCompanyHandler) UpdateCompany(c *gin.Context) {
 
 	result, err := h.companyService.UpdateCompany(c.Request.Context(), id, req)
 	if err != nil {
-		httputil.RespondError(c, http.StatusInternalServerError, "Failed to update company", err.Error())
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to update company", errorDetails(err))
 		return
 	}
 
@@ -112,7 +113,7 @@ func (h *AdminCompanyHandler) DeleteCompany(c *gin.Context) {
 	}
 
 	if err := h.companyService.DeleteCompany(c.Request.Context(), id); err != nil {
-		httputil.RespondError(c, http.StatusInternalServerError, "Failed to delete company", err.Error())
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to delete company", err.Error())
 		return
 	}
 
@@ -138,7 +139,7 @@ func (h *AdminCompanyHandler) ListCompanies(c *gin.Context) {
 
 	result, err := h.companyService.ListCompanies(c.Request.Context(), query)
 	if err != nil {
-		httputil.RespondError(c, http.StatusInternalServerError, "Failed to list companies", err.Error())
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to list companies", err.Error())
 		return
 	}
 
@@ -169,7 +170,7 @@ func (h *AdminCompanyHandler) UpdateBranding(c *gin.Context) {
 
 	result, err := h.companyService.UpdateBranding(c.Request.Context(), id, req)
 	if err != nil {
-		httputil.RespondError(c, http.StatusInternalServerError, "Failed to update branding", err.Error())
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to update branding", err.Error())
 		return
 	}
 
@@ -190,7 +191,7 @@ func (h *AdminCompanyHandler) SuspendCompany(c *gin.Context) {
 	}
 
 	if err := h.companyService.SuspendCompany(c.Request.Context(), id); err != nil {
-		httputil.RespondError(c, http.StatusInternalServerError, "Failed to suspend company", err.Error())
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to suspend company", err.Error())
 		return
 	}
 
@@ -211,7 +212,7 @@ func (h *AdminCompanyHandler) ActivateCompany(c *gin.Context) {
 	}
 
 	if err := h.companyService.ActivateCompany(c.Request.Context(), id); err != nil {
-		httputil.RespondError(c, http.StatusInternalServerError, "Failed to activate company", err.Error())
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to activate company", err.Error())
 		return
 	}
 
@@ -235,13 +236,64 @@ func (h *AdminCompanyHandler) CreateAdmin(c *gin.Context) {
 
 	result, err := h.companyService.CreateAdmin(c.Request.Context(), req)
 	if err != nil {
-		httputil.RespondError(c, http.StatusInternalServerError, "Failed to create admin", err.Error())
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to create admin", errorDetails(err))
 		return
 	}
 
 	httputil.RespondSuccess(c, http.StatusCreated, "Admin created successfully", result)
 }
 
+// DeactivateAdmin deactivates a company admin and ends all of their sessions
+// @Summary Deactivate company admin
+// @Tags Admin - Auth
+// @Produce json
+// @Param id path int true "Admin ID"
+// @Success 200 {object} company.CompanyAdminResponse
+// @Router /api/v1/admin/admins/{id}/deactivate [put]
+func (h *AdminCompanyHandler) DeactivateAdmin(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid admin ID", err.Error())
+		return
+	}
+
+	if adminID, ok := middleware.GetAdminID(c); ok && id == adminID {
+		httputil.RespondError(c, http.StatusBadRequest, "Failed to deactivate admin", "you cannot deactivate your own account")
+		return
+	}
+
+	result, err := h.companyService.DeactivateAdmin(c.Request.Context(), id)
+	if err != nil {
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to deactivate admin", err.Error())
+		return
+	}
+
+	httputil.RespondSuccess(c, http.StatusOK, "Admin deactivated successfully", result)
+}
+
+// ActivateAdmin re-activates a company admin
+// @Summary Activate company admin
+// @Tags Admin - Auth
+// @Produce json
+// @Param id path int true "Admin ID"
+// @Success 200 {object} company.CompanyAdminResponse
+// @Router /api/v1/admin/admins/{id}/activate [put]
+func (h *AdminCompanyHandler) ActivateAdmin(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid admin ID", err.Error())
+		return
+	}
+
+	result, err := h.companyService.ActivateAdmin(c.Request.Context(), id)
+	if err != nil {
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to activate admin", errorDetails(err))
+		return
+	}
+
+	httputil.RespondSuccess(c, http.StatusOK, "Admin activated successfully", result)
+}
+
 // LoginAdmin handles company admin login
 // @Summary Company admin login
 // @Tags Admin - Auth
@@ -257,9 +309,46 @@ func (h *AdminCompanyHandler) LoginAdmin(c *gin.Context) {
 		return
 	}
 
+	req.IPAddress = c.ClientIP()
+	req.UserAgent = c.Request.UserAgent()
+
 	result, err := h.companyService.LoginAdmin(c.Request.Context(), req)
 	if err != nil {
-		httputil.RespondError(c, http.StatusUnauthorized, "Login failed", err.Error())
+		setRetryAfter(c, err)
+		httputil.RespondError(c, errorStatus(err, http.StatusUnauthorized), "Login failed", err.Error())
+		return
+	}
+
+	if result.MFARequired {
+		httputil.RespondSuccess(c, http.StatusOK, "Two-factor authentication required", result)
+		return
+	}
+
+	httputil.RespondSuccess(c, http.StatusOK, "Login successful", result)
+}
+
+// VerifyMFALogin completes a login with a two-factor code
+// @Summary Complete admin login with a two-factor code
+// @Tags Admin - Auth
+// @Accept json
+// @Produce json
+// @Param request body company.MFALoginRequest true "MFA token from login and a TOTP or recovery code"
+// @Success 200 {object} company.LoginResponse
+// @Router /api/v1/admin/auth/login/mfa [post]
+func (h *AdminCompanyHandler) VerifyMFALogin(c *gin.Context) {
+	var req company.MFALoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	req.IPAddress = c.ClientIP()
+	req.UserAgent = c.Request.UserAgent()
+
+	result, err := h.companyService.CompleteMFALogin(c.Request.Context(), req)
+	if err != nil {
+		setRetryAfter(c, err)
+		httputil.RespondError(c, errorStatus(err, http.StatusUnauthorized), "Login failed", err.Error())
 		return
 	}
 
@@ -273,15 +362,15 @@ func (h *AdminCompanyHandler) LoginAdmin(c *gin.Context) {
 // @Success 200 {object} company.CompanyAdminResponse
 // @Router /api/v1/admin/auth/me [get]
 func (h *AdminCompanyHandler) GetAdminProfile(c *gin.Context) {
-	adminID, exists := c.Get("user_id")
-	if !exists {
+	adminID, ok := middleware.GetAdminID(c)
+	if !ok {
 		httputil.RespondError(c, http.StatusUnauthorized, "Unauthorized", "User ID not found in context")
 		return
 	}
 
-	result, err := h.companyService.GetAdminProfile(c.Request.Context(), adminID.(uint64))
+	result, err := h.companyService.GetAdminProfile(c.Request.Context(), adminID)
 	if err != nil {
-		httputil.RespondError(c, http.StatusNotFound, "Admin not found", err.Error())
+		httputil.RespondError(c, errorStatus(err, http.StatusNotFound), "Admin not found", err.Error())
 		return
 	}
 
@@ -40,7 +40,7 @@ func (h *AdminDriverHandler) CreateDriver(c *gin.Context) {
 
 	result, err := h.driverService.CreateDriver(c.Request.Context(), req)
 	if err != nil {
-		httputil.RespondError(c, http.StatusInternalServerError, "Failed to create driver", err.Error())
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to create driver", errorDetails(err))
 		return
 	}
 
@@ -63,7 +63,7 @@ func (h *AdminDriverHandler) GetDriver(c *gin.Context) {
 
 	result, err := h.driverService.GetDriver(c.Request.Context(), id)
 	if err != nil {
-		httputil.RespondError(c, http.StatusNotFound, "Driver not found", err.Error())
+		httputil.RespondError(c, errorStatus(err, http.StatusNotFound), "Driver not found", err.Error())
 		return
 	}
 
@@ -94,7 +94,7 @@ func (h *AdminDriverHandler) UpdateDriver(c *gin.Context) {
 
 	result, err := h.driverService.UpdateDriver(c.Request.Context(), id, req)
 	if err != nil {
-		httputil.RespondError(c, http.StatusInternalServerError, "Failed to update driver", err.Error())
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to update driver", err.Error())
 		return
 	}
 
@@ -115,7 +115,7 @@ func (h *AdminDriverHandler) DeleteDriver(c *gin.Context) {
 	}
 
 	if err := h.driverService.DeleteDriver(c.Request.Context(), id); err != nil {
-		httputil.RespondError(c, http.StatusInternalServerError, "Failed to delete driver", err.Error())
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to delete driver", err.Error())
 		return
 	}
 
@@ -144,7 +144,7 @@ func (h *AdminDriverHandler) ListDrivers(c *gin.Context) {
 
 	result, err := h.driverService.ListDrivers(c.Request.Context(), query)
 	if err != nil {
-		httputil.RespondError(c, http.StatusInternalServerError, "Failed to list drivers", err.Error())
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to list drivers", err.Error())
 		return
 	}
 
@@ -160,6 +160,7 @@ func (h *AdminDriverHandler) ListDrivers(c *gin.Context) {
 // @Param request body driver.AssignDriverToCompanyRequest true "Assignment request"
 // @Success 200 {object} driver.DriverResponse
 // @Router /api/v1/admin/drivers/{id}/assign-company [put]
+// @Router /api/v1/platform/drivers/{id}/assign-company [put]
 func (h *AdminDriverHandler) AssignDriverToCompany(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
 	if err != nil {
@@ -175,7 +176,7 @@ func (h *AdminDriverHandler) AssignDriverToCompany(c *gin.Context) {
 
 	result, err := h.driverService.AssignToCompany(c.Request.Context(), id, req)
 	if err != nil {
-		httputil.RespondError(c, http.StatusInternalServerError, "Failed to assign driver", err.Error())
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to assign driver", errorDetails(err))
 		return
 	}
 
@@ -196,7 +197,7 @@ func (h *AdminDriverHandler) BlockDriver(c *gin.Context) {
 	}
 
 	if err := h.driverService.BlockDriver(c.Request.Context(), id); err != nil {
-		httputil.RespondError(c, http.StatusInternalServerError, "Failed to block driver", err.Error())
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to block driver", err.Error())
 		return
 	}
 
@@ -217,7 +218,7 @@ func (h *AdminDriverHandler) UnblockDriver(c *gin.Context) {
 	}
 
 	if err := h.driverService.UnblockDriver(c.Request.Context(), id); err != nil {
-		httputil.RespondError(c, http.StatusInternalServerError, "Failed to unblock driver", err.Error())
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to unblock driver", errorDetails(err))
 		return
 	}
 
@@ -240,7 +241,7 @@ func (h *AdminDriverHandler) GetDriverPerformance(c *gin.Context) {
 
 	result, err := h.driverService.GetDriverPerformance(c.Request.Context(), id)
 	if err != nil {
-		httputil.RespondError(c, http.StatusNotFound, "Failed to get performance", err.Error())
+		httputil.RespondError(c, errorStatus(err, http.StatusNotFound), "Failed to get performance", err.Error())
 		return
 	}
 
@@ -274,7 +275,7 @@ func (h *AdminDriverHandler) GetDriverShifts(c *gin.Context) {
 
 	result, err := h.shiftService.GetDriverShifts(c.Request.Context(), id, query)
 	if err != nil {
-		httputil.RespondError(c, http.StatusInternalServerError, "Failed to get shifts", err.Error())
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to get shifts", err.Error())
 		return
 	}
 
@@ -0,0 +1,230 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"my-go-driver/internal/domain/maintenance"
+	"my-go-driver/pkg/httputil"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AdminMaintenanceHandler struct {
+	maintenanceService maintenance.Service
+}
+
+func NewAdminMaintenanceHandler(maintenanceService maintenance.Service) *AdminMaintenanceHandler {
+	return &AdminMaintenanceHandler{
+		maintenanceService: maintenanceService,
+	}
+}
+
+// CreateRule creates a maintenance rule
+// @Summary Create maintenance rule
+// @Tags Admin - Vehicle Maintenance
+// @Accept json
+// @Produce json
+// @Param request body maintenance.CreateRuleRequest true "Maintenance rule request"
+// @Success 201 {object} maintenance.RuleResponse
+// @Router /api/v1/admin/vehicles/maintenance-rules [post]
+func (h *AdminMaintenanceHandler) CreateRule(c *gin.Context) {
+	var req maintenance.CreateRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	result, err := h.maintenanceService.CreateRule(c.Request.Context(), req)
+	if err != nil {
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to create maintenance rule", err.Error())
+		return
+	}
+
+	httputil.RespondSuccess(c, http.StatusCreated, "Maintenance rule created successfully", result)
+}
+
+// ListRules lists the maintenance rules of a company
+// @Summary List maintenance rules
+// @Tags Admin - Vehicle Maintenance
+// @Produce json
+// @Param company_id query int false "Company ID"
+// @Success 200 {array} maintenance.RuleResponse
+// @Router /api/v1/admin/vehicles/maintenance-rules [get]
+func (h *AdminMaintenanceHandler) ListRules(c *gin.Context) {
+	var query maintenance.ListRulesQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid query parameters", err.Error())
+		return
+	}
+
+	result, err := h.maintenanceService.ListRules(c.Request.Context(), query)
+	if err != nil {
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to list maintenance rules", err.Error())
+		return
+	}
+
+	httputil.RespondSuccess(c, http.StatusOK, "Maintenance rules retrieved successfully", result)
+}
+
+// UpdateRule updates a maintenance rule
+// @Summary Update maintenance rule
+// @Tags Admin - Vehicle Maintenance
+// @Accept json
+// @Produce json
+// @Param rule_id path int true "Rule ID"
+// @Param request body maintenance.UpdateRuleRequest true "Maintenance rule update request"
+// @Success 200 {object} maintenance.RuleResponse
+// @Router /api/v1/admin/vehicles/maintenance-rules/{rule_id} [put]
+func (h *AdminMaintenanceHandler) UpdateRule(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("rule_id"), 10, 64)
+	if err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid rule ID", err.Error())
+		return
+	}
+
+	var req maintenance.UpdateRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	result, err := h.maintenanceService.UpdateRule(c.Request.Context(), id, req)
+	if err != nil {
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to update maintenance rule", err.Error())
+		return
+	}
+
+	httputil.RespondSuccess(c, http.StatusOK, "Maintenance rule updated successfully", result)
+}
+
+// DeleteRule deletes a maintenance rule
+// @Summary Delete maintenance rule
+// @Tags Admin - Vehicle Maintenance
+// @Param rule_id path int true "Rule ID"
+// @Success 200 {object} map[string]interface{}
+// @Router /api/v1/admin/vehicles/maintenance-rules/{rule_id} [delete]
+func (h *AdminMaintenanceHandler) DeleteRule(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("rule_id"), 10, 64)
+	if err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid rule ID", err.Error())
+		return
+	}
+
+	if err := h.maintenanceService.DeleteRule(c.Request.Context(), id); err != nil {
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to delete maintenance rule", err.Error())
+		return
+	}
+
+	httputil.RespondSuccess(c, http.StatusOK, "Maintenance rule deleted successfully", nil)
+}
+
+// RunCheck runs the maintenance check for a company's fleet
+// @Summary Run maintenance check
+// @Tags Admin - Vehicle Maintenance
+// @Accept json
+// @Produce json
+// @Param request body maintenance.RunCheckRequest true "Maintenance check request"
+// @Success 200 {object} maintenance.CheckResult
+// @Router /api/v1/admin/vehicles/maintenance-check [post]
+func (h *AdminMaintenanceHandler) RunCheck(c *gin.Context) {
+	var req maintenance.RunCheckRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	result, err := h.maintenanceService.CheckCompany(c.Request.Context(), req.CompanyID)
+	if err != nil {
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to run maintenance check", err.Error())
+		return
+	}
+
+	httputil.RespondSuccess(c, http.StatusOK, "Maintenance check completed successfully", result)
+}
+
+// RecordService records a service of a vehicle
+// @Summary Record vehicle service
+// @Tags Admin - Vehicle Maintenance
+// @Accept json
+// @Produce json
+// @Param id path int true "Vehicle ID"
+// @Param request body maintenance.CreateRecordRequest true "Maintenance record request"
+// @Success 201 {object} maintenance.RecordResponse
+// @Router /api/v1/admin/vehicles/{id}/maintenance [post]
+func (h *AdminMaintenanceHandler) RecordService(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid vehicle ID", err.Error())
+		return
+	}
+
+	var req maintenance.CreateRecordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	result, err := h.maintenanceService.RecordService(c.Request.Context(), id, req)
+	if err != nil {
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to record service", err.Error())
+		return
+	}
+
+	httputil.RespondSuccess(c, http.StatusCreated, "Service recorded successfully", result)
+}
+
+// ListRecords lists a vehicle's maintenance records
+// @Summary List vehicle maintenance records
+// @Tags Admin - Vehicle Maintenance
+// @Produce json
+// @Param id path int true "Vehicle ID"
+// @Param page query int false "Page number"
+// @Param limit query int false "Items per page"
+// @Param service_type query string false "Service type"
+// @Success 200 {object} maintenance.PaginatedRecordsResponse
+// @Router /api/v1/admin/vehicles/{id}/maintenance [get]
+func (h *AdminMaintenanceHandler) ListRecords(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid vehicle ID", err.Error())
+		return
+	}
+
+	query := maintenance.ListRecordsQuery{VehicleID: id}
+	if err := c.ShouldBindQuery(&query); err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid query parameters", err.Error())
+		return
+	}
+
+	result, err := h.maintenanceService.ListRecords(c.Request.Context(), query)
+	if err != nil {
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to list maintenance records", err.Error())
+		return
+	}
+
+	httputil.RespondSuccess(c, http.StatusOK, "Maintenance records retrieved successfully", result)
+}
+
+// GetSchedule shows where a vehicle stands against the maintenance rules
+// @Summary Get vehicle maintenance schedule
+// @Tags Admin - Vehicle Maintenance
+// @Produce json
+// @Param id path int true "Vehicle ID"
+// @Success 200 {array} maintenance.ScheduleItem
+// @Router /api/v1/admin/vehicles/{id}/maintenance/schedule [get]
+func (h *AdminMaintenanceHandler) GetSchedule(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid vehicle ID", err.Error())
+		return
+	}
+
+	result, err := h.maintenanceService.GetSchedule(c.Request.Context(), id)
+	if err != nil {
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to get maintenance schedule", err.Error())
+		return
+	}
+
+	httputil.RespondSuccess(c, http.StatusOK, "Maintenance schedule retrieved successfully", result)
+}
@@ -0,0 +1,178 @@
+package handler
+
+import (
+	"net/http"
+
+	"my-go-driver/internal/domain/mfa"
+	"my-go-driver/internal/middleware"
+	"my-go-driver/pkg/httputil"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AdminMFAHandler struct {
+	mfaService mfa.Service
+}
+
+func NewAdminMFAHandler(mfaService mfa.Service) *AdminMFAHandler {
+	return &AdminMFAHandler{
+		mfaService: mfaService,
+	}
+}
+
+// GetStatus returns the authenticated admin's two-factor status
+// @Summary Get two-factor status
+// @Tags Admin - MFA
+// @Produce json
+// @Success 200 {object} mfa.StatusResponse
+// @Router /api/v1/admin/auth/mfa [get]
+func (h *AdminMFAHandler) GetStatus(c *gin.Context) {
+	adminID, ok := middleware.GetAdminID(c)
+	if !ok {
+		httputil.RespondError(c, http.StatusUnauthorized, "Unauthorized", "User ID not found in context")
+		return
+	}
+
+	result, err := h.mfaService.GetStatus(c.Request.Context(), adminID)
+	if err != nil {
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to retrieve two-factor status", err.Error())
+		return
+	}
+
+	httputil.RespondSuccess(c, http.StatusOK, "Two-factor status retrieved successfully", result)
+}
+
+// Enroll starts TOTP enrollment for the authenticated admin
+// @Summary Start two-factor enrollment
+// @Tags Admin - MFA
+// @Produce json
+// @Success 200 {object} mfa.EnrollResponse
+// @Router /api/v1/admin/auth/mfa/enroll [post]
+func (h *AdminMFAHandler) Enroll(c *gin.Context) {
+	adminID, ok := middleware.GetAdminID(c)
+	if !ok {
+		httputil.RespondError(c, http.StatusUnauthorized, "Unauthorized", "User ID not found in context")
+		return
+	}
+
+	result, err := h.mfaService.Enroll(c.Request.Context(), adminID)
+	if err != nil {
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to start enrollment", err.Error())
+		return
+	}
+
+	httputil.RespondSuccess(c, http.StatusOK, "Scan the provisioning URI with an authenticator app", result)
+}
+
+// EnrollWithChallenge starts TOTP enrollment during a login that requires it
+// @Summary Start two-factor enrollment during login
+// @Tags Admin - MFA
+// @Accept json
+// @Produce json
+// @Param request body mfa.ChallengeEnrollRequest true "MFA token from login"
+// @Success 200 {object} mfa.EnrollResponse
+// @Router /api/v1/admin/auth/login/mfa/enroll [post]
+func (h *AdminMFAHandler) EnrollWithChallenge(c *gin.Context) {
+	var req mfa.ChallengeEnrollRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	result, err := h.mfaService.EnrollWithChallenge(c.Request.Context(), req)
+	if err != nil {
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to start enrollment", err.Error())
+		return
+	}
+
+	httputil.RespondSuccess(c, http.StatusOK, "Scan the provisioning URI with an authenticator app", result)
+}
+
+// Confirm enables two-factor authentication with a first code
+// @Summary Confirm two-factor enrollment
+// @Tags Admin - MFA
+// @Accept json
+// @Produce json
+// @Param request body mfa.CodeRequest true "TOTP code"
+// @Success 200 {object} mfa.RecoveryCodesResponse
+// @Router /api/v1/admin/auth/mfa/confirm [post]
+func (h *AdminMFAHandler) Confirm(c *gin.Context) {
+	adminID, ok := middleware.GetAdminID(c)
+	if !ok {
+		httputil.RespondError(c, http.StatusUnauthorized, "Unauthorized", "User ID not found in context")
+		return
+	}
+
+	var req mfa.CodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	result, err := h.mfaService.Confirm(c.Request.Context(), adminID, req)
+	if err != nil {
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to enable two-factor authentication", err.Error())
+		return
+	}
+
+	httputil.RespondSuccess(c, http.StatusOK, "Two-factor authentication enabled", result)
+}
+
+// Disable turns off two-factor authentication
+// @Summary Disable two-factor authentication
+// @Tags Admin - MFA
+// @Accept json
+// @Produce json
+// @Param request body mfa.CodeRequest true "TOTP or recovery code"
+// @Success 200
+// @Router /api/v1/admin/auth/mfa/disable [post]
+func (h *AdminMFAHandler) Disable(c *gin.Context) {
+	adminID, ok := middleware.GetAdminID(c)
+	if !ok {
+		httputil.RespondError(c, http.StatusUnauthorized, "Unauthorized", "User ID not found in context")
+		return
+	}
+
+	var req mfa.CodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	if err := h.mfaService.Disable(c.Request.Context(), adminID, req); err != nil {
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to disable two-factor authentication", err.Error())
+		return
+	}
+
+	httputil.RespondSuccess(c, http.StatusOK, "Two-factor authentication disabled", nil)
+}
+
+// RegenerateRecoveryCodes replaces the admin's recovery codes
+// @Summary Regenerate recovery codes
+// @Tags Admin - MFA
+// @Accept json
+// @Produce json
+// @Param request body mfa.CodeRequest true "TOTP code"
+// @Success 200 {object} mfa.RecoveryCodesResponse
+// @Router /api/v1/admin/auth/mfa/recovery-codes [post]
+func (h *AdminMFAHandler) RegenerateRecoveryCodes(c *gin.Context) {
+	adminID, ok := middleware.GetAdminID(c)
+	if !ok {
+		httputil.RespondError(c, http.StatusUnauthorized, "Unauthorized", "User ID not found in context")
+		return
+	}
+
+	var req mfa.CodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	result, err := h.mfaService.RegenerateRecoveryCodes(c.Request.Context(), adminID, req)
+	if err != nil {
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to regenerate recovery codes", err.Error())
+		return
+	}
+
+	httputil.RespondSuccess(c, http.StatusOK, "Recovery codes regenerated", result)
+}
@@ -29,7 +29,7 @@ func NewAdminModuleHandler(moduleService module.Service) *AdminModuleHandler {
 func (h *AdminModuleHandler) ListAllModules(c *gin.Context) {
 	result, err := h.moduleService.ListAllModules(c.Request.Context())
 	if err != nil {
-		httputil.RespondError(c, http.StatusInternalServerError, "Failed to list modules", err.Error())
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to list modules", err.Error())
 		return
 	}
 
@@ -60,13 +60,49 @@ func (h *AdminModuleHandler) AssignModuleToCompany(c *gin.Context) {
 
 	result, err := h.moduleService.AssignModuleToCompany(c.Request.Context(), companyID, req)
 	if err != nil {
-		httputil.RespondError(c, http.StatusInternalServerError, "Failed to assign module", err.Error())
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to assign module", errorDetails(err))
 		return
 	}
 
 	httputil.RespondSuccess(c, http.StatusCreated, "Module assigned successfully", result)
 }
 
+// SetCompanyModules replaces a company's modules with the requested set
+// @Summary Set company modules
+// @Description Diffs the requested modules against the company's modules and applies the changes in one transaction. With dry_run, only the planned changes are returned.
+// @Tags Admin - Modules
+// @Accept json
+// @Produce json
+// @Param id path int true "Company ID"
+// @Param request body module.SetCompanyModulesRequest true "Desired modules"
+// @Success 200 {object} module.SetCompanyModulesResponse
+// @Router /api/v1/admin/companies/{id}/modules [put]
+func (h *AdminModuleHandler) SetCompanyModules(c *gin.Context) {
+	companyID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid company ID", err.Error())
+		return
+	}
+
+	var req module.SetCompanyModulesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	result, err := h.moduleService.SetCompanyModules(c.Request.Context(), companyID, req)
+	if err != nil {
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to set company modules", errorDetails(err))
+		return
+	}
+
+	message := "Company modules updated successfully"
+	if req.DryRun {
+		message = "Company module changes planned"
+	}
+	httputil.RespondSuccess(c, http.StatusOK, message, result)
+}
+
 // GetCompanyModules gets all modules assigned to a company
 // @Summary Get company modules
 // @Tags Admin - Modules
@@ -83,13 +119,52 @@ func (h *AdminModuleHandler) GetCompanyModules(c *gin.Context) {
 
 	result, err := h.moduleService.GetCompanyModules(c.Request.Context(), companyID)
 	if err != nil {
-		httputil.RespondError(c, http.StatusInternalServerError, "Failed to get company modules", err.Error())
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to get company modules", err.Error())
 		return
 	}
 
 	httputil.RespondSuccess(c, http.StatusOK, "Company modules retrieved successfully", result)
 }
 
+// UpdateModuleConfig changes the config of a module assigned to a company
+// @Summary Update company module config
+// @Description Applies a JSON merge patch to the module config and validates the result against the module's config schema
+// @Tags Admin - Modules
+// @Accept json
+// @Produce json
+// @Param id path int true "Company ID"
+// @Param module_id path int true "Module ID"
+// @Param request body module.UpdateModuleConfigRequest true "Config patch"
+// @Success 200 {object} module.CompanyModuleResponse
+// @Router /api/v1/admin/companies/{id}/modules/{module_id} [patch]
+func (h *AdminModuleHandler) UpdateModuleConfig(c *gin.Context) {
+	companyID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid company ID", err.Error())
+		return
+	}
+
+	moduleID, err := strconv.ParseUint(c.Param("module_id"), 10, 64)
+	if err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid module ID", err.Error())
+		return
+	}
+
+	var req module.UpdateModuleConfigRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	result, err := h.moduleService.UpdateModuleConfig(c.Request.Context(), companyID, moduleID, req)
+	if err != nil {
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to update module config", errorDetails(err))
+		return
+	}
+
+	httputil.RespondSuccess(c, http.StatusOK, "Module config updated successfully", result)
+}
+
 // RemoveModuleFromCompany removes a module from a company
 // @Summary Remove module from company
 // @Tags Admin - Modules
@@ -111,9 +186,120 @@ func (h *AdminModuleHandler) RemoveModuleFromCompany(c *gin.Context) {
 	}
 
 	if err := h.moduleService.RemoveModuleFromCompany(c.Request.Context(), companyID, moduleID); err != nil {
-		httputil.RespondError(c, http.StatusInternalServerError, "Failed to remove module", err.Error())
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to remove module", errorDetails(err))
 		return
 	}
 
 	httputil.RespondSuccess(c, http.StatusOK, "Module removed successfully", nil)
 }
+
+// GetStoreModules gets the modules that apply to a store
+// @Summary Get store modules
+// @Description Resolves the company's modules for a store, with the store's overrides layered on top
+// @Tags Admin - Modules
+// @Produce json
+// @Param id path int true "Company ID"
+// @Param store_id path int true "Store ID"
+// @Success 200 {array} module.EffectiveModuleResponse
+// @Router /api/v1/admin/companies/{id}/stores/{store_id}/modules [get]
+func (h *AdminModuleHandler) GetStoreModules(c *gin.Context) {
+	companyID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid company ID", err.Error())
+		return
+	}
+
+	storeID, err := strconv.ParseUint(c.Param("store_id"), 10, 64)
+	if err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid store ID", err.Error())
+		return
+	}
+
+	result, err := h.moduleService.GetStoreModules(c.Request.Context(), companyID, storeID)
+	if err != nil {
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to get store modules", err.Error())
+		return
+	}
+
+	httputil.RespondSuccess(c, http.StatusOK, "Store modules retrieved successfully", result)
+}
+
+// SetStoreModule overrides a company module for a store
+// @Summary Set store module override
+// @Tags Admin - Modules
+// @Accept json
+// @Produce json
+// @Param id path int true "Company ID"
+// @Param store_id path int true "Store ID"
+// @Param module_id path int true "Module ID"
+// @Param request body module.SetStoreModuleRequest true "Store override"
+// @Success 200 {object} module.EffectiveModuleResponse
+// @Router /api/v1/admin/companies/{id}/stores/{store_id}/modules/{module_id} [put]
+func (h *AdminModuleHandler) SetStoreModule(c *gin.Context) {
+	companyID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid company ID", err.Error())
+		return
+	}
+
+	storeID, err := strconv.ParseUint(c.Param("store_id"), 10, 64)
+	if err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid store ID", err.Error())
+		return
+	}
+
+	moduleID, err := strconv.ParseUint(c.Param("module_id"), 10, 64)
+	if err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid module ID", err.Error())
+		return
+	}
+
+	var req module.SetStoreModuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	result, err := h.moduleService.SetStoreModule(c.Request.Context(), companyID, storeID, moduleID, req)
+	if err != nil {
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to set store module", errorDetails(err))
+		return
+	}
+
+	httputil.RespondSuccess(c, http.StatusOK, "Store module updated successfully", result)
+}
+
+// RemoveStoreModule removes a store's override of a company module
+// @Summary Remove store module override
+// @Tags Admin - Modules
+// @Param id path int true "Company ID"
+// @Param store_id path int true "Store ID"
+// @Param module_id path int true "Module ID"
+// @Success 200 {object} map[string]interface{}
+// @Router /api/v1/admin/companies/{id}/stores/{store_id}/modules/{module_id} [delete]
+func (h *AdminModuleHandler) RemoveStoreModule(c *gin.Context) {
+	companyID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid company ID", err.Error())
+		return
+	}
+
+	storeID, err := strconv.ParseUint(c.Param("store_id"), 10, 64)
+	if err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid store ID", err.Error())
+		return
+	}
+
+	moduleID, err := strconv.ParseUint(c.Param("module_id"), 10, 64)
+	if err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid module ID", err.Error())
+		return
+	}
+
+	if err := h.moduleService.RemoveStoreModule(c.Request.Context(), companyID, storeID, moduleID); err != nil {
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to remove store module", err.Error())
+		return
+	}
+
+	httputil.RespondSuccess(c, http.StatusOK, "Store module override removed successfully", nil)
+}
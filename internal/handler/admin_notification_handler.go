@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"my-go-driver/internal/domain/notification"
+	"my-go-driver/internal/middleware"
+	"my-go-driver/pkg/httputil"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AdminNotificationHandler struct {
+	notificationService notification.Service
+}
+
+func NewAdminNotificationHandler(notificationService notification.Service) *AdminNotificationHandler {
+	return &AdminNotificationHandler{
+		notificationService: notificationService,
+	}
+}
+
+// ListNotifications lists the notifications of the authenticated admin
+// @Summary List notifications
+// @Tags Admin - Notifications
+// @Produce json
+// @Param page query int false "Page number"
+// @Param limit query int false "Items per page"
+// @Param company_id query int false "Company ID"
+// @Param type query string false "Notification type"
+// @Param is_read query bool false "Read status"
+// @Success 200 {object} notification.PaginatedNotificationsResponse
+// @Router /api/v1/admin/notifications [get]
+func (h *AdminNotificationHandler) ListNotifications(c *gin.Context) {
+	var query notification.ListNotificationsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid query parameters", err.Error())
+		return
+	}
+
+	// Read state is kept per admin, so API keys have no notifications
+	adminID, ok := middleware.GetAdminID(c)
+	if !ok {
+		httputil.RespondError(c, http.StatusForbidden, "Notifications are only available to admins", "")
+		return
+	}
+	query.UserID = adminID
+
+	result, err := h.notificationService.ListNotifications(c.Request.Context(), query)
+	if err != nil {
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to list notifications", err.Error())
+		return
+	}
+
+	httputil.RespondSuccess(c, http.StatusOK, "Notifications retrieved successfully", result)
+}
+
+// MarkRead marks a notification as read
+// @Summary Mark notification as read
+// @Tags Admin - Notifications
+// @Produce json
+// @Param id path int true "Notification ID"
+// @Success 200 {object} notification.NotificationResponse
+// @Router /api/v1/admin/notifications/{id}/read [put]
+func (h *AdminNotificationHandler) MarkRead(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid notification ID", err.Error())
+		return
+	}
+	adminID, ok := middleware.GetAdminID(c)
+	if !ok {
+		httputil.RespondError(c, http.StatusForbidden, "Notifications are only available to admins", "")
+		return
+	}
+
+	result, err := h.notificationService.MarkRead(c.Request.Context(), id, adminID)
+	if err != nil {
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to mark notification as read", err.Error())
+		return
+	}
+
+	httputil.RespondSuccess(c, http.StatusOK, "Notification marked as read", result)
+}
@@ -0,0 +1,173 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"my-go-driver/internal/domain/rbac"
+	"my-go-driver/pkg/httputil"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AdminRoleHandler struct {
+	rbacService rbac.Service
+}
+
+func NewAdminRoleHandler(rbacService rbac.Service) *AdminRoleHandler {
+	return &AdminRoleHandler{
+		rbacService: rbacService,
+	}
+}
+
+// ListPermissions lists all permissions that can be granted to roles
+// @Summary List permissions
+// @Tags Admin - Roles
+// @Produce json
+// @Success 200 {array} string
+// @Router /api/v1/admin/permissions [get]
+func (h *AdminRoleHandler) ListPermissions(c *gin.Context) {
+	httputil.RespondSuccess(c, http.StatusOK, "Permissions retrieved successfully", h.rbacService.ListPermissions(c.Request.Context()))
+}
+
+// ListRoles lists built-in and custom roles of the admin's company
+// @Summary List roles
+// @Tags Admin - Roles
+// @Produce json
+// @Success 200 {array} rbac.RoleResponse
+// @Router /api/v1/admin/roles [get]
+func (h *AdminRoleHandler) ListRoles(c *gin.Context) {
+	companyID, ok := companyIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.rbacService.ListRoles(c.Request.Context(), companyID)
+	if err != nil {
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to list roles", err.Error())
+		return
+	}
+
+	httputil.RespondSuccess(c, http.StatusOK, "Roles retrieved successfully", result)
+}
+
+// CreateRole creates a custom role
+// @Summary Create custom role
+// @Tags Admin - Roles
+// @Accept json
+// @Produce json
+// @Param request body rbac.CreateRoleRequest true "Role creation request"
+// @Success 201 {object} rbac.RoleResponse
+// @Router /api/v1/admin/roles [post]
+func (h *AdminRoleHandler) CreateRole(c *gin.Context) {
+	companyID, ok := companyIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req rbac.CreateRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	result, err := h.rbacService.CreateRole(c.Request.Context(), companyID, req)
+	if err != nil {
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to create role", err.Error())
+		return
+	}
+
+	httputil.RespondSuccess(c, http.StatusCreated, "Role created successfully", result)
+}
+
+// UpdateRole updates a custom role
+// @Summary Update custom role
+// @Tags Admin - Roles
+// @Accept json
+// @Produce json
+// @Param id path int true "Role ID"
+// @Param request body rbac.UpdateRoleRequest true "Role update request"
+// @Success 200 {object} rbac.RoleResponse
+// @Router /api/v1/admin/roles/{id} [put]
+func (h *AdminRoleHandler) UpdateRole(c *gin.Context) {
+	companyID, ok := companyIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid role ID", err.Error())
+		return
+	}
+
+	var req rbac.UpdateRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	result, err := h.rbacService.UpdateRole(c.Request.Context(), companyID, id, req)
+	if err != nil {
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to update role", err.Error())
+		return
+	}
+
+	httputil.RespondSuccess(c, http.StatusOK, "Role updated successfully", result)
+}
+
+// DeleteRole deletes a custom role
+// @Summary Delete custom role
+// @Tags Admin - Roles
+// @Param id path int true "Role ID"
+// @Success 200 {object} map[string]interface{}
+// @Router /api/v1/admin/roles/{id} [delete]
+func (h *AdminRoleHandler) DeleteRole(c *gin.Context) {
+	companyID, ok := companyIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid role ID", err.Error())
+		return
+	}
+
+	if err := h.rbacService.DeleteRole(c.Request.Context(), companyID, id); err != nil {
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to delete role", err.Error())
+		return
+	}
+
+	httputil.RespondSuccess(c, http.StatusOK, "Role deleted successfully", nil)
+}
+
+// AssignAdminRole assigns a custom role to a company admin
+// @Summary Assign custom role to admin
+// @Tags Admin - Roles
+// @Accept json
+// @Produce json
+// @Param id path int true "Admin ID"
+// @Param request body rbac.AssignRoleRequest true "Role assignment request"
+// @Success 200 {object} map[string]interface{}
+// @Router /api/v1/admin/admins/{id}/role [put]
+func (h *AdminRoleHandler) AssignAdminRole(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid admin ID", err.Error())
+		return
+	}
+
+	var req rbac.AssignRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	if err := h.rbacService.AssignAdminRole(c.Request.Context(), id, req); err != nil {
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to assign role", err.Error())
+		return
+	}
+
+	httputil.RespondSuccess(c, http.StatusOK, "Role assigned successfully", nil)
+}
@@ -0,0 +1,189 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"my-go-driver/internal/domain/store"
+	"my-go-driver/pkg/httputil"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AdminStoreHandler struct {
+	storeService store.Service
+}
+
+func NewAdminStoreHandler(storeService store.Service) *AdminStoreHandler {
+	return &AdminStoreHandler{
+		storeService: storeService,
+	}
+}
+
+// CreateStore creates a new store
+// @Summary Create store
+// @Tags Admin - Stores
+// @Accept json
+// @Produce json
+// @Param request body store.CreateStoreRequest true "Store creation request"
+// @Success 201 {object} store.StoreResponse
+// @Router /api/v1/admin/stores [post]
+func (h *AdminStoreHandler) CreateStore(c *gin.Context) {
+	var req store.CreateStoreRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	result, err := h.storeService.CreateStore(c.Request.Context(), req)
+	if err != nil {
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to create store", err.Error())
+		return
+	}
+
+	httputil.RespondSuccess(c, http.StatusCreated, "Store created successfully", result)
+}
+
+// GetStore retrieves a store by ID
+// @Summary Get store
+// @Tags Admin - Stores
+// @Produce json
+// @Param id path int true "Store ID"
+// @Success 200 {object} store.StoreResponse
+// @Router /api/v1/admin/stores/{id} [get]
+func (h *AdminStoreHandler) GetStore(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid store ID", err.Error())
+		return
+	}
+
+	result, err := h.storeService.GetStore(c.Request.Context(), id)
+	if err != nil {
+		httputil.RespondError(c, errorStatus(err, http.StatusNotFound), "Store not found", err.Error())
+		return
+	}
+
+	httputil.RespondSuccess(c, http.StatusOK, "Store retrieved successfully", result)
+}
+
+// UpdateStore updates a store
+// @Summary Update store
+// @Tags Admin - Stores
+// @Accept json
+// @Produce json
+// @Param id path int true "Store ID"
+// @Param request body store.UpdateStoreRequest true "Store update request"
+// @Success 200 {object} store.StoreResponse
+// @Router /api/v1/admin/stores/{id} [put]
+func (h *AdminStoreHandler) UpdateStore(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid store ID", err.Error())
+		return
+	}
+
+	var req store.UpdateStoreRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	result, err := h.storeService.UpdateStore(c.Request.Context(), id, req)
+	if err != nil {
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to update store", err.Error())
+		return
+	}
+
+	httputil.RespondSuccess(c, http.StatusOK, "Store updated successfully", result)
+}
+
+// DeleteStore deletes a store
+// @Summary Delete store
+// @Tags Admin - Stores
+// @Param id path int true "Store ID"
+// @Success 200 {object} map[string]interface{}
+// @Router /api/v1/admin/stores/{id} [delete]
+func (h *AdminStoreHandler) DeleteStore(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid store ID", err.Error())
+		return
+	}
+
+	if err := h.storeService.DeleteStore(c.Request.Context(), id); err != nil {
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to delete store", err.Error())
+		return
+	}
+
+	httputil.RespondSuccess(c, http.StatusOK, "Store deleted successfully", nil)
+}
+
+// ListStores lists stores with pagination
+// @Summary List stores
+// @Tags Admin - Stores
+// @Produce json
+// @Param page query int false "Page number"
+// @Param limit query int false "Items per page"
+// @Param company_id query int false "Company ID"
+// @Param status query string false "Store status"
+// @Param search query string false "Search term"
+// @Success 200 {object} store.PaginatedStoresResponse
+// @Router /api/v1/admin/stores [get]
+func (h *AdminStoreHandler) ListStores(c *gin.Context) {
+	var query store.ListStoresQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid query parameters", err.Error())
+		return
+	}
+
+	result, err := h.storeService.ListStores(c.Request.Context(), query)
+	if err != nil {
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to list stores", err.Error())
+		return
+	}
+
+	httputil.RespondSuccess(c, http.StatusOK, "Stores retrieved successfully", result)
+}
+
+// ActivateStore activates a store
+// @Summary Activate store
+// @Tags Admin - Stores
+// @Param id path int true "Store ID"
+// @Success 200 {object} map[string]interface{}
+// @Router /api/v1/admin/stores/{id}/activate [put]
+func (h *AdminStoreHandler) ActivateStore(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid store ID", err.Error())
+		return
+	}
+
+	if err := h.storeService.ActivateStore(c.Request.Context(), id); err != nil {
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to activate store", err.Error())
+		return
+	}
+
+	httputil.RespondSuccess(c, http.StatusOK, "Store activated successfully", nil)
+}
+
+// DeactivateStore deactivates a store
+// @Summary Deactivate store
+// @Tags Admin - Stores
+// @Param id path int true "Store ID"
+// @Success 200 {object} map[string]interface{}
+// @Router /api/v1/admin/stores/{id}/deactivate [put]
+func (h *AdminStoreHandler) DeactivateStore(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid store ID", err.Error())
+		return
+	}
+
+	if err := h.storeService.DeactivateStore(c.Request.Context(), id); err != nil {
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to deactivate store", err.Error())
+		return
+	}
+
+	httputil.RespondSuccess(c, http.StatusOK, "Store deactivated successfully", nil)
+}
@@ -0,0 +1,290 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"my-go-driver/internal/domain/vehicle"
+	"my-go-driver/pkg/httputil"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AdminVehicleHandler struct {
+	vehicleService vehicle.Service
+}
+
+func NewAdminVehicleHandler(vehicleService vehicle.Service) *AdminVehicleHandler {
+	return &AdminVehicleHandler{
+		vehicleService: vehicleService,
+	}
+}
+
+// CreateVehicle creates a new vehicle
+// @Summary Create vehicle
+// @Tags Admin - Vehicles
+// @Accept json
+// @Produce json
+// @Param request body vehicle.CreateVehicleRequest true "Vehicle creation request"
+// @Success 201 {object} vehicle.VehicleResponse
+// @Router /api/v1/admin/vehicles [post]
+func (h *AdminVehicleHandler) CreateVehicle(c *gin.Context) {
+	var req vehicle.CreateVehicleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	result, err := h.vehicleService.CreateVehicle(c.Request.Context(), req)
+	if err != nil {
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to create vehicle", err.Error())
+		return
+	}
+
+	httputil.RespondSuccess(c, http.StatusCreated, "Vehicle created successfully", result)
+}
+
+// GetVehicle retrieves a vehicle by ID
+// @Summary Get vehicle
+// @Tags Admin - Vehicles
+// @Produce json
+// @Param id path int true "Vehicle ID"
+// @Success 200 {object} vehicle.VehicleResponse
+// @Router /api/v1/admin/vehicles/{id} [get]
+func (h *AdminVehicleHandler) GetVehicle(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid vehicle ID", err.Error())
+		return
+	}
+
+	result, err := h.vehicleService.GetVehicle(c.Request.Context(), id)
+	if err != nil {
+		httputil.RespondError(c, errorStatus(err, http.StatusNotFound), "Vehicle not found", err.Error())
+		return
+	}
+
+	httputil.RespondSuccess(c, http.StatusOK, "Vehicle retrieved successfully", result)
+}
+
+// UpdateVehicle updates a vehicle
+// @Summary Update vehicle
+// @Tags Admin - Vehicles
+// @Accept json
+// @Produce json
+// @Param id path int true "Vehicle ID"
+// @Param request body vehicle.UpdateVehicleRequest true "Vehicle update request"
+// @Success 200 {object} vehicle.VehicleResponse
+// @Router /api/v1/admin/vehicles/{id} [put]
+func (h *AdminVehicleHandler) UpdateVehicle(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid vehicle ID", err.Error())
+		return
+	}
+
+	var req vehicle.UpdateVehicleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	result, err := h.vehicleService.UpdateVehicle(c.Request.Context(), id, req)
+	if err != nil {
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to update vehicle", err.Error())
+		return
+	}
+
+	httputil.RespondSuccess(c, http.StatusOK, "Vehicle updated successfully", result)
+}
+
+// DeleteVehicle deletes a vehicle
+// @Summary Delete vehicle
+// @Tags Admin - Vehicles
+// @Param id path int true "Vehicle ID"
+// @Success 200 {object} map[string]interface{}
+// @Router /api/v1/admin/vehicles/{id} [delete]
+func (h *AdminVehicleHandler) DeleteVehicle(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid vehicle ID", err.Error())
+		return
+	}
+
+	if err := h.vehicleService.DeleteVehicle(c.Request.Context(), id); err != nil {
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to delete vehicle", err.Error())
+		return
+	}
+
+	httputil.RespondSuccess(c, http.StatusOK, "Vehicle deleted successfully", nil)
+}
+
+// ListVehicles lists vehicles with pagination
+// @Summary List vehicles
+// @Tags Admin - Vehicles
+// @Produce json
+// @Param page query int false "Page number"
+// @Param limit query int false "Items per page"
+// @Param company_id query int false "Company ID"
+// @Param store_id query int false "Store ID"
+// @Param type query string false "Vehicle type"
+// @Param status query string false "Vehicle status"
+// @Param search query string false "Search term"
+// @Param maintenance_due query bool false "Due for maintenance"
+// @Success 200 {object} vehicle.PaginatedVehiclesResponse
+// @Router /api/v1/admin/vehicles [get]
+func (h *AdminVehicleHandler) ListVehicles(c *gin.Context) {
+	var query vehicle.ListVehiclesQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid query parameters", err.Error())
+		return
+	}
+
+	result, err := h.vehicleService.ListVehicles(c.Request.Context(), query)
+	if err != nil {
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to list vehicles", err.Error())
+		return
+	}
+
+	httputil.RespondSuccess(c, http.StatusOK, "Vehicles retrieved successfully", result)
+}
+
+// ChangeVehicleStatus moves a vehicle to another status
+// @Summary Change vehicle status
+// @Tags Admin - Vehicles
+// @Accept json
+// @Produce json
+// @Param id path int true "Vehicle ID"
+// @Param request body vehicle.ChangeStatusRequest true "Vehicle status request"
+// @Success 200 {object} vehicle.VehicleResponse
+// @Router /api/v1/admin/vehicles/{id}/status [put]
+func (h *AdminVehicleHandler) ChangeVehicleStatus(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid vehicle ID", err.Error())
+		return
+	}
+
+	var req vehicle.ChangeStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	result, err := h.vehicleService.ChangeStatus(c.Request.Context(), id, req)
+	if err != nil {
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to change vehicle status", err.Error())
+		return
+	}
+
+	httputil.RespondSuccess(c, http.StatusOK, "Vehicle status changed successfully", result)
+}
+
+// AssignDriver assigns a vehicle to a driver
+// @Summary Assign vehicle to driver
+// @Tags Admin - Vehicles
+// @Accept json
+// @Produce json
+// @Param id path int true "Vehicle ID"
+// @Param request body vehicle.AssignDriverRequest true "Assignment request"
+// @Success 201 {object} vehicle.AssignmentResponse
+// @Router /api/v1/admin/vehicles/{id}/assign [post]
+func (h *AdminVehicleHandler) AssignDriver(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid vehicle ID", err.Error())
+		return
+	}
+
+	var req vehicle.AssignDriverRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	result, err := h.vehicleService.AssignDriver(c.Request.Context(), id, req)
+	if err != nil {
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to assign vehicle", err.Error())
+		return
+	}
+
+	httputil.RespondSuccess(c, http.StatusCreated, "Vehicle assigned successfully", result)
+}
+
+// UnassignDriver ends a vehicle's assignment to its driver
+// @Summary Unassign vehicle from driver
+// @Tags Admin - Vehicles
+// @Produce json
+// @Param id path int true "Vehicle ID"
+// @Success 200 {object} vehicle.AssignmentResponse
+// @Router /api/v1/admin/vehicles/{id}/unassign [put]
+func (h *AdminVehicleHandler) UnassignDriver(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid vehicle ID", err.Error())
+		return
+	}
+
+	result, err := h.vehicleService.UnassignDriver(c.Request.Context(), id)
+	if err != nil {
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to unassign vehicle", err.Error())
+		return
+	}
+
+	httputil.RespondSuccess(c, http.StatusOK, "Vehicle unassigned successfully", result)
+}
+
+// GetVehicleAssignments lists a vehicle's assignment history
+// @Summary Get vehicle assignments
+// @Tags Admin - Vehicles
+// @Produce json
+// @Param id path int true "Vehicle ID"
+// @Param page query int false "Page number"
+// @Param limit query int false "Items per page"
+// @Param active query bool false "Only active or ended assignments"
+// @Success 200 {object} vehicle.PaginatedAssignmentsResponse
+// @Router /api/v1/admin/vehicles/{id}/assignments [get]
+func (h *AdminVehicleHandler) GetVehicleAssignments(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid vehicle ID", err.Error())
+		return
+	}
+
+	h.listAssignments(c, vehicle.ListAssignmentsQuery{VehicleID: id})
+}
+
+// GetDriverAssignments lists a driver's vehicle assignment history
+// @Summary Get driver vehicle assignments
+// @Tags Admin - Drivers
+// @Produce json
+// @Param id path int true "Driver ID"
+// @Param page query int false "Page number"
+// @Param limit query int false "Items per page"
+// @Param active query bool false "Only active or ended assignments"
+// @Success 200 {object} vehicle.PaginatedAssignmentsResponse
+// @Router /api/v1/admin/drivers/{id}/vehicle-assignments [get]
+func (h *AdminVehicleHandler) GetDriverAssignments(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid driver ID", err.Error())
+		return
+	}
+
+	h.listAssignments(c, vehicle.ListAssignmentsQuery{DriverID: id})
+}
+
+func (h *AdminVehicleHandler) listAssignments(c *gin.Context, query vehicle.ListAssignmentsQuery) {
+	if err := c.ShouldBindQuery(&query); err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid query parameters", err.Error())
+		return
+	}
+
+	result, err := h.vehicleService.ListAssignments(c.Request.Context(), query)
+	if err != nil {
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to list vehicle assignments", err.Error())
+		return
+	}
+
+	httputil.RespondSuccess(c, http.StatusOK, "Vehicle assignments retrieved successfully", result)
+}
@@ -0,0 +1,20 @@
+package handler
+
+import (
+	"net/http"
+
+	"my-go-driver/internal/middleware"
+	"my-go-driver/pkg/httputil"
+
+	"github.com/gin-gonic/gin"
+)
+
+// companyIDFromContext returns the authenticated admin's company, responding
+// with 401 when the request is not scoped to a company
+func companyIDFromContext(c *gin.Context) (uint64, bool) {
+	companyID, ok := middleware.GetCompanyID(c)
+	if !ok {
+		httputil.RespondError(c, http.StatusUnauthorized, "Unauthorized", "Company ID not found in context")
+	}
+	return companyID, ok
+}
@@ -0,0 +1,193 @@
+package handler
+
+import (
+	"net/http"
+
+	"my-go-driver/internal/domain/driver"
+	"my-go-driver/internal/domain/shift"
+	"my-go-driver/internal/middleware"
+	"my-go-driver/pkg/httputil"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DriverHandler serves the driver-facing API
+type DriverHandler struct {
+	driverService driver.Service
+	shiftService  shift.Service
+}
+
+func NewDriverHandler(driverService driver.Service, shiftService shift.Service) *DriverHandler {
+	return &DriverHandler{
+		driverService: driverService,
+		shiftService:  shiftService,
+	}
+}
+
+// Login handles driver login
+// @Summary Driver login
+// @Tags Driver - Auth
+// @Accept json
+// @Produce json
+// @Param request body driver.LoginRequest true "Login credentials"
+// @Success 200 {object} driver.LoginResponse
+// @Router /api/v1/driver/auth/login [post]
+func (h *DriverHandler) Login(c *gin.Context) {
+	var req driver.LoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	req.IPAddress = c.ClientIP()
+	req.UserAgent = c.Request.UserAgent()
+
+	result, err := h.driverService.LoginDriver(c.Request.Context(), req)
+	if err != nil {
+		setRetryAfter(c, err)
+		httputil.RespondError(c, errorStatus(err, http.StatusUnauthorized), "Login failed", err.Error())
+		return
+	}
+
+	httputil.RespondSuccess(c, http.StatusOK, "Login successful", result)
+}
+
+// GetProfile retrieves the authenticated driver's profile
+// @Summary Get driver profile
+// @Tags Driver - Profile
+// @Produce json
+// @Success 200 {object} driver.DriverResponse
+// @Router /api/v1/driver/me [get]
+func (h *DriverHandler) GetProfile(c *gin.Context) {
+	driverID, ok := middleware.GetDriverID(c)
+	if !ok {
+		httputil.RespondError(c, http.StatusUnauthorized, "Unauthorized", "Driver ID not found in context")
+		return
+	}
+
+	result, err := h.driverService.GetDriver(c.Request.Context(), driverID)
+	if err != nil {
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to get profile", err.Error())
+		return
+	}
+
+	httputil.RespondSuccess(c, http.StatusOK, "Profile retrieved successfully", result)
+}
+
+// UpdateProfile updates the authenticated driver's profile
+// @Summary Update driver profile
+// @Tags Driver - Profile
+// @Accept json
+// @Produce json
+// @Param request body driver.UpdateProfileRequest true "Profile update request"
+// @Success 200 {object} driver.DriverResponse
+// @Router /api/v1/driver/me [put]
+func (h *DriverHandler) UpdateProfile(c *gin.Context) {
+	driverID, ok := middleware.GetDriverID(c)
+	if !ok {
+		httputil.RespondError(c, http.StatusUnauthorized, "Unauthorized", "Driver ID not found in context")
+		return
+	}
+
+	var req driver.UpdateProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	result, err := h.driverService.UpdateProfile(c.Request.Context(), driverID, req)
+	if err != nil {
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to update profile", err.Error())
+		return
+	}
+
+	httputil.RespondSuccess(c, http.StatusOK, "Profile updated successfully", result)
+}
+
+// GoOnline marks the authenticated driver as online
+// @Summary Go online
+// @Tags Driver - Profile
+// @Produce json
+// @Success 200 {object} driver.DriverResponse
+// @Router /api/v1/driver/me/online [put]
+func (h *DriverHandler) GoOnline(c *gin.Context) {
+	h.setOnlineStatus(c, driver.OnlineStatusOnline)
+}
+
+// GoOffline marks the authenticated driver as offline
+// @Summary Go offline
+// @Tags Driver - Profile
+// @Produce json
+// @Success 200 {object} driver.DriverResponse
+// @Router /api/v1/driver/me/offline [put]
+func (h *DriverHandler) GoOffline(c *gin.Context) {
+	h.setOnlineStatus(c, driver.OnlineStatusOffline)
+}
+
+func (h *DriverHandler) setOnlineStatus(c *gin.Context, status driver.OnlineStatus) {
+	driverID, ok := middleware.GetDriverID(c)
+	if !ok {
+		httputil.RespondError(c, http.StatusUnauthorized, "Unauthorized", "Driver ID not found in context")
+		return
+	}
+
+	result, err := h.driverService.SetOnlineStatus(c.Request.Context(), driverID, status)
+	if err != nil {
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to update online status", err.Error())
+		return
+	}
+
+	httputil.RespondSuccess(c, http.StatusOK, "Online status updated successfully", result)
+}
+
+// StartShift starts a shift for the authenticated driver
+// @Summary Start shift
+// @Tags Driver - Shifts
+// @Produce json
+// @Success 201 {object} shift.ShiftResponse
+// @Router /api/v1/driver/shifts/start [post]
+func (h *DriverHandler) StartShift(c *gin.Context) {
+	driverID, ok := middleware.GetDriverID(c)
+	if !ok {
+		httputil.RespondError(c, http.StatusUnauthorized, "Unauthorized", "Driver ID not found in context")
+		return
+	}
+
+	result, err := h.shiftService.StartShift(c.Request.Context(), driverID)
+	if err != nil {
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to start shift", err.Error())
+		return
+	}
+
+	httputil.RespondSuccess(c, http.StatusCreated, "Shift started successfully", result)
+}
+
+// EndShift ends the authenticated driver's shift in progress
+// @Summary End shift
+// @Tags Driver - Shifts
+// @Accept json
+// @Produce json
+// @Param request body shift.EndShiftRequest true "End shift request"
+// @Success 200 {object} shift.ShiftResponse
+// @Router /api/v1/driver/shifts/end [post]
+func (h *DriverHandler) EndShift(c *gin.Context) {
+	driverID, ok := middleware.GetDriverID(c)
+	if !ok {
+		httputil.RespondError(c, http.StatusUnauthorized, "Unauthorized", "Driver ID not found in context")
+		return
+	}
+
+	var req shift.EndShiftRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	result, err := h.shiftService.EndShift(c.Request.Context(), driverID, req)
+	if err != nil {
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to end shift", err.Error())
+		return
+	}
+
+	httputil.RespondSuccess(c, http.StatusOK, "Shift ended successfully", result)
+}
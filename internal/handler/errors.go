@@ -0,0 +1,164 @@
+package handler
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"my-go-driver/internal/domain/account"
+	"my-go-driver/internal/domain/apikey"
+	"my-go-driver/internal/domain/client"
+	"my-go-driver/internal/domain/company"
+	"my-go-driver/internal/domain/driver"
+	"my-go-driver/internal/domain/maintenance"
+	"my-go-driver/internal/domain/mfa"
+	"my-go-driver/internal/domain/module"
+	"my-go-driver/internal/domain/notification"
+	"my-go-driver/internal/domain/plan"
+	"my-go-driver/internal/domain/platform"
+	"my-go-driver/internal/domain/quota"
+	"my-go-driver/internal/domain/rbac"
+	"my-go-driver/internal/domain/session"
+	"my-go-driver/internal/domain/shift"
+	"my-go-driver/internal/domain/store"
+	"my-go-driver/internal/domain/vehicle"
+	"my-go-driver/internal/tenant"
+	"my-go-driver/pkg/httputil"
+	"my-go-driver/pkg/throttle"
+
+	"github.com/gin-gonic/gin"
+)
+
+// errorStatus maps domain errors to an HTTP status code, falling back to the given status
+func errorStatus(err error, fallback int) int {
+	switch {
+	case errors.Is(err, company.ErrCompanyNotFound),
+		errors.Is(err, company.ErrAdminNotFound),
+		errors.Is(err, driver.ErrDriverNotFound),
+		errors.Is(err, module.ErrModuleNotFound),
+		errors.Is(err, module.ErrModuleNotAssigned),
+		errors.Is(err, store.ErrStoreNotFound),
+		errors.Is(err, module.ErrNoStoreOverride),
+		errors.Is(err, vehicle.ErrVehicleNotFound),
+		errors.Is(err, vehicle.ErrNotAssigned),
+		errors.Is(err, maintenance.ErrRuleNotFound),
+		errors.Is(err, notification.ErrNotificationNotFound),
+		errors.Is(err, client.ErrClientNotFound),
+		errors.Is(err, client.ErrAddressNotFound),
+		errors.Is(err, platform.ErrAdminNotFound),
+		errors.Is(err, rbac.ErrRoleNotFound),
+		errors.Is(err, apikey.ErrAPIKeyNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, tenant.ErrPlatformOnly),
+		errors.Is(err, driver.ErrDriverSuspended),
+		errors.Is(err, company.ErrCompanySuspended),
+		errors.Is(err, rbac.ErrPermissionDenied),
+		errors.Is(err, rbac.ErrCustomRolesDisabled),
+		errors.Is(err, mfa.ErrMFARequired),
+		errors.Is(err, apikey.ErrAPIAccessDisabled),
+		errors.Is(err, quota.ErrQuotaExceeded),
+		errors.Is(err, plan.ErrModuleNotInPlan),
+		errors.Is(err, module.ErrModuleDisabled):
+		return http.StatusForbidden
+	case errors.Is(err, session.ErrInvalidRefreshToken),
+		errors.Is(err, session.ErrRefreshTokenReused),
+		errors.Is(err, session.ErrSessionRevoked),
+		errors.Is(err, mfa.ErrInvalidChallenge):
+		return http.StatusUnauthorized
+	case errors.Is(err, rbac.ErrUnknownPermission),
+		errors.Is(err, account.ErrInvalidToken),
+		errors.Is(err, mfa.ErrInvalidCode),
+		errors.Is(err, mfa.ErrNotEnrolled),
+		errors.Is(err, apikey.ErrInvalidAllowedIP),
+		errors.Is(err, apikey.ErrExpiryInPast),
+		errors.Is(err, module.ErrInvalidConfig),
+		errors.Is(err, module.ErrDuplicateModule),
+		errors.Is(err, store.ErrInvalidOpeningHours),
+		errors.Is(err, store.ErrIncompleteCoordinates),
+		errors.Is(err, maintenance.ErrNoInterval),
+		errors.Is(err, maintenance.ErrServiceDateInFuture),
+		errors.Is(err, client.ErrInvalidPhone),
+		errors.Is(err, client.ErrIncompleteCoordinates),
+		errors.Is(err, client.ErrMergeIntoSelf):
+		return http.StatusBadRequest
+	case errors.Is(err, throttle.ErrLocked):
+		return http.StatusTooManyRequests
+	case errors.Is(err, account.ErrEmailAlreadyVerified),
+		errors.Is(err, mfa.ErrAlreadyEnabled),
+		errors.Is(err, quota.ErrLimitBelowUsage),
+		errors.Is(err, plan.ErrModulesNotInPlan),
+		errors.Is(err, module.ErrModuleNotEnabled),
+		errors.Is(err, module.ErrMissingDependencies),
+		errors.Is(err, module.ErrModuleConflict),
+		errors.Is(err, module.ErrDependencyCycle),
+		errors.Is(err, module.ErrModuleRequired),
+		errors.Is(err, module.ErrModuleAlreadyAssigned),
+		errors.Is(err, store.ErrStoreInactive),
+		errors.Is(err, store.ErrStoreHasOrders),
+		errors.Is(err, vehicle.ErrPlateTaken),
+		errors.Is(err, vehicle.ErrInvalidStatusTransition),
+		errors.Is(err, vehicle.ErrVehicleUnavailable),
+		errors.Is(err, vehicle.ErrVehicleAssigned),
+		errors.Is(err, vehicle.ErrDriverHasVehicle),
+		errors.Is(err, vehicle.ErrNoVehicleAvailable),
+		errors.Is(err, vehicle.ErrVehicleHasHistory),
+		errors.Is(err, shift.ErrShiftInProgress),
+		errors.Is(err, shift.ErrNoShiftStarted),
+		errors.Is(err, maintenance.ErrRuleExists),
+		errors.Is(err, client.ErrPhoneTaken),
+		errors.Is(err, client.ErrClientHasOrders):
+		return http.StatusConflict
+	default:
+		return fallback
+	}
+}
+
+// errorDetails returns the errors payload for err: structured details for
+// errors clients act on programmatically, the message otherwise
+func errorDetails(err error) interface{} {
+	var quotaErr *quota.Error
+	if errors.As(err, &quotaErr) {
+		return quotaErr
+	}
+	var planErr *plan.ModulesNotInPlanError
+	if errors.As(err, &planErr) {
+		return planErr
+	}
+	var disabledErr *module.DisabledError
+	if errors.As(err, &disabledErr) {
+		return disabledErr
+	}
+	var dependencyErr *module.DependencyError
+	if errors.As(err, &dependencyErr) {
+		return dependencyErr
+	}
+	var configErr *module.ConfigError
+	if errors.As(err, &configErr) {
+		return configValidationErrors(configErr)
+	}
+	return err.Error()
+}
+
+// configValidationErrors reports module config errors in the same shape as
+// request validation errors
+func configValidationErrors(err *module.ConfigError) []httputil.ValidationError {
+	details := make([]httputil.ValidationError, len(err.Errors))
+	for i, fieldErr := range err.Errors {
+		details[i] = httputil.ValidationError{
+			Field:   fieldErr.Field,
+			Message: fieldErr.Field + " " + fieldErr.Message,
+		}
+	}
+	return details
+}
+
+// setRetryAfter sets the Retry-After header when err is a lockout
+func setRetryAfter(c *gin.Context, err error) {
+	var locked *throttle.LockedError
+	if errors.As(err, &locked) {
+		seconds := math.Ceil(locked.RetryAfter(time.Now()).Seconds())
+		c.Header("Retry-After", strconv.Itoa(int(seconds)))
+	}
+}
@@ -0,0 +1,33 @@
+package handler
+
+import (
+	"net/http"
+
+	"my-go-driver/pkg/jwt"
+
+	"github.com/gin-gonic/gin"
+)
+
+// JWKSHandler publishes the public token verification keys
+type JWKSHandler struct {
+	tokenService *jwt.Service
+}
+
+func NewJWKSHandler(tokenService *jwt.Service) *JWKSHandler {
+	return &JWKSHandler{
+		tokenService: tokenService,
+	}
+}
+
+// GetJWKS returns the JSON Web Key Set used to verify access tokens offline.
+// The document is served as-is, without the API response envelope, as
+// required by JWKS consumers.
+// @Summary JSON Web Key Set
+// @Tags Auth
+// @Produce json
+// @Success 200 {object} jwt.JWKSet
+// @Router /.well-known/jwks.json [get]
+func (h *JWKSHandler) GetJWKS(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, h.tokenService.JWKS())
+}
@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"net/http"
+
+	"my-go-driver/internal/domain/plan"
+	"my-go-driver/pkg/httputil"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PlanHandler struct {
+	planService plan.Service
+}
+
+func NewPlanHandler(planService plan.Service) *PlanHandler {
+	return &PlanHandler{
+		planService: planService,
+	}
+}
+
+// ListPlans lists the billing plans with their modules and default quotas
+// @Summary List plans
+// @Tags Platform - Plans
+// @Produce json
+// @Success 200 {array} plan.Plan
+// @Router /api/v1/platform/plans [get]
+func (h *PlanHandler) ListPlans(c *gin.Context) {
+	result, err := h.planService.ListPlans(c.Request.Context())
+	if err != nil {
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to list plans", err.Error())
+		return
+	}
+
+	httputil.RespondSuccess(c, http.StatusOK, "Plans retrieved successfully", result)
+}
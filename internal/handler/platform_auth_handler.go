@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"net/http"
+
+	"my-go-driver/internal/domain/platform"
+	"my-go-driver/internal/middleware"
+	"my-go-driver/pkg/httputil"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PlatformAuthHandler struct {
+	platformService platform.Service
+}
+
+func NewPlatformAuthHandler(platformService platform.Service) *PlatformAuthHandler {
+	return &PlatformAuthHandler{
+		platformService: platformService,
+	}
+}
+
+// Login handles platform operator login
+// @Summary Platform operator login
+// @Tags Platform - Auth
+// @Accept json
+// @Produce json
+// @Param request body platform.LoginRequest true "Login credentials"
+// @Success 200 {object} platform.LoginResponse
+// @Router /api/v1/platform/auth/login [post]
+func (h *PlatformAuthHandler) Login(c *gin.Context) {
+	var req platform.LoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	req.IPAddress = c.ClientIP()
+	req.UserAgent = c.Request.UserAgent()
+
+	result, err := h.platformService.Login(c.Request.Context(), req)
+	if err != nil {
+		setRetryAfter(c, err)
+		httputil.RespondError(c, errorStatus(err, http.StatusUnauthorized), "Login failed", err.Error())
+		return
+	}
+
+	httputil.RespondSuccess(c, http.StatusOK, "Login successful", result)
+}
+
+// GetProfile retrieves the platform operator profile
+// @Summary Get platform operator profile
+// @Tags Platform - Auth
+// @Produce json
+// @Success 200 {object} platform.AdminResponse
+// @Router /api/v1/platform/auth/me [get]
+func (h *PlatformAuthHandler) GetProfile(c *gin.Context) {
+	adminID, ok := middleware.GetPlatformAdminID(c)
+	if !ok {
+		httputil.RespondError(c, http.StatusUnauthorized, "Unauthorized", "User ID not found in context")
+		return
+	}
+
+	result, err := h.platformService.GetProfile(c.Request.Context(), adminID)
+	if err != nil {
+		httputil.RespondError(c, errorStatus(err, http.StatusNotFound), "Admin not found", err.Error())
+		return
+	}
+
+	httputil.RespondSuccess(c, http.StatusOK, "Profile retrieved successfully", result)
+}
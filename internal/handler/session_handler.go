@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"net/http"
+
+	"my-go-driver/internal/domain/session"
+	"my-go-driver/internal/middleware"
+	"my-go-driver/pkg/httputil"
+	"my-go-driver/pkg/jwt"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SessionHandler serves token refresh and logout for every audience
+type SessionHandler struct {
+	sessionService session.Service
+}
+
+func NewSessionHandler(sessionService session.Service) *SessionHandler {
+	return &SessionHandler{
+		sessionService: sessionService,
+	}
+}
+
+// RefreshAdmin exchanges a company admin refresh token for a new token pair
+// @Summary Refresh admin token
+// @Tags Admin - Auth
+// @Accept json
+// @Produce json
+// @Param request body session.RefreshRequest true "Refresh token"
+// @Success 200 {object} session.TokenPair
+// @Router /api/v1/admin/auth/refresh [post]
+func (h *SessionHandler) RefreshAdmin(c *gin.Context) {
+	h.refresh(c, jwt.SubjectAdmin)
+}
+
+// RefreshPlatform exchanges a platform operator refresh token for a new token pair
+// @Summary Refresh platform operator token
+// @Tags Platform - Auth
+// @Accept json
+// @Produce json
+// @Param request body session.RefreshRequest true "Refresh token"
+// @Success 200 {object} session.TokenPair
+// @Router /api/v1/platform/auth/refresh [post]
+func (h *SessionHandler) RefreshPlatform(c *gin.Context) {
+	h.refresh(c, jwt.SubjectPlatform)
+}
+
+// RefreshDriver exchanges a driver refresh token for a new token pair
+// @Summary Refresh driver token
+// @Tags Driver - Auth
+// @Accept json
+// @Produce json
+// @Param request body session.RefreshRequest true "Refresh token"
+// @Success 200 {object} session.TokenPair
+// @Router /api/v1/driver/auth/refresh [post]
+func (h *SessionHandler) RefreshDriver(c *gin.Context) {
+	h.refresh(c, jwt.SubjectDriver)
+}
+
+// Logout revokes the session of the current access token
+// @Summary Logout
+// @Tags Auth
+// @Produce json
+// @Success 200
+// @Router /api/v1/{admin|platform|driver}/auth/logout [post]
+func (h *SessionHandler) Logout(c *gin.Context) {
+	sessionID, ok := middleware.GetSessionID(c)
+	if !ok {
+		httputil.RespondError(c, http.StatusUnauthorized, "Unauthorized", "Session not found in context")
+		return
+	}
+
+	if err := h.sessionService.Revoke(c.Request.Context(), sessionID); err != nil {
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to logout", err.Error())
+		return
+	}
+
+	httputil.RespondSuccess(c, http.StatusOK, "Logged out successfully", nil)
+}
+
+func (h *SessionHandler) refresh(c *gin.Context, subjectType jwt.SubjectType) {
+	var req session.RefreshRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid request payload", err.Error())
+		return
+	}
+
+	result, err := h.sessionService.Refresh(c.Request.Context(), subjectType, req.RefreshToken)
+	if err != nil {
+		httputil.RespondError(c, errorStatus(err, http.StatusInternalServerError), "Failed to refresh token", err.Error())
+		return
+	}
+
+	httputil.RespondSuccess(c, http.StatusOK, "Token refreshed successfully", result)
+}
@@ -2,50 +2,52 @@ package middleware
 
 import (
 	"net/http"
-	"strings"
 
+	"my-go-driver/internal/domain/company"
+	"my-go-driver/internal/domain/session"
+	"my-go-driver/internal/tenant"
 	"my-go-driver/pkg/httputil"
 	"my-go-driver/pkg/jwt"
 
 	"github.com/gin-gonic/gin"
 )
 
-// AdminAuth returns a gin middleware for admin JWT authentication
-func AdminAuth(jwtSecret string) gin.HandlerFunc {
+// AdminAuth returns a gin middleware for admin JWT authentication.
+// It resolves the admin's company and scopes the request context to it,
+// so every service call made on behalf of the admin is tenant-restricted.
+// Requests already authenticated by APIKeyAuth are passed through.
+func AdminAuth(tokens *jwt.Service, companyService company.Service, sessionService session.Service) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// Get authorization header
-		authHeader := c.GetHeader(AuthorizationHeader)
-		if authHeader == "" {
-			httputil.RespondError(c, http.StatusUnauthorized, "Authorization header required", "")
-			c.Abort()
+		if _, ok := GetAPIKeyID(c); ok {
+			c.Next()
 			return
 		}
 
-		// Check if it starts with Bearer
-		if !strings.HasPrefix(authHeader, BearerPrefix) {
-			httputil.RespondError(c, http.StatusUnauthorized, "Invalid authorization format", "")
-			c.Abort()
+		claims, ok := authenticateSession(c, tokens, jwt.SubjectAdmin, sessionService)
+		if !ok {
 			return
 		}
 
-		// Extract token
-		token := strings.TrimPrefix(authHeader, BearerPrefix)
-		if token == "" {
-			httputil.RespondError(c, http.StatusUnauthorized, "Token is required", "")
+		// Resolve the admin's tenant. The request is not scoped yet, so the
+		// lookup acts for the platform.
+		admin, err := companyService.GetAdminProfile(tenant.WithPlatform(c.Request.Context()), claims.SubjectID())
+		if err != nil {
+			httputil.RespondError(c, http.StatusUnauthorized, "Invalid or expired token", err.Error())
 			c.Abort()
 			return
 		}
-
-		// Validate token
-		userID, err := jwt.ValidateToken(token, jwtSecret)
-		if err != nil {
-			httputil.RespondError(c, http.StatusUnauthorized, "Invalid or expired token", err.Error())
+		if !admin.IsActive {
+			httputil.RespondError(c, http.StatusUnauthorized, "Account is inactive", "")
+			c.Abort()
+			return
+		}
+		if err := companyService.CheckCompanyActive(c.Request.Context(), admin.CompanyID); err != nil {
+			httputil.RespondError(c, http.StatusForbidden, "Company is suspended", err.Error())
 			c.Abort()
 			return
 		}
 
-		// Set user info in context
-		c.Set("user_id", userID)
+		scopeToCompany(c, admin.CompanyID)
 
 		c.Next()
 	}
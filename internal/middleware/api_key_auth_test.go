@@ -0,0 +1,87 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"my-go-driver/internal/domain/apikey"
+
+	"github.com/gin-gonic/gin"
+)
+
+// allowlistAPIKeyService accepts any key presented from allowedIP
+type allowlistAPIKeyService struct {
+	apikey.Service
+	allowedIP string
+}
+
+func (s *allowlistAPIKeyService) Authenticate(ctx context.Context, rawKey, clientIP string) (*apikey.APIKey, error) {
+	if clientIP != s.allowedIP {
+		return nil, apikey.ErrIPNotAllowed
+	}
+	return &apikey.APIKey{ID: 1, CompanyID: 1}, nil
+}
+
+func TestAPIKeyAuthClientIP(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		trustedProxies []string
+		remoteAddr     string
+		forwardedFor   string
+		wantStatus     int
+	}{
+		{
+			name:       "allowed peer address",
+			remoteAddr: "10.0.0.1:4000",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:         "spoofed forwarded header without trusted proxies",
+			remoteAddr:   "203.0.113.9:4000",
+			forwardedFor: "10.0.0.1",
+			wantStatus:   http.StatusForbidden,
+		},
+		{
+			name:           "forwarded header from a trusted proxy",
+			trustedProxies: []string{"192.168.0.0/16"},
+			remoteAddr:     "192.168.1.1:4000",
+			forwardedFor:   "10.0.0.1",
+			wantStatus:     http.StatusOK,
+		},
+		{
+			name:           "spoofed forwarded header from an untrusted peer",
+			trustedProxies: []string{"192.168.0.0/16"},
+			remoteAddr:     "203.0.113.9:4000",
+			forwardedFor:   "10.0.0.1",
+			wantStatus:     http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.New()
+			if err := r.SetTrustedProxies(tt.trustedProxies); err != nil {
+				t.Fatalf("SetTrustedProxies: %v", err)
+			}
+			r.Use(APIKeyAuth(&allowlistAPIKeyService{allowedIP: "10.0.0.1"}))
+			r.GET("/", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.RemoteAddr = tt.remoteAddr
+			req.Header.Set(APIKeyHeader, "key")
+			if tt.forwardedFor != "" {
+				req.Header.Set("X-Forwarded-For", tt.forwardedFor)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+		})
+	}
+}
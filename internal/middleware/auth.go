@@ -4,8 +4,10 @@ import (
 	"net/http"
 	"strings"
 
+	"my-go-driver/internal/domain/session"
+	"my-go-driver/internal/tenant"
 	"my-go-driver/pkg/httputil"
-	jwtpkg "my-go-driver/pkg/jwt"
+	"my-go-driver/pkg/jwt"
 
 	"github.com/gin-gonic/gin"
 )
@@ -13,72 +15,141 @@ import (
 const (
 	AuthorizationHeader = "Authorization"
 	BearerPrefix        = "Bearer "
-	UserIDKey           = "user_id"
-	EmailKey            = "email"
 )
 
-// Auth returns a gin middleware for JWT authentication
-func Auth(jwtManager *jwtpkg.Manager) gin.HandlerFunc {
-	return func(c *gin.Context) {
-		// Get authorization header
-		authHeader := c.GetHeader(AuthorizationHeader)
-		if authHeader == "" {
-			httputil.RespondError(c, http.StatusUnauthorized, "Authorization header required", nil)
-			c.Abort()
-			return
-		}
-
-		// Check if it starts with Bearer
-		if !strings.HasPrefix(authHeader, BearerPrefix) {
-			httputil.RespondError(c, http.StatusUnauthorized, "Invalid authorization format", nil)
-			c.Abort()
-			return
-		}
-
-		// Extract token
-		token := strings.TrimPrefix(authHeader, BearerPrefix)
-		if token == "" {
-			httputil.RespondError(c, http.StatusUnauthorized, "Token is required", nil)
-			c.Abort()
-			return
-		}
-
-		// Validate token
-		claims, err := jwtManager.Validate(token)
-		if err != nil {
-			if err == jwtpkg.ErrExpiredToken {
-				httputil.RespondError(c, http.StatusUnauthorized, "Token has expired", nil)
-			} else {
-				httputil.RespondError(c, http.StatusUnauthorized, "Invalid token", nil)
-			}
-			c.Abort()
-			return
-		}
-
-		// Set user info in context
-		c.Set(UserIDKey, claims.UserID)
-		c.Set(EmailKey, claims.Email)
-
-		c.Next()
+// Gin context keys. They are unexported so values are only read through the
+// typed accessors below.
+const (
+	claimsKey    = "auth_claims"
+	companyIDKey = "auth_company_id"
+	storeIDKey   = "auth_store_id"
+)
+
+// bearerToken extracts the bearer token from the Authorization header.
+// It responds with 401 and aborts the request when the header is missing or malformed.
+func bearerToken(c *gin.Context) (string, bool) {
+	authHeader := c.GetHeader(AuthorizationHeader)
+	if authHeader == "" {
+		httputil.RespondError(c, http.StatusUnauthorized, "Authorization header required", "")
+		c.Abort()
+		return "", false
+	}
+
+	if !strings.HasPrefix(authHeader, BearerPrefix) {
+		httputil.RespondError(c, http.StatusUnauthorized, "Invalid authorization format", "")
+		c.Abort()
+		return "", false
+	}
+
+	token := strings.TrimPrefix(authHeader, BearerPrefix)
+	if token == "" {
+		httputil.RespondError(c, http.StatusUnauthorized, "Token is required", "")
+		c.Abort()
+		return "", false
+	}
+
+	return token, true
+}
+
+// authenticateSession validates a bearer access token issued to the given
+// subject type and checks that the session it belongs to has not been revoked.
+// It responds with 401 and aborts the request on failure.
+func authenticateSession(c *gin.Context, tokens *jwt.Service, subjectType jwt.SubjectType, sessionService session.Service) (*jwt.Claims, bool) {
+	token, ok := bearerToken(c)
+	if !ok {
+		return nil, false
+	}
+
+	// Validate token
+	claims, err := tokens.Validate(token, subjectType)
+	if err != nil {
+		httputil.RespondError(c, http.StatusUnauthorized, "Invalid or expired token", err.Error())
+		c.Abort()
+		return nil, false
+	}
+
+	// Reject tokens whose session was logged out or revoked
+	if err := sessionService.ValidateSession(c.Request.Context(), claims.SessionID); err != nil {
+		httputil.RespondError(c, http.StatusUnauthorized, "Invalid or expired token", err.Error())
+		c.Abort()
+		return nil, false
+	}
+
+	c.Set(claimsKey, claims)
+	return claims, true
+}
+
+// scopeToCompany records the caller's company and restricts every service
+// call made for the request to that tenant
+func scopeToCompany(c *gin.Context, companyID uint64) {
+	c.Set(companyIDKey, companyID)
+	c.Request = c.Request.WithContext(tenant.WithCompany(c.Request.Context(), companyID))
+}
+
+// scopeToStore records the store the caller belongs to, so feature gates
+// apply the store's module overrides
+func scopeToStore(c *gin.Context, storeID uint64) {
+	c.Set(storeIDKey, storeID)
+}
+
+// GetClaims retrieves the validated token claims from the context
+func GetClaims(c *gin.Context) (*jwt.Claims, bool) {
+	value, exists := c.Get(claimsKey)
+	if !exists {
+		return nil, false
+	}
+	claims, ok := value.(*jwt.Claims)
+	return claims, ok
+}
+
+// GetAdminID retrieves the authenticated company admin's ID from the context
+func GetAdminID(c *gin.Context) (uint64, bool) {
+	return subjectID(c, jwt.SubjectAdmin)
+}
+
+// GetPlatformAdminID retrieves the authenticated platform operator's ID from the context
+func GetPlatformAdminID(c *gin.Context) (uint64, bool) {
+	return subjectID(c, jwt.SubjectPlatform)
+}
+
+// GetDriverID retrieves the authenticated driver's ID from the context
+func GetDriverID(c *gin.Context) (uint64, bool) {
+	return subjectID(c, jwt.SubjectDriver)
+}
+
+// GetSessionID retrieves the session ID of the current access token from the context
+func GetSessionID(c *gin.Context) (uint64, bool) {
+	claims, ok := GetClaims(c)
+	if !ok || claims.SessionID == 0 {
+		return 0, false
 	}
+	return claims.SessionID, true
 }
 
-// GetUserID retrieves the user ID from the context
-func GetUserID(c *gin.Context) (uint, bool) {
-	userID, exists := c.Get(UserIDKey)
+// GetCompanyID retrieves the company the request is scoped to from the context
+func GetCompanyID(c *gin.Context) (uint64, bool) {
+	value, exists := c.Get(companyIDKey)
 	if !exists {
 		return 0, false
 	}
-	id, ok := userID.(uint)
+	id, ok := value.(uint64)
 	return id, ok
 }
 
-// GetEmail retrieves the email from the context
-func GetEmail(c *gin.Context) (string, bool) {
-	email, exists := c.Get(EmailKey)
+// GetStoreID retrieves the store the caller belongs to from the context
+func GetStoreID(c *gin.Context) (uint64, bool) {
+	value, exists := c.Get(storeIDKey)
 	if !exists {
-		return "", false
+		return 0, false
+	}
+	id, ok := value.(uint64)
+	return id, ok
+}
+
+func subjectID(c *gin.Context, subjectType jwt.SubjectType) (uint64, bool) {
+	claims, ok := GetClaims(c)
+	if !ok || claims.SubjectType != subjectType {
+		return 0, false
 	}
-	e, ok := email.(string)
-	return e, ok
+	return claims.SubjectID(), true
 }
@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"net/http"
+
+	"my-go-driver/internal/domain/company"
+	"my-go-driver/internal/domain/driver"
+	"my-go-driver/internal/domain/session"
+	"my-go-driver/internal/tenant"
+	"my-go-driver/pkg/httputil"
+	"my-go-driver/pkg/jwt"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DriverAuth returns a gin middleware for driver JWT authentication.
+// The driver is reloaded on every request so suspended drivers, and drivers
+// of suspended companies, are rejected immediately, and the request context
+// is scoped to the driver's company and store.
+func DriverAuth(tokens *jwt.Service, driverService driver.Service, companyService company.Service, sessionService session.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, ok := authenticateSession(c, tokens, jwt.SubjectDriver, sessionService)
+		if !ok {
+			return
+		}
+
+		// The request is not scoped yet, so the lookup acts for the platform
+		d, err := driverService.GetDriver(tenant.WithPlatform(c.Request.Context()), claims.SubjectID())
+		if err != nil {
+			httputil.RespondError(c, http.StatusUnauthorized, "Invalid or expired token", err.Error())
+			c.Abort()
+			return
+		}
+		if d.Status == driver.DriverStatusSuspended {
+			httputil.RespondError(c, http.StatusForbidden, "Driver account is suspended", "")
+			c.Abort()
+			return
+		}
+		if err := companyService.CheckCompanyActive(c.Request.Context(), d.CompanyID); err != nil {
+			httputil.RespondError(c, http.StatusForbidden, "Company is suspended", err.Error())
+			c.Abort()
+			return
+		}
+
+		scopeToCompany(c, d.CompanyID)
+		if d.StoreID != nil {
+			scopeToStore(c, *d.StoreID)
+		}
+
+		c.Next()
+	}
+}
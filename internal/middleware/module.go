@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+
+	"my-go-driver/internal/domain/module"
+	"my-go-driver/pkg/httputil"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequireModule returns a gin middleware that rejects requests with 403
+// module_disabled unless the caller's company has the module enabled and,
+// for callers that belong to a store such as drivers, the store has not
+// turned it off. It must run after the authentication middleware; requests
+// not scoped to a company, such as those of platform operators, are passed
+// through.
+func RequireModule(moduleService module.Service, moduleKey string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		companyID, ok := GetCompanyID(c)
+		if !ok {
+			c.Next()
+			return
+		}
+
+		var err error
+		if storeID, ok := GetStoreID(c); ok {
+			err = moduleService.RequireEnabledForStore(c.Request.Context(), companyID, storeID, moduleKey)
+		} else {
+			err = moduleService.RequireEnabled(c.Request.Context(), companyID, moduleKey)
+		}
+		if err != nil {
+			var disabled *module.DisabledError
+			if errors.As(err, &disabled) {
+				httputil.RespondError(c, http.StatusForbidden, "Module is not enabled", disabled)
+			} else {
+				httputil.RespondError(c, http.StatusInternalServerError, "Failed to check module", err.Error())
+			}
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
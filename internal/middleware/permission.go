@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"net/http"
+
+	"my-go-driver/internal/domain/rbac"
+	"my-go-driver/pkg/httputil"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LoadPermissions resolves the authenticated admin's permissions and attaches
+// them to the request context. It must run after AdminAuth. API key requests
+// already carry the key's scopes and are passed through.
+func LoadPermissions(rbacService rbac.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, ok := GetAPIKeyID(c); ok {
+			c.Next()
+			return
+		}
+
+		adminID, ok := GetAdminID(c)
+		if !ok {
+			httputil.RespondError(c, http.StatusUnauthorized, "Unauthorized", "User ID not found in context")
+			c.Abort()
+			return
+		}
+
+		permissions, err := rbacService.ResolvePermissions(c.Request.Context(), adminID)
+		if err != nil {
+			httputil.RespondError(c, http.StatusUnauthorized, "Failed to resolve permissions", err.Error())
+			c.Abort()
+			return
+		}
+
+		c.Request = c.Request.WithContext(rbac.WithPermissions(c.Request.Context(), permissions))
+
+		c.Next()
+	}
+}
+
+// RequirePermission returns a gin middleware that rejects callers lacking perm
+func RequirePermission(perm rbac.Permission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := rbac.Check(c.Request.Context(), perm); err != nil {
+			httputil.RespondError(c, http.StatusForbidden, "Permission denied", string(perm)+" is required")
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"net/http"
+
+	"my-go-driver/internal/domain/platform"
+	"my-go-driver/internal/domain/rbac"
+	"my-go-driver/internal/domain/session"
+	"my-go-driver/internal/tenant"
+	"my-go-driver/pkg/httputil"
+	"my-go-driver/pkg/jwt"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PlatformAuth returns a gin middleware for platform operator JWT authentication.
+// Platform requests are not tenant-scoped and may act on any company.
+func PlatformAuth(tokens *jwt.Service, platformService platform.Service, sessionService session.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, ok := authenticateSession(c, tokens, jwt.SubjectPlatform, sessionService)
+		if !ok {
+			return
+		}
+
+		admin, err := platformService.GetProfile(c.Request.Context(), claims.SubjectID())
+		if err != nil {
+			httputil.RespondError(c, http.StatusUnauthorized, "Invalid or expired token", err.Error())
+			c.Abort()
+			return
+		}
+		if !admin.IsActive {
+			httputil.RespondError(c, http.StatusUnauthorized, "Account is inactive", "")
+			c.Abort()
+			return
+		}
+
+		// Operators act on every company with every permission
+		c.Request = c.Request.WithContext(rbac.WithAllPermissions(tenant.WithPlatform(c.Request.Context())))
+
+		c.Next()
+	}
+}
@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+
+	"my-go-driver/internal/domain/ratelimit"
+	"my-go-driver/pkg/httputil"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Rate limit response headers
+const (
+	RateLimitLimitHeader     = "X-RateLimit-Limit"
+	RateLimitRemainingHeader = "X-RateLimit-Remaining"
+	RateLimitResetHeader     = "X-RateLimit-Reset"
+)
+
+// RateLimit returns a gin middleware that enforces the company's API rate
+// limit, counting API key and driver requests against the key or driver as
+// well. It must run after the authentication middleware; requests not scoped
+// to a company are passed through.
+func RateLimit(rateLimitService ratelimit.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		companyID, ok := GetCompanyID(c)
+		if !ok {
+			c.Next()
+			return
+		}
+
+		req := ratelimit.Request{CompanyID: companyID}
+		if id, ok := GetAPIKeyID(c); ok {
+			req.APIKeyID = &id
+		} else if id, ok := GetDriverID(c); ok {
+			req.DriverID = &id
+		}
+
+		result, err := rateLimitService.Allow(c.Request.Context(), req)
+		if err != nil {
+			httputil.RespondError(c, http.StatusInternalServerError, "Failed to apply rate limit", err.Error())
+			c.Abort()
+			return
+		}
+		if result == nil {
+			c.Next()
+			return
+		}
+
+		c.Header(RateLimitLimitHeader, strconv.Itoa(result.Limit))
+		c.Header(RateLimitRemainingHeader, strconv.Itoa(result.Remaining))
+		c.Header(RateLimitResetHeader, strconv.FormatInt(result.Reset.Unix(), 10))
+
+		if !result.Allowed {
+			seconds := math.Ceil(result.RetryAfter.Seconds())
+			c.Header("Retry-After", strconv.Itoa(int(seconds)))
+			httputil.RespondError(c, http.StatusTooManyRequests, "Rate limit exceeded", "")
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
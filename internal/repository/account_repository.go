@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"my-go-driver/internal/domain/account"
+
+	"gorm.io/gorm"
+)
+
+type accountRepository struct {
+	db *gorm.DB
+}
+
+// NewAccountRepository creates a new admin account token repository
+func NewAccountRepository(db *gorm.DB) account.Repository {
+	return &accountRepository{db: db}
+}
+
+func (r *accountRepository) Create(ctx context.Context, token *account.Token) error {
+	return dbFor(ctx, r.db).Create(token).Error
+}
+
+func (r *accountRepository) GetByHash(ctx context.Context, tokenHash string) (*account.Token, error) {
+	var token account.Token
+	err := dbFor(ctx, r.db).Where("token_hash = ?", tokenHash).First(&token).Error
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *accountRepository) MarkUsed(ctx context.Context, id uint64) (bool, error) {
+	result := dbFor(ctx, r.db).Model(&account.Token{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *accountRepository) InvalidateUnused(ctx context.Context, adminID uint64, purpose account.Purpose) error {
+	return dbFor(ctx, r.db).Model(&account.Token{}).
+		Where("admin_id = ? AND purpose = ? AND used_at IS NULL", adminID, purpose).
+		Update("used_at", time.Now()).Error
+}
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+
+	"my-go-driver/internal/domain/activity"
+
+	"gorm.io/gorm"
+)
+
+type activityRepository struct {
+	db *gorm.DB
+}
+
+// NewActivityRepository creates a new activity log repository
+func NewActivityRepository(db *gorm.DB) activity.Repository {
+	return &activityRepository{db: db}
+}
+
+func (r *activityRepository) Create(ctx context.Context, log *activity.Log) error {
+	return dbFor(ctx, r.db).Create(log).Error
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"my-go-driver/internal/domain/apikey"
+
+	"gorm.io/gorm"
+)
+
+type apiKeyRepository struct {
+	db *gorm.DB
+}
+
+// NewAPIKeyRepository creates a new API key repository
+func NewAPIKeyRepository(db *gorm.DB) apikey.Repository {
+	return &apiKeyRepository{db: db}
+}
+
+func (r *apiKeyRepository) Create(ctx context.Context, key *apikey.APIKey) error {
+	return dbFor(ctx, r.db).Create(key).Error
+}
+
+func (r *apiKeyRepository) GetByID(ctx context.Context, id uint64) (*apikey.APIKey, error) {
+	var key apikey.APIKey
+	err := dbFor(ctx, r.db).Where("id = ?", id).First(&key).Error
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+func (r *apiKeyRepository) GetByHash(ctx context.Context, keyHash string) (*apikey.APIKey, error) {
+	var key apikey.APIKey
+	err := dbFor(ctx, r.db).Where("key_hash = ?", keyHash).First(&key).Error
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+func (r *apiKeyRepository) ListByCompany(ctx context.Context, companyID uint64) ([]apikey.APIKey, error) {
+	var keys []apikey.APIKey
+	err := dbFor(ctx, r.db).
+		Where("company_id = ?", companyID).
+		Order("created_at DESC").
+		Find(&keys).Error
+	return keys, err
+}
+
+func (r *apiKeyRepository) Revoke(ctx context.Context, id uint64) error {
+	return dbFor(ctx, r.db).Model(&apikey.APIKey{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", time.Now()).Error
+}
+
+func (r *apiKeyRepository) TouchLastUsed(ctx context.Context, id uint64, at time.Time, ip string) error {
+	return dbFor(ctx, r.db).Model(&apikey.APIKey{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{"last_used_at": at, "last_used_ip": ip}).Error
+}
@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"context"
+
+	"my-go-driver/internal/domain/client"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type clientRepository struct {
+	db *gorm.DB
+}
+
+// NewClientRepository creates a new client repository
+func NewClientRepository(db *gorm.DB) client.Repository {
+	return &clientRepository{db: db}
+}
+
+func (r *clientRepository) Create(ctx context.Context, c *client.Client) error {
+	return dbFor(ctx, r.db).Create(c).Error
+}
+
+func (r *clientRepository) GetByID(ctx context.Context, id uint64) (*client.Client, error) {
+	var c client.Client
+	err := dbFor(ctx, r.db).First(&c, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (r *clientRepository) GetByIDForUpdate(ctx context.Context, id uint64) (*client.Client, error) {
+	var c client.Client
+	err := dbFor(ctx, r.db).Clauses(clause.Locking{Strength: "UPDATE"}).First(&c, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (r *clientRepository) GetByPhone(ctx context.Context, phone string, companyID uint64) (*client.Client, error) {
+	var c client.Client
+	err := dbFor(ctx, r.db).Where("phone = ? AND company_id = ?", phone, companyID).First(&c).Error
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (r *clientRepository) Update(ctx context.Context, c *client.Client) error {
+	return dbFor(ctx, r.db).Save(c).Error
+}
+
+func (r *clientRepository) Delete(ctx context.Context, id uint64) error {
+	return dbFor(ctx, r.db).Delete(&client.Client{}, id).Error
+}
+
+func (r *clientRepository) List(ctx context.Context, query client.ListClientsQuery) ([]client.Client, int64, error) {
+	var clients []client.Client
+	var total int64
+
+	db := dbFor(ctx, r.db).Model(&client.Client{})
+
+	// Apply filters
+	if query.CompanyID > 0 {
+		db = db.Where("company_id = ?", query.CompanyID)
+	}
+
+	if query.Phone != "" {
+		db = db.Where("phone = ?", query.Phone)
+	}
+
+	if query.Search != "" {
+		searchPattern := "%" + query.Search + "%"
+		if phone := client.NormalizePhone(query.Search); phone != "" {
+			db = db.Where("name LIKE ? OR email LIKE ? OR phone LIKE ?", searchPattern, searchPattern, "%"+phone+"%")
+		} else {
+			db = db.Where("name LIKE ? OR email LIKE ?", searchPattern, searchPattern)
+		}
+	}
+
+	// Get total count
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Set defaults
+	if query.Page <= 0 {
+		query.Page = 1
+	}
+	if query.Limit <= 0 {
+		query.Limit = 10
+	}
+
+	// Apply pagination
+	offset := (query.Page - 1) * query.Limit
+	err := db.Offset(offset).Limit(query.Limit).Order("name").Find(&clients).Error
+
+	return clients, total, err
+}
+
+func (r *clientRepository) CountOrders(ctx context.Context, clientID uint64) (int64, error) {
+	var count int64
+	err := dbFor(ctx, r.db).Table("orders").Where("client_id = ?", clientID).Count(&count).Error
+	return count, err
+}
+
+func (r *clientRepository) ReassignOrders(ctx context.Context, fromIDs []uint64, toID uint64) error {
+	return dbFor(ctx, r.db).Table("orders").Where("client_id IN ?", fromIDs).Update("client_id", toID).Error
+}
+
+func (r *clientRepository) CreateAddress(ctx context.Context, a *client.Address) error {
+	return dbFor(ctx, r.db).Create(a).Error
+}
+
+func (r *clientRepository) GetAddress(ctx context.Context, id uint64) (*client.Address, error) {
+	var a client.Address
+	err := dbFor(ctx, r.db).First(&a, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+func (r *clientRepository) ListAddresses(ctx context.Context, clientIDs []uint64) ([]client.Address, error) {
+	var addresses []client.Address
+	err := dbFor(ctx, r.db).
+		Where("client_id IN ?", clientIDs).
+		Order("is_default DESC, id").
+		Find(&addresses).Error
+	return addresses, err
+}
+
+func (r *clientRepository) UpdateAddress(ctx context.Context, a *client.Address) error {
+	return dbFor(ctx, r.db).Save(a).Error
+}
+
+func (r *clientRepository) DeleteAddress(ctx context.Context, id uint64) error {
+	return dbFor(ctx, r.db).Delete(&client.Address{}, id).Error
+}
+
+func (r *clientRepository) SetDefaultAddress(ctx context.Context, clientID, addressID uint64) error {
+	return dbFor(ctx, r.db).Model(&client.Address{}).
+		Where("client_id = ?", clientID).
+		Update("is_default", gorm.Expr("id = ?", addressID)).Error
+}
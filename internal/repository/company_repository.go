@@ -7,6 +7,7 @@ import (
 	"my-go-driver/internal/domain/company"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type companyRepository struct {
@@ -19,12 +20,21 @@ func NewCompanyRepository(db *gorm.DB) company.Repository {
 }
 
 func (r *companyRepository) Create(ctx context.Context, c *company.Company) error {
-	return r.db.WithContext(ctx).Create(c).Error
+	return dbFor(ctx, r.db).Create(c).Error
 }
 
 func (r *companyRepository) GetByID(ctx context.Context, id uint64) (*company.Company, error) {
 	var c company.Company
-	err := r.db.WithContext(ctx).First(&c, id).Error
+	err := dbFor(ctx, r.db).First(&c, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (r *companyRepository) GetByIDForUpdate(ctx context.Context, id uint64) (*company.Company, error) {
+	var c company.Company
+	err := dbFor(ctx, r.db).Clauses(clause.Locking{Strength: "UPDATE"}).First(&c, id).Error
 	if err != nil {
 		return nil, err
 	}
@@ -32,20 +42,24 @@ func (r *companyRepository) GetByID(ctx context.Context, id uint64) (*company.Co
 }
 
 func (r *companyRepository) Update(ctx context.Context, c *company.Company) error {
-	return r.db.WithContext(ctx).Save(c).Error
+	return dbFor(ctx, r.db).Save(c).Error
 }
 
 func (r *companyRepository) Delete(ctx context.Context, id uint64) error {
-	return r.db.WithContext(ctx).Delete(&company.Company{}, id).Error
+	return dbFor(ctx, r.db).Delete(&company.Company{}, id).Error
 }
 
 func (r *companyRepository) List(ctx context.Context, query company.ListCompaniesQuery) ([]company.Company, int64, error) {
 	var companies []company.Company
 	var total int64
 
-	db := r.db.WithContext(ctx).Model(&company.Company{})
+	db := dbFor(ctx, r.db).Model(&company.Company{})
 
 	// Apply filters
+	if query.ID > 0 {
+		db = db.Where("id = ?", query.ID)
+	}
+
 	if query.Status != "" {
 		db = db.Where("status = ?", query.Status)
 	}
@@ -92,21 +106,21 @@ func (r *companyRepository) UpdateBranding(ctx context.Context, id uint64, brand
 		return fmt.Errorf("no branding fields to update")
 	}
 
-	return r.db.WithContext(ctx).Model(&company.Company{}).Where("id = ?", id).Updates(updates).Error
+	return dbFor(ctx, r.db).Model(&company.Company{}).Where("id = ?", id).Updates(updates).Error
 }
 
 func (r *companyRepository) UpdateStatus(ctx context.Context, id uint64, status company.CompanyStatus) error {
-	return r.db.WithContext(ctx).Model(&company.Company{}).Where("id = ?", id).Update("status", status).Error
+	return dbFor(ctx, r.db).Model(&company.Company{}).Where("id = ?", id).Update("status", status).Error
 }
 
 // Company Admin methods
 func (r *companyRepository) CreateAdmin(ctx context.Context, admin *company.CompanyAdmin) error {
-	return r.db.WithContext(ctx).Create(admin).Error
+	return dbFor(ctx, r.db).Create(admin).Error
 }
 
 func (r *companyRepository) GetAdminByEmail(ctx context.Context, email string) (*company.CompanyAdmin, error) {
 	var admin company.CompanyAdmin
-	err := r.db.WithContext(ctx).Where("email = ?", email).First(&admin).Error
+	err := dbFor(ctx, r.db).Where("email = ?", email).First(&admin).Error
 	if err != nil {
 		return nil, err
 	}
@@ -115,15 +129,19 @@ func (r *companyRepository) GetAdminByEmail(ctx context.Context, email string) (
 
 func (r *companyRepository) GetAdminByID(ctx context.Context, id uint64) (*company.CompanyAdmin, error) {
 	var admin company.CompanyAdmin
-	err := r.db.WithContext(ctx).Preload("Company").First(&admin, id).Error
+	err := dbFor(ctx, r.db).Preload("Company").First(&admin, id).Error
 	if err != nil {
 		return nil, err
 	}
 	return &admin, nil
 }
 
+func (r *companyRepository) UpdateAdmin(ctx context.Context, admin *company.CompanyAdmin) error {
+	return dbFor(ctx, r.db).Omit("Company").Save(admin).Error
+}
+
 func (r *companyRepository) ListAdmins(ctx context.Context, companyID uint64) ([]company.CompanyAdmin, error) {
 	var admins []company.CompanyAdmin
-	err := r.db.WithContext(ctx).Where("company_id = ?", companyID).Find(&admins).Error
+	err := dbFor(ctx, r.db).Where("company_id = ?", companyID).Find(&admins).Error
 	return admins, err
 }
@@ -6,6 +6,7 @@ import (
 	"my-go-driver/internal/domain/driver"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type driverRepository struct {
@@ -18,12 +19,21 @@ func NewDriverRepository(db *gorm.DB) driver.Repository {
 }
 
 func (r *driverRepository) Create(ctx context.Context, d *driver.Driver) error {
-	return r.db.WithContext(ctx).Create(d).Error
+	return dbFor(ctx, r.db).Create(d).Error
 }
 
 func (r *driverRepository) GetByID(ctx context.Context, id uint64) (*driver.Driver, error) {
 	var d driver.Driver
-	err := r.db.WithContext(ctx).First(&d, id).Error
+	err := dbFor(ctx, r.db).First(&d, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+func (r *driverRepository) GetByIDForUpdate(ctx context.Context, id uint64) (*driver.Driver, error) {
+	var d driver.Driver
+	err := dbFor(ctx, r.db).Clauses(clause.Locking{Strength: "UPDATE"}).First(&d, id).Error
 	if err != nil {
 		return nil, err
 	}
@@ -32,7 +42,7 @@ func (r *driverRepository) GetByID(ctx context.Context, id uint64) (*driver.Driv
 
 func (r *driverRepository) GetByPhone(ctx context.Context, phone string, companyID uint64) (*driver.Driver, error) {
 	var d driver.Driver
-	err := r.db.WithContext(ctx).Where("phone = ? AND company_id = ?", phone, companyID).First(&d).Error
+	err := dbFor(ctx, r.db).Where("phone = ? AND company_id = ?", phone, companyID).First(&d).Error
 	if err != nil {
 		return nil, err
 	}
@@ -40,18 +50,18 @@ func (r *driverRepository) GetByPhone(ctx context.Context, phone string, company
 }
 
 func (r *driverRepository) Update(ctx context.Context, d *driver.Driver) error {
-	return r.db.WithContext(ctx).Save(d).Error
+	return dbFor(ctx, r.db).Save(d).Error
 }
 
 func (r *driverRepository) Delete(ctx context.Context, id uint64) error {
-	return r.db.WithContext(ctx).Delete(&driver.Driver{}, id).Error
+	return dbFor(ctx, r.db).Delete(&driver.Driver{}, id).Error
 }
 
 func (r *driverRepository) List(ctx context.Context, query driver.ListDriversQuery) ([]driver.Driver, int64, error) {
 	var drivers []driver.Driver
 	var total int64
 
-	db := r.db.WithContext(ctx).Model(&driver.Driver{})
+	db := dbFor(ctx, r.db).Model(&driver.Driver{})
 
 	// Apply filters
 	if query.CompanyID > 0 {
@@ -96,7 +106,11 @@ func (r *driverRepository) List(ctx context.Context, query driver.ListDriversQue
 }
 
 func (r *driverRepository) UpdateStatus(ctx context.Context, id uint64, status driver.DriverStatus) error {
-	return r.db.WithContext(ctx).Model(&driver.Driver{}).Where("id = ?", id).Update("status", status).Error
+	return dbFor(ctx, r.db).Model(&driver.Driver{}).Where("id = ?", id).Update("status", status).Error
+}
+
+func (r *driverRepository) UpdateOnlineStatus(ctx context.Context, id uint64, status driver.OnlineStatus) error {
+	return dbFor(ctx, r.db).Model(&driver.Driver{}).Where("id = ?", id).Update("online_status", status).Error
 }
 
 func (r *driverRepository) GetPerformance(ctx context.Context, driverID uint64) (*driver.DriverPerformance, error) {
@@ -121,7 +135,7 @@ func (r *driverRepository) GetPerformance(ctx context.Context, driverID uint64)
 		GROUP BY driver_id
 	`
 
-	err := r.db.WithContext(ctx).Raw(query, driverID).Scan(&performance).Error
+	err := dbFor(ctx, r.db).Raw(query, driverID).Scan(&performance).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			// Return zero performance if no shifts found
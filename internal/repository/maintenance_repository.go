@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"my-go-driver/internal/domain/maintenance"
+
+	"gorm.io/gorm"
+)
+
+type maintenanceRepository struct {
+	db *gorm.DB
+}
+
+// NewMaintenanceRepository creates a new maintenance repository
+func NewMaintenanceRepository(db *gorm.DB) maintenance.Repository {
+	return &maintenanceRepository{db: db}
+}
+
+func (r *maintenanceRepository) CreateRule(ctx context.Context, rule *maintenance.Rule) error {
+	return dbFor(ctx, r.db).Create(rule).Error
+}
+
+func (r *maintenanceRepository) GetRuleByID(ctx context.Context, id uint64) (*maintenance.Rule, error) {
+	var rule maintenance.Rule
+	err := dbFor(ctx, r.db).First(&rule, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+func (r *maintenanceRepository) GetRuleByServiceType(ctx context.Context, companyID uint64, serviceType string) (*maintenance.Rule, error) {
+	var rule maintenance.Rule
+	err := dbFor(ctx, r.db).Where("company_id = ? AND service_type = ?", companyID, serviceType).First(&rule).Error
+	if err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+func (r *maintenanceRepository) UpdateRule(ctx context.Context, rule *maintenance.Rule) error {
+	return dbFor(ctx, r.db).Save(rule).Error
+}
+
+func (r *maintenanceRepository) DeleteRule(ctx context.Context, id uint64) error {
+	return dbFor(ctx, r.db).Delete(&maintenance.Rule{}, id).Error
+}
+
+func (r *maintenanceRepository) ListRules(ctx context.Context, companyID uint64) ([]maintenance.Rule, error) {
+	var rules []maintenance.Rule
+	db := dbFor(ctx, r.db)
+	if companyID > 0 {
+		db = db.Where("company_id = ?", companyID)
+	}
+	err := db.Order("service_type").Find(&rules).Error
+	return rules, err
+}
+
+func (r *maintenanceRepository) CreateRecord(ctx context.Context, record *maintenance.Record) error {
+	return dbFor(ctx, r.db).Create(record).Error
+}
+
+func (r *maintenanceRepository) ListRecords(ctx context.Context, query maintenance.ListRecordsQuery) ([]maintenance.Record, int64, error) {
+	var records []maintenance.Record
+	var total int64
+
+	db := dbFor(ctx, r.db).Model(&maintenance.Record{}).Where("vehicle_id = ?", query.VehicleID)
+
+	// Apply filters
+	if query.ServiceType != "" {
+		db = db.Where("service_type = ?", query.ServiceType)
+	}
+
+	// Get total count
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Set defaults
+	if query.Page <= 0 {
+		query.Page = 1
+	}
+	if query.Limit <= 0 {
+		query.Limit = 10
+	}
+
+	// Apply pagination
+	offset := (query.Page - 1) * query.Limit
+	err := db.Offset(offset).Limit(query.Limit).Order("serviced_at DESC, id DESC").Find(&records).Error
+
+	return records, total, err
+}
+
+func (r *maintenanceRepository) LastServiceDates(ctx context.Context, vehicleID uint64) (map[string]time.Time, error) {
+	var rows []struct {
+		ServiceType string
+		ServicedAt  time.Time
+	}
+	err := dbFor(ctx, r.db).Model(&maintenance.Record{}).
+		Select("service_type, MAX(serviced_at) AS serviced_at").
+		Where("vehicle_id = ?", vehicleID).
+		Group("service_type").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	dates := make(map[string]time.Time, len(rows))
+	for _, row := range rows {
+		dates[row.ServiceType] = row.ServicedAt
+	}
+	return dates, nil
+}
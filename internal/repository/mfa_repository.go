@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"my-go-driver/internal/domain/mfa"
+
+	"gorm.io/gorm"
+)
+
+type mfaRepository struct {
+	db *gorm.DB
+}
+
+// NewMFARepository creates a new MFA repository
+func NewMFARepository(db *gorm.DB) mfa.Repository {
+	return &mfaRepository{db: db}
+}
+
+func (r *mfaRepository) GetEnrollment(ctx context.Context, adminID uint64) (*mfa.Enrollment, error) {
+	var e mfa.Enrollment
+	err := dbFor(ctx, r.db).Where("admin_id = ?", adminID).First(&e).Error
+	if err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+func (r *mfaRepository) CreateEnrollment(ctx context.Context, e *mfa.Enrollment) error {
+	return dbFor(ctx, r.db).Create(e).Error
+}
+
+func (r *mfaRepository) ConfirmEnrollment(ctx context.Context, adminID uint64, confirmedAt time.Time) error {
+	return dbFor(ctx, r.db).Model(&mfa.Enrollment{}).
+		Where("admin_id = ?", adminID).
+		Update("confirmed_at", confirmedAt).Error
+}
+
+func (r *mfaRepository) DeleteEnrollment(ctx context.Context, adminID uint64) error {
+	return dbFor(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("admin_id = ?", adminID).Delete(&mfa.RecoveryCode{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("admin_id = ?", adminID).Delete(&mfa.Enrollment{}).Error
+	})
+}
+
+func (r *mfaRepository) AdvanceLastUsedStep(ctx context.Context, adminID uint64, step uint64) (bool, error) {
+	result := dbFor(ctx, r.db).Model(&mfa.Enrollment{}).
+		Where("admin_id = ? AND last_used_step < ?", adminID, step).
+		Update("last_used_step", step)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *mfaRepository) ReplaceRecoveryCodes(ctx context.Context, adminID uint64, codes []mfa.RecoveryCode) error {
+	return dbFor(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("admin_id = ?", adminID).Delete(&mfa.RecoveryCode{}).Error; err != nil {
+			return err
+		}
+		if len(codes) == 0 {
+			return nil
+		}
+		return tx.Create(&codes).Error
+	})
+}
+
+func (r *mfaRepository) UseRecoveryCode(ctx context.Context, adminID uint64, codeHash string) (bool, error) {
+	result := dbFor(ctx, r.db).Model(&mfa.RecoveryCode{}).
+		Where("admin_id = ? AND code_hash = ? AND used_at IS NULL", adminID, codeHash).
+		Limit(1).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *mfaRepository) CountUnusedRecoveryCodes(ctx context.Context, adminID uint64) (int64, error) {
+	var count int64
+	err := dbFor(ctx, r.db).Model(&mfa.RecoveryCode{}).
+		Where("admin_id = ? AND used_at IS NULL", adminID).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *mfaRepository) CreateChallenge(ctx context.Context, c *mfa.Challenge) error {
+	return dbFor(ctx, r.db).Create(c).Error
+}
+
+func (r *mfaRepository) GetChallengeByHash(ctx context.Context, tokenHash string) (*mfa.Challenge, error) {
+	var c mfa.Challenge
+	err := dbFor(ctx, r.db).Where("token_hash = ?", tokenHash).First(&c).Error
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (r *mfaRepository) IncrementChallengeAttempts(ctx context.Context, id uint64) error {
+	return dbFor(ctx, r.db).Model(&mfa.Challenge{}).
+		Where("id = ?", id).
+		Update("attempts", gorm.Expr("attempts + 1")).Error
+}
+
+func (r *mfaRepository) MarkChallengeUsed(ctx context.Context, id uint64) (bool, error) {
+	result := dbFor(ctx, r.db).Model(&mfa.Challenge{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
@@ -19,13 +19,19 @@ func NewModuleRepository(db *gorm.DB) module.Repository {
 
 func (r *moduleRepository) ListAllModules(ctx context.Context) ([]module.ModuleMaster, error) {
 	var modules []module.ModuleMaster
-	err := r.db.WithContext(ctx).Order("category, name").Find(&modules).Error
+	err := dbFor(ctx, r.db).Order("category, name").Find(&modules).Error
+	return modules, err
+}
+
+func (r *moduleRepository) ListDefaultModules(ctx context.Context) ([]module.ModuleMaster, error) {
+	var modules []module.ModuleMaster
+	err := dbFor(ctx, r.db).Where("default_enabled = ?", true).Order("category, name").Find(&modules).Error
 	return modules, err
 }
 
 func (r *moduleRepository) GetModuleByID(ctx context.Context, id uint64) (*module.ModuleMaster, error) {
 	var mod module.ModuleMaster
-	err := r.db.WithContext(ctx).First(&mod, id).Error
+	err := dbFor(ctx, r.db).First(&mod, id).Error
 	if err != nil {
 		return nil, err
 	}
@@ -33,28 +39,97 @@ func (r *moduleRepository) GetModuleByID(ctx context.Context, id uint64) (*modul
 }
 
 func (r *moduleRepository) AssignModule(ctx context.Context, companyModule *module.CompanyModule) error {
-	return r.db.WithContext(ctx).Create(companyModule).Error
+	return dbFor(ctx, r.db).Create(companyModule).Error
 }
 
 func (r *moduleRepository) GetCompanyModules(ctx context.Context, companyID uint64) ([]module.CompanyModule, error) {
 	var modules []module.CompanyModule
-	err := r.db.WithContext(ctx).Preload("Module").Where("company_id = ?", companyID).Find(&modules).Error
+	err := dbFor(ctx, r.db).Preload("Module").Where("company_id = ?", companyID).Find(&modules).Error
 	return modules, err
 }
 
 func (r *moduleRepository) GetCompanyModule(ctx context.Context, companyID, moduleID uint64) (*module.CompanyModule, error) {
 	var companyModule module.CompanyModule
-	err := r.db.WithContext(ctx).Where("company_id = ? AND module_id = ?", companyID, moduleID).First(&companyModule).Error
+	err := dbFor(ctx, r.db).Where("company_id = ? AND module_id = ?", companyID, moduleID).First(&companyModule).Error
 	if err != nil {
 		return nil, err
 	}
 	return &companyModule, nil
 }
 
+func (r *moduleRepository) GetCompanyModuleByKey(ctx context.Context, companyID uint64, moduleKey string) (*module.CompanyModule, error) {
+	var companyModule module.CompanyModule
+	err := dbFor(ctx, r.db).
+		Preload("Module").
+		Joins("JOIN modules_master ON modules_master.id = company_modules.module_id").
+		Where("company_modules.company_id = ? AND modules_master.module_key = ?", companyID, moduleKey).
+		First(&companyModule).Error
+	if err != nil {
+		return nil, err
+	}
+	return &companyModule, nil
+}
+
+func (r *moduleRepository) ListEnabledModuleKeys(ctx context.Context, companyID uint64) ([]string, error) {
+	var keys []string
+	err := dbFor(ctx, r.db).Model(&module.CompanyModule{}).
+		Joins("JOIN modules_master ON modules_master.id = company_modules.module_id").
+		Where("company_modules.company_id = ? AND company_modules.is_enabled = ?", companyID, true).
+		Pluck("modules_master.module_key", &keys).Error
+	return keys, err
+}
+
 func (r *moduleRepository) UpdateModuleConfig(ctx context.Context, id uint64, config module.ModuleConfig) error {
-	return r.db.WithContext(ctx).Model(&module.CompanyModule{}).Where("id = ?", id).Update("config", config).Error
+	return dbFor(ctx, r.db).Model(&module.CompanyModule{}).Where("id = ?", id).Update("config", config).Error
+}
+
+func (r *moduleRepository) SetModuleEnabled(ctx context.Context, id uint64, enabled bool) error {
+	return dbFor(ctx, r.db).Model(&module.CompanyModule{}).Where("id = ?", id).Update("is_enabled", enabled).Error
+}
+
+func (r *moduleRepository) UpdateCompanyModule(ctx context.Context, id uint64, enabled bool, config module.ModuleConfig) error {
+	return dbFor(ctx, r.db).Model(&module.CompanyModule{}).Where("id = ?", id).
+		Updates(map[string]interface{}{"is_enabled": enabled, "config": config}).Error
 }
 
 func (r *moduleRepository) RemoveModule(ctx context.Context, companyID, moduleID uint64) error {
-	return r.db.WithContext(ctx).Where("company_id = ? AND module_id = ?", companyID, moduleID).Delete(&module.CompanyModule{}).Error
+	return dbFor(ctx, r.db).Where("company_id = ? AND module_id = ?", companyID, moduleID).Delete(&module.CompanyModule{}).Error
+}
+
+func (r *moduleRepository) ListStoreModules(ctx context.Context, storeID uint64) ([]module.StoreModule, error) {
+	var modules []module.StoreModule
+	err := dbFor(ctx, r.db).Preload("Module").Where("store_id = ?", storeID).Find(&modules).Error
+	return modules, err
+}
+
+func (r *moduleRepository) GetStoreModule(ctx context.Context, storeID, moduleID uint64) (*module.StoreModule, error) {
+	var storeModule module.StoreModule
+	err := dbFor(ctx, r.db).Where("store_id = ? AND module_id = ?", storeID, moduleID).First(&storeModule).Error
+	if err != nil {
+		return nil, err
+	}
+	return &storeModule, nil
+}
+
+func (r *moduleRepository) SaveStoreModule(ctx context.Context, storeModule *module.StoreModule) error {
+	return dbFor(ctx, r.db).Omit("Module").Save(storeModule).Error
+}
+
+func (r *moduleRepository) RemoveStoreModule(ctx context.Context, storeID, moduleID uint64) error {
+	return dbFor(ctx, r.db).Where("store_id = ? AND module_id = ?", storeID, moduleID).Delete(&module.StoreModule{}).Error
+}
+
+func (r *moduleRepository) RemoveCompanyStoreModules(ctx context.Context, companyID, moduleID uint64) error {
+	db := dbFor(ctx, r.db)
+	stores := db.Table("stores").Select("id").Where("company_id = ?", companyID)
+	return db.Where("module_id = ? AND store_id IN (?)", moduleID, stores).Delete(&module.StoreModule{}).Error
+}
+
+func (r *moduleRepository) ListStoreDisabledModuleKeys(ctx context.Context, storeID uint64) ([]string, error) {
+	var keys []string
+	err := dbFor(ctx, r.db).Model(&module.StoreModule{}).
+		Joins("JOIN modules_master ON modules_master.id = store_modules.module_id").
+		Where("store_modules.store_id = ? AND store_modules.is_enabled = ?", storeID, false).
+		Pluck("modules_master.module_key", &keys).Error
+	return keys, err
 }
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+
+	"my-go-driver/internal/domain/notification"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type notificationRepository struct {
+	db *gorm.DB
+}
+
+// NewNotificationRepository creates a new notification repository
+func NewNotificationRepository(db *gorm.DB) notification.Repository {
+	return &notificationRepository{db: db}
+}
+
+func (r *notificationRepository) Create(ctx context.Context, n *notification.Notification) error {
+	return dbFor(ctx, r.db).Create(n).Error
+}
+
+func (r *notificationRepository) GetByID(ctx context.Context, id uint64) (*notification.Notification, error) {
+	var n notification.Notification
+	err := dbFor(ctx, r.db).First(&n, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &n, nil
+}
+
+func (r *notificationRepository) List(ctx context.Context, query notification.ListNotificationsQuery) ([]notification.Notification, int64, error) {
+	var notifications []notification.Notification
+	var total int64
+
+	db := dbFor(ctx, r.db).Model(&notification.Notification{}).Where("driver_id IS NULL")
+
+	// Apply filters
+	if query.CompanyID > 0 {
+		db = db.Where("company_id = ?", query.CompanyID)
+	}
+
+	// Notifications addressed to other admins are never listed
+	db = db.Where("user_id IS NULL OR user_id = ?", query.UserID)
+
+	if query.Type != "" {
+		db = db.Where("type = ?", query.Type)
+	}
+
+	if query.IsRead != nil {
+		db = db.Where("EXISTS (SELECT 1 FROM notification_reads r WHERE r.notification_id = notifications.id AND r.admin_id = ?) = ?", query.UserID, *query.IsRead)
+	}
+
+	// Get total count
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Set defaults
+	if query.Page <= 0 {
+		query.Page = 1
+	}
+	if query.Limit <= 0 {
+		query.Limit = 10
+	}
+
+	// Apply pagination
+	offset := (query.Page - 1) * query.Limit
+	err := db.Offset(offset).Limit(query.Limit).Order("created_at DESC, id DESC").Find(&notifications).Error
+	if err != nil || len(notifications) == 0 {
+		return notifications, total, err
+	}
+
+	// Report the admin's own read state
+	ids := make([]uint64, len(notifications))
+	for i, n := range notifications {
+		ids[i] = n.ID
+	}
+	var readIDs []uint64
+	err = dbFor(ctx, r.db).Model(&notification.Read{}).
+		Where("admin_id = ? AND notification_id IN ?", query.UserID, ids).
+		Pluck("notification_id", &readIDs).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	read := make(map[uint64]bool, len(readIDs))
+	for _, id := range readIDs {
+		read[id] = true
+	}
+	for i := range notifications {
+		notifications[i].IsRead = read[notifications[i].ID]
+	}
+
+	return notifications, total, nil
+}
+
+func (r *notificationRepository) MarkRead(ctx context.Context, id, adminID uint64) error {
+	return dbFor(ctx, r.db).Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&notification.Read{NotificationID: id, AdminID: adminID}).Error
+}
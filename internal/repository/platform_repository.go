@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+
+	"my-go-driver/internal/domain/platform"
+
+	"gorm.io/gorm"
+)
+
+type platformRepository struct {
+	db *gorm.DB
+}
+
+// NewPlatformRepository creates a new platform operator repository
+func NewPlatformRepository(db *gorm.DB) platform.Repository {
+	return &platformRepository{db: db}
+}
+
+func (r *platformRepository) Create(ctx context.Context, admin *platform.Admin) error {
+	return dbFor(ctx, r.db).Create(admin).Error
+}
+
+func (r *platformRepository) GetByEmail(ctx context.Context, email string) (*platform.Admin, error) {
+	var admin platform.Admin
+	err := dbFor(ctx, r.db).Where("email = ? AND deleted_at IS NULL", email).First(&admin).Error
+	if err != nil {
+		return nil, err
+	}
+	return &admin, nil
+}
+
+func (r *platformRepository) GetByID(ctx context.Context, id uint64) (*platform.Admin, error) {
+	var admin platform.Admin
+	err := dbFor(ctx, r.db).Where("deleted_at IS NULL").First(&admin, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &admin, nil
+}
@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+
+	"my-go-driver/internal/domain/company"
+	"my-go-driver/internal/domain/driver"
+	"my-go-driver/internal/domain/quota"
+
+	"gorm.io/gorm"
+)
+
+type quotaRepository struct {
+	db *gorm.DB
+}
+
+// NewQuotaRepository creates a new quota repository
+func NewQuotaRepository(db *gorm.DB) quota.Repository {
+	return &quotaRepository{db: db}
+}
+
+func (r *quotaRepository) CountActiveDrivers(ctx context.Context, companyID uint64) (int64, error) {
+	var count int64
+	err := dbFor(ctx, r.db).Model(&driver.Driver{}).
+		Where("company_id = ? AND status <> ? AND deleted_at IS NULL", companyID, driver.DriverStatusSuspended).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *quotaRepository) CountActiveAdmins(ctx context.Context, companyID uint64) (int64, error) {
+	var count int64
+	err := dbFor(ctx, r.db).Model(&company.CompanyAdmin{}).
+		Where("company_id = ? AND is_active = ? AND deleted_at IS NULL", companyID, true).
+		Count(&count).Error
+	return count, err
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+// newDryRunDB returns a MySQL connection that builds statements without
+// running them, and the statements it has built
+func newDryRunDB(t *testing.T) (*gorm.DB, *[]string) {
+	db, err := gorm.Open(mysql.New(mysql.Config{DSN: "test@tcp(127.0.0.1:3306)/test", SkipInitializeWithVersion: true}),
+		&gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	if err != nil {
+		t.Fatalf("gorm.Open: %v", err)
+	}
+
+	var statements []string
+	err = db.Callback().Query().After("gorm:query").Register("test:capture", func(tx *gorm.DB) {
+		statements = append(statements, tx.Dialector.Explain(tx.Statement.SQL.String(), tx.Statement.Vars...))
+	})
+	if err != nil {
+		t.Fatalf("register callback: %v", err)
+	}
+	return db, &statements
+}
+
+func TestQuotaRepositoryCounts(t *testing.T) {
+	tests := []struct {
+		name  string
+		count func(r *quotaRepository) error
+		want  string
+	}{
+		{
+			name: "drivers exclude suspended and deleted drivers",
+			count: func(r *quotaRepository) error {
+				_, err := r.CountActiveDrivers(context.Background(), 3)
+				return err
+			},
+			want: "SELECT count(*) FROM `drivers` WHERE company_id = 3 AND status <> 'suspended' AND deleted_at IS NULL",
+		},
+		{
+			name: "seats exclude deactivated and deleted admins",
+			count: func(r *quotaRepository) error {
+				_, err := r.CountActiveAdmins(context.Background(), 3)
+				return err
+			},
+			want: "SELECT count(*) FROM `company_admins` WHERE company_id = 3 AND is_active = true AND deleted_at IS NULL",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, statements := newDryRunDB(t)
+			if err := tt.count(&quotaRepository{db: db}); err != nil {
+				t.Fatalf("count: %v", err)
+			}
+			if len(*statements) != 1 || (*statements)[0] != tt.want {
+				t.Fatalf("statements = %q, want [%q]", *statements, tt.want)
+			}
+		})
+	}
+}
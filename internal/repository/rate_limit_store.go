@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"my-go-driver/pkg/tokenbucket"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// rateLimitBucket is a row of the rate_limit_buckets table
+type rateLimitBucket struct {
+	BucketKey  string    `gorm:"primaryKey;size:255"`
+	Tokens     float64   `gorm:"not null"`
+	RefilledAt time.Time `gorm:"not null"`
+}
+
+func (rateLimitBucket) TableName() string {
+	return "rate_limit_buckets"
+}
+
+type rateLimitStore struct {
+	db *gorm.DB
+}
+
+// NewRateLimitStore creates a token bucket store backed by the
+// rate_limit_buckets table, shared by every API instance using the database
+func NewRateLimitStore(db *gorm.DB) tokenbucket.Store {
+	return &rateLimitStore{db: db}
+}
+
+func (s *rateLimitStore) Take(ctx context.Context, key string, rate tokenbucket.Rate, now time.Time) (tokenbucket.Result, error) {
+	// Insert a full bucket before locking it, so concurrent first requests
+	// wait on an existing row instead of taking gap locks that deadlock
+	seed := rateLimitBucket{BucketKey: key, Tokens: float64(rate.Limit), RefilledAt: now}
+	err := s.db.WithContext(ctx).
+		Clauses(clause.Insert{Modifier: "IGNORE"}).
+		Create(&seed).Error
+	if err != nil {
+		return tokenbucket.Result{}, err
+	}
+
+	var result tokenbucket.Result
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var row rateLimitBucket
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("bucket_key = ?", key).
+			First(&row).Error
+		if err != nil {
+			return err
+		}
+
+		var bucket tokenbucket.Bucket
+		bucket, result = rate.Take(tokenbucket.Bucket{Tokens: row.Tokens, UpdatedAt: row.RefilledAt}, now)
+
+		return tx.Model(&rateLimitBucket{}).
+			Where("bucket_key = ?", key).
+			Updates(map[string]interface{}{
+				"tokens":      bucket.Tokens,
+				"refilled_at": bucket.UpdatedAt,
+			}).Error
+	})
+	return result, err
+}
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+
+	"my-go-driver/internal/domain/rbac"
+
+	"gorm.io/gorm"
+)
+
+type rbacRepository struct {
+	db *gorm.DB
+}
+
+// NewRBACRepository creates a new custom role repository
+func NewRBACRepository(db *gorm.DB) rbac.Repository {
+	return &rbacRepository{db: db}
+}
+
+func (r *rbacRepository) Create(ctx context.Context, role *rbac.Role) error {
+	return dbFor(ctx, r.db).Create(role).Error
+}
+
+func (r *rbacRepository) GetByID(ctx context.Context, id uint64) (*rbac.Role, error) {
+	var role rbac.Role
+	err := dbFor(ctx, r.db).First(&role, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &role, nil
+}
+
+func (r *rbacRepository) GetByName(ctx context.Context, companyID uint64, name string) (*rbac.Role, error) {
+	var role rbac.Role
+	err := dbFor(ctx, r.db).Where("company_id = ? AND name = ?", companyID, name).First(&role).Error
+	if err != nil {
+		return nil, err
+	}
+	return &role, nil
+}
+
+func (r *rbacRepository) ListByCompany(ctx context.Context, companyID uint64) ([]rbac.Role, error) {
+	var roles []rbac.Role
+	err := dbFor(ctx, r.db).Where("company_id = ?", companyID).Order("name").Find(&roles).Error
+	return roles, err
+}
+
+func (r *rbacRepository) Update(ctx context.Context, role *rbac.Role) error {
+	return dbFor(ctx, r.db).Save(role).Error
+}
+
+func (r *rbacRepository) Delete(ctx context.Context, id uint64) error {
+	return dbFor(ctx, r.db).Delete(&rbac.Role{}, id).Error
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"my-go-driver/internal/domain/session"
+	"my-go-driver/pkg/jwt"
+
+	"gorm.io/gorm"
+)
+
+type sessionRepository struct {
+	db *gorm.DB
+}
+
+// NewSessionRepository creates a new session repository
+func NewSessionRepository(db *gorm.DB) session.Repository {
+	return &sessionRepository{db: db}
+}
+
+func (r *sessionRepository) CreateSession(ctx context.Context, s *session.Session) error {
+	return dbFor(ctx, r.db).Create(s).Error
+}
+
+func (r *sessionRepository) GetSessionByID(ctx context.Context, id uint64) (*session.Session, error) {
+	var s session.Session
+	err := dbFor(ctx, r.db).First(&s, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (r *sessionRepository) RevokeSession(ctx context.Context, id uint64, reason string) error {
+	return r.revoke(ctx, reason, "id = ?", id)
+}
+
+func (r *sessionRepository) RevokeSubjectSessions(ctx context.Context, subjectType jwt.SubjectType, subjectID uint64, reason string) error {
+	return r.revoke(ctx, reason, "subject_type = ? AND subject_id = ?", subjectType, subjectID)
+}
+
+func (r *sessionRepository) RevokeCompanySessions(ctx context.Context, companyID uint64, reason string) error {
+	return r.revoke(ctx, reason, "company_id = ?", companyID)
+}
+
+func (r *sessionRepository) CreateRefreshToken(ctx context.Context, t *session.RefreshToken) error {
+	return dbFor(ctx, r.db).Create(t).Error
+}
+
+func (r *sessionRepository) GetRefreshTokenByHash(ctx context.Context, tokenHash string) (*session.RefreshToken, error) {
+	var t session.RefreshToken
+	err := dbFor(ctx, r.db).Where("token_hash = ?", tokenHash).First(&t).Error
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func (r *sessionRepository) MarkRefreshTokenUsed(ctx context.Context, id uint64) (bool, error) {
+	result := dbFor(ctx, r.db).Model(&session.RefreshToken{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// revoke marks every still-active session matching the condition as revoked
+func (r *sessionRepository) revoke(ctx context.Context, reason string, query string, args ...interface{}) error {
+	return dbFor(ctx, r.db).Model(&session.Session{}).
+		Where(query, args...).
+		Where("revoked_at IS NULL").
+		Updates(map[string]interface{}{
+			"revoked_at":     time.Now(),
+			"revoked_reason": reason,
+		}).Error
+}
@@ -7,6 +7,7 @@ import (
 	"my-go-driver/internal/domain/shift"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type shiftRepository struct {
@@ -22,7 +23,7 @@ func (r *shiftRepository) GetByDriverID(ctx context.Context, driverID uint64, qu
 	var shifts []shift.DriverShift
 	var total int64
 
-	db := r.db.WithContext(ctx).Model(&shift.DriverShift{}).Where("driver_id = ?", driverID)
+	db := dbFor(ctx, r.db).Model(&shift.DriverShift{}).Where("driver_id = ?", driverID)
 
 	// Apply filters
 	if query.CompanyID > 0 {
@@ -66,3 +67,33 @@ func (r *shiftRepository) GetByDriverID(ctx context.Context, driverID uint64, qu
 
 	return shifts, total, err
 }
+
+func (r *shiftRepository) Create(ctx context.Context, sh *shift.DriverShift) error {
+	return dbFor(ctx, r.db).Create(sh).Error
+}
+
+func (r *shiftRepository) GetOngoing(ctx context.Context, driverID uint64) (*shift.DriverShift, error) {
+	var sh shift.DriverShift
+	err := dbFor(ctx, r.db).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("driver_id = ? AND status = ?", driverID, shift.ShiftStatusOngoing).
+		First(&sh).Error
+	if err != nil {
+		return nil, err
+	}
+	return &sh, nil
+}
+
+func (r *shiftRepository) Update(ctx context.Context, sh *shift.DriverShift) error {
+	return dbFor(ctx, r.db).Save(sh).Error
+}
+
+func (r *shiftRepository) SumVehicleDistance(ctx context.Context, vehicleID uint64, since time.Time) (float64, error) {
+	var total float64
+	err := dbFor(ctx, r.db).Model(&shift.DriverShift{}).
+		Select("COALESCE(SUM(total_distance), 0)").
+		Where("vehicle_id = ? AND start_time >= ?", vehicleID, since).
+		Where("status IN ?", []shift.ShiftStatus{shift.ShiftStatusOngoing, shift.ShiftStatusCompleted}).
+		Scan(&total).Error
+	return total, err
+}
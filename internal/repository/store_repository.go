@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+
+	"my-go-driver/internal/domain/store"
+
+	"gorm.io/gorm"
+)
+
+type storeRepository struct {
+	db *gorm.DB
+}
+
+// NewStoreRepository creates a new store repository
+func NewStoreRepository(db *gorm.DB) store.Repository {
+	return &storeRepository{db: db}
+}
+
+func (r *storeRepository) Create(ctx context.Context, s *store.Store) error {
+	return dbFor(ctx, r.db).Create(s).Error
+}
+
+func (r *storeRepository) GetByID(ctx context.Context, id uint64) (*store.Store, error) {
+	var s store.Store
+	err := dbFor(ctx, r.db).First(&s, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (r *storeRepository) Update(ctx context.Context, s *store.Store) error {
+	return dbFor(ctx, r.db).Save(s).Error
+}
+
+func (r *storeRepository) Delete(ctx context.Context, id uint64) error {
+	return dbFor(ctx, r.db).Delete(&store.Store{}, id).Error
+}
+
+func (r *storeRepository) HasOrders(ctx context.Context, id uint64) (bool, error) {
+	var count int64
+	err := dbFor(ctx, r.db).Table("orders").Where("store_id = ?", id).Count(&count).Error
+	return count > 0, err
+}
+
+func (r *storeRepository) List(ctx context.Context, query store.ListStoresQuery) ([]store.Store, int64, error) {
+	var stores []store.Store
+	var total int64
+
+	db := dbFor(ctx, r.db).Model(&store.Store{})
+
+	// Apply filters
+	if query.CompanyID > 0 {
+		db = db.Where("company_id = ?", query.CompanyID)
+	}
+
+	if query.Status != "" {
+		db = db.Where("status = ?", query.Status)
+	}
+
+	if query.Search != "" {
+		searchPattern := "%" + query.Search + "%"
+		db = db.Where("name LIKE ? OR address LIKE ? OR phone LIKE ?", searchPattern, searchPattern, searchPattern)
+	}
+
+	// Get total count
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Set defaults
+	if query.Page <= 0 {
+		query.Page = 1
+	}
+	if query.Limit <= 0 {
+		query.Limit = 10
+	}
+
+	// Apply pagination
+	offset := (query.Page - 1) * query.Limit
+	err := db.Offset(offset).Limit(query.Limit).Order("name").Find(&stores).Error
+
+	return stores, total, err
+}
+
+func (r *storeRepository) UpdateStatus(ctx context.Context, id uint64, status store.StoreStatus) error {
+	return dbFor(ctx, r.db).Model(&store.Store{}).Where("id = ?", id).Update("status", status).Error
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"my-go-driver/pkg/throttle"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// loginThrottle is a row of the login_throttle table
+type loginThrottle struct {
+	ThrottleKey   string    `gorm:"primaryKey;size:255"`
+	Failures      int       `gorm:"not null"`
+	LastFailureAt time.Time `gorm:"not null"`
+	LockedUntil   *time.Time
+	UpdatedAt     time.Time
+}
+
+func (loginThrottle) TableName() string {
+	return "login_throttle"
+}
+
+type throttleStore struct {
+	db *gorm.DB
+}
+
+// NewThrottleStore creates a throttle store backed by the login_throttle
+// table, shared by every API instance using the database
+func NewThrottleStore(db *gorm.DB) throttle.Store {
+	return &throttleStore{db: db}
+}
+
+func (s *throttleStore) Get(ctx context.Context, key string) (throttle.State, error) {
+	var row loginThrottle
+	err := s.db.WithContext(ctx).Where("throttle_key = ?", key).First(&row).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return throttle.State{}, nil
+		}
+		return throttle.State{}, err
+	}
+	return row.state(), nil
+}
+
+func (s *throttleStore) Fail(ctx context.Context, key string, policy throttle.Policy, now time.Time) (throttle.State, error) {
+	var state throttle.State
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var row loginThrottle
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("throttle_key = ?", key).
+			First(&row).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		state = policy.Fail(row.state(), now)
+
+		next := loginThrottle{
+			ThrottleKey:   key,
+			Failures:      state.Failures,
+			LastFailureAt: state.LastFailure,
+		}
+		if !state.LockedUntil.IsZero() {
+			next.LockedUntil = &state.LockedUntil
+		}
+		return tx.Clauses(clause.OnConflict{UpdateAll: true}).Create(&next).Error
+	})
+	return state, err
+}
+
+func (s *throttleStore) Reset(ctx context.Context, key string) error {
+	return s.db.WithContext(ctx).Where("throttle_key = ?", key).Delete(&loginThrottle{}).Error
+}
+
+func (row loginThrottle) state() throttle.State {
+	state := throttle.State{
+		Failures:    row.Failures,
+		LastFailure: row.LastFailureAt,
+	}
+	if row.LockedUntil != nil {
+		state.LockedUntil = *row.LockedUntil
+	}
+	return state
+}
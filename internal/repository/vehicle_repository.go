@@ -0,0 +1,241 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"my-go-driver/internal/domain/vehicle"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type vehicleRepository struct {
+	db *gorm.DB
+}
+
+// NewVehicleRepository creates a new vehicle repository
+func NewVehicleRepository(db *gorm.DB) vehicle.Repository {
+	return &vehicleRepository{db: db}
+}
+
+func (r *vehicleRepository) Create(ctx context.Context, v *vehicle.Vehicle) error {
+	return dbFor(ctx, r.db).Create(v).Error
+}
+
+func (r *vehicleRepository) GetByID(ctx context.Context, id uint64) (*vehicle.Vehicle, error) {
+	var v vehicle.Vehicle
+	err := dbFor(ctx, r.db).First(&v, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+func (r *vehicleRepository) GetByIDForUpdate(ctx context.Context, id uint64) (*vehicle.Vehicle, error) {
+	var v vehicle.Vehicle
+	err := dbFor(ctx, r.db).Clauses(clause.Locking{Strength: "UPDATE"}).First(&v, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+func (r *vehicleRepository) GetByPlate(ctx context.Context, plateNumber string, companyID uint64) (*vehicle.Vehicle, error) {
+	var v vehicle.Vehicle
+	err := dbFor(ctx, r.db).Where("plate_number = ? AND company_id = ?", plateNumber, companyID).First(&v).Error
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+func (r *vehicleRepository) Update(ctx context.Context, v *vehicle.Vehicle) error {
+	return dbFor(ctx, r.db).Save(v).Error
+}
+
+func (r *vehicleRepository) Delete(ctx context.Context, id uint64) error {
+	return dbFor(ctx, r.db).Delete(&vehicle.Vehicle{}, id).Error
+}
+
+func (r *vehicleRepository) HasHistory(ctx context.Context, id uint64) (bool, error) {
+	for _, table := range []string{"driver_vehicle_assignments", "driver_shifts", "vehicle_maintenance_records"} {
+		var count int64
+		if err := dbFor(ctx, r.db).Table(table).Where("vehicle_id = ?", id).Count(&count).Error; err != nil {
+			return false, err
+		}
+		if count > 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *vehicleRepository) List(ctx context.Context, query vehicle.ListVehiclesQuery) ([]vehicle.Vehicle, int64, error) {
+	var vehicles []vehicle.Vehicle
+	var total int64
+
+	db := dbFor(ctx, r.db).Model(&vehicle.Vehicle{})
+
+	// Apply filters
+	if query.CompanyID > 0 {
+		db = db.Where("company_id = ?", query.CompanyID)
+	}
+
+	if query.StoreID > 0 {
+		db = db.Where("store_id = ?", query.StoreID)
+	}
+
+	if query.Type != "" {
+		db = db.Where("type = ?", query.Type)
+	}
+
+	if query.Status != "" {
+		db = db.Where("status = ?", query.Status)
+	}
+
+	if query.MaintenanceDue != nil {
+		if *query.MaintenanceDue {
+			db = db.Where("JSON_LENGTH(maintenance_due) > 0")
+		} else {
+			db = db.Where("maintenance_due IS NULL OR JSON_LENGTH(maintenance_due) = 0")
+		}
+	}
+
+	if query.Search != "" {
+		searchPattern := "%" + query.Search + "%"
+		db = db.Where("plate_number LIKE ? OR fuel_type LIKE ?", searchPattern, searchPattern)
+	}
+
+	// Get total count
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Set defaults
+	if query.Page <= 0 {
+		query.Page = 1
+	}
+	if query.Limit <= 0 {
+		query.Limit = 10
+	}
+
+	// Apply pagination
+	offset := (query.Page - 1) * query.Limit
+	err := db.Offset(offset).Limit(query.Limit).Order("plate_number").Find(&vehicles).Error
+
+	return vehicles, total, err
+}
+
+func (r *vehicleRepository) UpdateStatus(ctx context.Context, id uint64, status vehicle.VehicleStatus) error {
+	return dbFor(ctx, r.db).Model(&vehicle.Vehicle{}).Where("id = ?", id).Update("status", status).Error
+}
+
+func (r *vehicleRepository) ListByCompany(ctx context.Context, companyID uint64) ([]vehicle.Vehicle, error) {
+	var vehicles []vehicle.Vehicle
+	err := dbFor(ctx, r.db).
+		Where("company_id = ? AND status <> ?", companyID, vehicle.VehicleStatusOutOfService).
+		Order("id").
+		Find(&vehicles).Error
+	return vehicles, err
+}
+
+func (r *vehicleRepository) SetMaintenanceDue(ctx context.Context, id uint64, due vehicle.ServiceTypes) error {
+	return dbFor(ctx, r.db).Model(&vehicle.Vehicle{}).Where("id = ?", id).Update("maintenance_due", due).Error
+}
+
+func (r *vehicleRepository) UpdateLastOilChange(ctx context.Context, id uint64, date time.Time) error {
+	return dbFor(ctx, r.db).Model(&vehicle.Vehicle{}).Where("id = ?", id).Update("last_oil_change", date).Error
+}
+
+func (r *vehicleRepository) CreateAssignment(ctx context.Context, a *vehicle.Assignment) error {
+	return dbFor(ctx, r.db).Create(a).Error
+}
+
+func (r *vehicleRepository) GetActiveAssignmentByVehicle(ctx context.Context, vehicleID uint64) (*vehicle.Assignment, error) {
+	return r.activeAssignment(ctx, "vehicle_id = ?", vehicleID)
+}
+
+func (r *vehicleRepository) GetActiveAssignmentByDriver(ctx context.Context, driverID uint64) (*vehicle.Assignment, error) {
+	return r.activeAssignment(ctx, "driver_id = ?", driverID)
+}
+
+func (r *vehicleRepository) activeAssignment(ctx context.Context, condition string, id uint64) (*vehicle.Assignment, error) {
+	var a vehicle.Assignment
+	err := dbFor(ctx, r.db).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where(condition, id).
+		Where("is_active = ?", true).
+		First(&a).Error
+	if err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+func (r *vehicleRepository) EndAssignment(ctx context.Context, id uint64, at time.Time) error {
+	return dbFor(ctx, r.db).Model(&vehicle.Assignment{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"is_active":     false,
+		"unassigned_at": at,
+	}).Error
+}
+
+func (r *vehicleRepository) ListAssignments(ctx context.Context, query vehicle.ListAssignmentsQuery) ([]vehicle.Assignment, int64, error) {
+	var assignments []vehicle.Assignment
+	var total int64
+
+	db := dbFor(ctx, r.db).Model(&vehicle.Assignment{})
+
+	// Apply filters
+	if query.DriverID > 0 {
+		db = db.Where("driver_id = ?", query.DriverID)
+	}
+
+	if query.VehicleID > 0 {
+		db = db.Where("vehicle_id = ?", query.VehicleID)
+	}
+
+	if query.Active != nil {
+		db = db.Where("is_active = ?", *query.Active)
+	}
+
+	// Get total count
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Set defaults
+	if query.Page <= 0 {
+		query.Page = 1
+	}
+	if query.Limit <= 0 {
+		query.Limit = 10
+	}
+
+	// Apply pagination
+	offset := (query.Page - 1) * query.Limit
+	err := db.Offset(offset).Limit(query.Limit).Order("assigned_at DESC, id DESC").Find(&assignments).Error
+
+	return assignments, total, err
+}
+
+func (r *vehicleRepository) FindAvailable(ctx context.Context, companyID uint64, storeID *uint64) (*vehicle.Vehicle, error) {
+	var v vehicle.Vehicle
+
+	db := dbFor(ctx, r.db).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("company_id = ? AND status = ?", companyID, vehicle.VehicleStatusActive).
+		Where("NOT EXISTS (SELECT 1 FROM driver_vehicle_assignments a WHERE a.vehicle_id = vehicles.id AND a.is_active = ?)", true)
+
+	if storeID != nil {
+		db = db.Where("store_id = ?", *storeID)
+	} else {
+		db = db.Where("store_id IS NULL")
+	}
+
+	err := db.Order("id").First(&v).Error
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
@@ -1,8 +1,17 @@
 package router
 
 import (
+	"my-go-driver/internal/domain/apikey"
+	"my-go-driver/internal/domain/company"
+	"my-go-driver/internal/domain/driver"
+	"my-go-driver/internal/domain/module"
+	"my-go-driver/internal/domain/platform"
+	"my-go-driver/internal/domain/ratelimit"
+	"my-go-driver/internal/domain/rbac"
+	"my-go-driver/internal/domain/session"
 	"my-go-driver/internal/handler"
 	"my-go-driver/internal/middleware"
+	"my-go-driver/pkg/jwt"
 	"my-go-driver/pkg/logger"
 
 	"github.com/gin-gonic/gin"
@@ -12,10 +21,32 @@ import (
 func SetupRoutes(
 	r *gin.Engine,
 	log *logger.Logger,
-	jwtSecret string,
+	tokenService *jwt.Service,
+	companyService company.Service,
+	driverService driver.Service,
+	platformService platform.Service,
+	rbacService rbac.Service,
+	sessionService session.Service,
+	apiKeyService apikey.Service,
+	rateLimitService ratelimit.Service,
+	moduleService module.Service,
 	adminCompanyHandler *handler.AdminCompanyHandler,
 	adminDriverHandler *handler.AdminDriverHandler,
 	adminModuleHandler *handler.AdminModuleHandler,
+	adminStoreHandler *handler.AdminStoreHandler,
+	adminVehicleHandler *handler.AdminVehicleHandler,
+	adminMaintenanceHandler *handler.AdminMaintenanceHandler,
+	adminClientHandler *handler.AdminClientHandler,
+	platformAuthHandler *handler.PlatformAuthHandler,
+	adminRoleHandler *handler.AdminRoleHandler,
+	driverHandler *handler.DriverHandler,
+	sessionHandler *handler.SessionHandler,
+	jwksHandler *handler.JWKSHandler,
+	adminAccountHandler *handler.AdminAccountHandler,
+	adminMFAHandler *handler.AdminMFAHandler,
+	adminAPIKeyHandler *handler.AdminAPIKeyHandler,
+	adminNotificationHandler *handler.AdminNotificationHandler,
+	planHandler *handler.PlanHandler,
 ) {
 	// Global middleware
 	r.Use(middleware.Logger(log))
@@ -30,35 +61,43 @@ func SetupRoutes(
 		})
 	})
 
+	// Public token verification keys for other services
+	r.GET("/.well-known/jwks.json", jwksHandler.GetJWKS)
+
 	// API v1 routes
 	v1 := r.Group("/api/v1")
 	{
-
-		// Admin routes
-		admin := v1.Group("/admin")
+		// Platform operator routes (tenant, plan and module entitlement management)
+		platformGroup := v1.Group("/platform")
 		{
-			// Public admin authentication routes
-			adminAuth := admin.Group("/auth")
+			// Public platform authentication routes
+			platformAuth := platformGroup.Group("/auth")
 			{
-				adminAuth.POST("/login", adminCompanyHandler.LoginAdmin)
+				platformAuth.POST("/login", platformAuthHandler.Login)
+				platformAuth.POST("/refresh", sessionHandler.RefreshPlatform)
 			}
 
-			// Public company creation (for initial setup)
-			admin.POST("/companies", adminCompanyHandler.CreateCompany)
-
-			// Protected admin routes (require authentication)
-			protected := admin.Group("")
-			protected.Use(middleware.AdminAuth(jwtSecret))
+			// Protected platform routes (require platform operator authentication)
+			protected := platformGroup.Group("")
+			protected.Use(middleware.PlatformAuth(tokenService, platformService, sessionService))
 			{
-				// Admin profile
-				protected.GET("/auth/me", adminCompanyHandler.GetAdminProfile)
+				// Operator profile
+				protected.GET("/auth/me", platformAuthHandler.GetProfile)
+				protected.POST("/auth/logout", sessionHandler.Logout)
 
-				// Admin management
+				// Company admin management for any tenant
 				protected.POST("/admins", adminCompanyHandler.CreateAdmin)
+				protected.POST("/admins/invite", adminAccountHandler.InviteAdmin)
+				protected.PUT("/admins/:id/deactivate", adminCompanyHandler.DeactivateAdmin)
+				protected.PUT("/admins/:id/activate", adminCompanyHandler.ActivateAdmin)
 
-				// Company management
+				// Driver reassignment between tenants
+				protected.PUT("/drivers/:id/assign-company", adminDriverHandler.AssignDriverToCompany)
+
+				// Tenant management
 				companies := protected.Group("/companies")
 				{
+					companies.POST("", adminCompanyHandler.CreateCompany)
 					companies.GET("", adminCompanyHandler.ListCompanies)
 					companies.GET("/:id", adminCompanyHandler.GetCompany)
 					companies.PUT("/:id", adminCompanyHandler.UpdateCompany)
@@ -67,31 +106,229 @@ func SetupRoutes(
 					companies.PUT("/:id/suspend", adminCompanyHandler.SuspendCompany)
 					companies.PUT("/:id/activate", adminCompanyHandler.ActivateCompany)
 
-					// Company modules
+					// Module entitlements
 					companies.POST("/:id/modules", adminModuleHandler.AssignModuleToCompany)
 					companies.GET("/:id/modules", adminModuleHandler.GetCompanyModules)
+					companies.PUT("/:id/modules", adminModuleHandler.SetCompanyModules)
+					companies.PATCH("/:id/modules/:module_id", adminModuleHandler.UpdateModuleConfig)
 					companies.DELETE("/:id/modules/:module_id", adminModuleHandler.RemoveModuleFromCompany)
+					companies.GET("/:id/stores/:store_id/modules", adminModuleHandler.GetStoreModules)
+					companies.PUT("/:id/stores/:store_id/modules/:module_id", adminModuleHandler.SetStoreModule)
+					companies.DELETE("/:id/stores/:store_id/modules/:module_id", adminModuleHandler.RemoveStoreModule)
+				}
+
+				// Modules
+				modules := protected.Group("/modules")
+				{
+					modules.GET("", adminModuleHandler.ListAllModules)
+				}
+
+				// Plan catalog
+				protected.GET("/plans", planHandler.ListPlans)
+			}
+		}
+
+		// Driver routes (driver mobile app, scoped to the driver's company)
+		driverGroup := v1.Group("/driver")
+		{
+			// Public driver authentication routes
+			driverAuth := driverGroup.Group("/auth")
+			{
+				driverAuth.POST("/login", driverHandler.Login)
+				driverAuth.POST("/refresh", sessionHandler.RefreshDriver)
+			}
+
+			// Protected driver routes (require driver authentication)
+			protected := driverGroup.Group("")
+			protected.Use(
+				middleware.DriverAuth(tokenService, driverService, companyService, sessionService),
+				middleware.RateLimit(rateLimitService),
+			)
+			{
+				protected.POST("/auth/logout", sessionHandler.Logout)
+				protected.GET("/me", driverHandler.GetProfile)
+				protected.PUT("/me", driverHandler.UpdateProfile)
+				protected.PUT("/me/online", driverHandler.GoOnline)
+				protected.PUT("/me/offline", driverHandler.GoOffline)
+
+				// Shifts
+				shifts := protected.Group("/shifts", middleware.RequireModule(moduleService, module.KeyShiftManagement))
+				{
+					shifts.POST("/start", driverHandler.StartShift)
+					shifts.POST("/end", driverHandler.EndShift)
+				}
+			}
+		}
+
+		// Admin routes (company admins, scoped to their own company)
+		admin := v1.Group("/admin")
+		{
+			// Public admin authentication routes
+			adminAuth := admin.Group("/auth")
+			{
+				adminAuth.POST("/login", adminCompanyHandler.LoginAdmin)
+				adminAuth.POST("/login/mfa", adminCompanyHandler.VerifyMFALogin)
+				adminAuth.POST("/login/mfa/enroll", adminMFAHandler.EnrollWithChallenge)
+				adminAuth.POST("/refresh", sessionHandler.RefreshAdmin)
+				adminAuth.POST("/accept-invite", adminAccountHandler.AcceptInvite)
+				adminAuth.POST("/forgot-password", adminAccountHandler.ForgotPassword)
+				adminAuth.POST("/reset-password", adminAccountHandler.ResetPassword)
+				adminAuth.POST("/verify-email", adminAccountHandler.VerifyEmail)
+			}
+
+			// Protected admin routes (require an admin token or an API key)
+			protected := admin.Group("")
+			protected.Use(
+				middleware.APIKeyAuth(apiKeyService),
+				middleware.AdminAuth(tokenService, companyService, sessionService),
+				middleware.RateLimit(rateLimitService),
+				middleware.LoadPermissions(rbacService),
+			)
+			{
+				// Admin profile
+				protected.GET("/auth/me", adminCompanyHandler.GetAdminProfile)
+				protected.POST("/auth/logout", sessionHandler.Logout)
+				protected.POST("/auth/verify-email/send", adminAccountHandler.SendEmailVerification)
+
+				// Two-factor authentication
+				protected.GET("/auth/mfa", adminMFAHandler.GetStatus)
+				protected.POST("/auth/mfa/enroll", adminMFAHandler.Enroll)
+				protected.POST("/auth/mfa/confirm", adminMFAHandler.Confirm)
+				protected.POST("/auth/mfa/disable", adminMFAHandler.Disable)
+				protected.POST("/auth/mfa/recovery-codes", adminMFAHandler.RegenerateRecoveryCodes)
+
+				// Admin management
+				protected.POST("/admins", middleware.RequirePermission(rbac.PermAdminsWrite), adminCompanyHandler.CreateAdmin)
+				protected.POST("/admins/invite", middleware.RequirePermission(rbac.PermAdminsWrite), adminAccountHandler.InviteAdmin)
+				protected.PUT("/admins/:id/role", middleware.RequirePermission(rbac.PermRolesManage), adminRoleHandler.AssignAdminRole)
+				protected.PUT("/admins/:id/deactivate", middleware.RequirePermission(rbac.PermAdminsWrite), adminCompanyHandler.DeactivateAdmin)
+				protected.PUT("/admins/:id/activate", middleware.RequirePermission(rbac.PermAdminsWrite), adminCompanyHandler.ActivateAdmin)
+
+				// API keys
+				apiKeys := protected.Group("/api-keys",
+					middleware.RequireModule(moduleService, module.KeyAPIAccess),
+					middleware.RequirePermission(rbac.PermAPIKeysManage),
+				)
+				{
+					apiKeys.GET("", adminAPIKeyHandler.ListKeys)
+					apiKeys.POST("", adminAPIKeyHandler.CreateKey)
+					apiKeys.DELETE("/:id", adminAPIKeyHandler.RevokeKey)
+				}
+
+				// Notifications
+				notifications := protected.Group("/notifications",
+					middleware.RequireModule(moduleService, module.KeyNotifications),
+					middleware.RequirePermission(rbac.PermNotificationsRead),
+				)
+				{
+					notifications.GET("", adminNotificationHandler.ListNotifications)
+					notifications.PUT("/:id/read", adminNotificationHandler.MarkRead)
+				}
+
+				// Roles & permissions
+				protected.GET("/permissions", middleware.RequirePermission(rbac.PermRolesManage), adminRoleHandler.ListPermissions)
+				roles := protected.Group("/roles", middleware.RequirePermission(rbac.PermRolesManage))
+				{
+					roles.GET("", adminRoleHandler.ListRoles)
+					roles.POST("", adminRoleHandler.CreateRole)
+					roles.PUT("/:id", adminRoleHandler.UpdateRole)
+					roles.DELETE("/:id", adminRoleHandler.DeleteRole)
+				}
+
+				// Company management
+				companies := protected.Group("/companies")
+				{
+					companies.GET("/:id", middleware.RequirePermission(rbac.PermCompaniesRead), adminCompanyHandler.GetCompany)
+					companies.PUT("/:id", middleware.RequirePermission(rbac.PermCompaniesWrite), adminCompanyHandler.UpdateCompany)
+					companies.PUT("/:id/branding", middleware.RequirePermission(rbac.PermCompaniesWrite), adminCompanyHandler.UpdateBranding)
+
+					// Company modules
+					companies.GET("/:id/modules", middleware.RequirePermission(rbac.PermModulesRead), adminModuleHandler.GetCompanyModules)
+					companies.PATCH("/:id/modules/:module_id", middleware.RequirePermission(rbac.PermModulesAssign), adminModuleHandler.UpdateModuleConfig)
+					companies.GET("/:id/stores/:store_id/modules", middleware.RequirePermission(rbac.PermModulesRead), adminModuleHandler.GetStoreModules)
+					companies.PUT("/:id/stores/:store_id/modules/:module_id", middleware.RequirePermission(rbac.PermModulesAssign), adminModuleHandler.SetStoreModule)
+					companies.DELETE("/:id/stores/:store_id/modules/:module_id", middleware.RequirePermission(rbac.PermModulesAssign), adminModuleHandler.RemoveStoreModule)
+				}
+
+				// Store management
+				stores := protected.Group("/stores", middleware.RequireModule(moduleService, module.KeyStoreManagement))
+				{
+					stores.POST("", middleware.RequirePermission(rbac.PermStoresWrite), adminStoreHandler.CreateStore)
+					stores.GET("", middleware.RequirePermission(rbac.PermStoresRead), adminStoreHandler.ListStores)
+					stores.GET("/:id", middleware.RequirePermission(rbac.PermStoresRead), adminStoreHandler.GetStore)
+					stores.PUT("/:id", middleware.RequirePermission(rbac.PermStoresWrite), adminStoreHandler.UpdateStore)
+					stores.DELETE("/:id", middleware.RequirePermission(rbac.PermStoresWrite), adminStoreHandler.DeleteStore)
+					stores.PUT("/:id/activate", middleware.RequirePermission(rbac.PermStoresWrite), adminStoreHandler.ActivateStore)
+					stores.PUT("/:id/deactivate", middleware.RequirePermission(rbac.PermStoresWrite), adminStoreHandler.DeactivateStore)
+				}
+
+				// Vehicle management
+				vehicles := protected.Group("/vehicles", middleware.RequireModule(moduleService, module.KeyVehicleManagement))
+				{
+					vehicles.POST("", middleware.RequirePermission(rbac.PermVehiclesWrite), adminVehicleHandler.CreateVehicle)
+					vehicles.GET("", middleware.RequirePermission(rbac.PermVehiclesRead), adminVehicleHandler.ListVehicles)
+					vehicles.GET("/:id", middleware.RequirePermission(rbac.PermVehiclesRead), adminVehicleHandler.GetVehicle)
+					vehicles.PUT("/:id", middleware.RequirePermission(rbac.PermVehiclesWrite), adminVehicleHandler.UpdateVehicle)
+					vehicles.DELETE("/:id", middleware.RequirePermission(rbac.PermVehiclesWrite), adminVehicleHandler.DeleteVehicle)
+					vehicles.PUT("/:id/status", middleware.RequirePermission(rbac.PermVehiclesWrite), adminVehicleHandler.ChangeVehicleStatus)
+					vehicles.POST("/:id/assign", middleware.RequirePermission(rbac.PermVehiclesWrite), adminVehicleHandler.AssignDriver)
+					vehicles.PUT("/:id/unassign", middleware.RequirePermission(rbac.PermVehiclesWrite), adminVehicleHandler.UnassignDriver)
+					vehicles.GET("/:id/assignments", middleware.RequirePermission(rbac.PermVehiclesRead), adminVehicleHandler.GetVehicleAssignments)
+
+					// Maintenance
+					vehicles.POST("/maintenance-rules", middleware.RequirePermission(rbac.PermVehiclesWrite), adminMaintenanceHandler.CreateRule)
+					vehicles.GET("/maintenance-rules", middleware.RequirePermission(rbac.PermVehiclesRead), adminMaintenanceHandler.ListRules)
+					vehicles.PUT("/maintenance-rules/:rule_id", middleware.RequirePermission(rbac.PermVehiclesWrite), adminMaintenanceHandler.UpdateRule)
+					vehicles.DELETE("/maintenance-rules/:rule_id", middleware.RequirePermission(rbac.PermVehiclesWrite), adminMaintenanceHandler.DeleteRule)
+					vehicles.POST("/maintenance-check", middleware.RequirePermission(rbac.PermVehiclesWrite), adminMaintenanceHandler.RunCheck)
+					vehicles.POST("/:id/maintenance", middleware.RequirePermission(rbac.PermVehiclesWrite), adminMaintenanceHandler.RecordService)
+					vehicles.GET("/:id/maintenance", middleware.RequirePermission(rbac.PermVehiclesRead), adminMaintenanceHandler.ListRecords)
+					vehicles.GET("/:id/maintenance/schedule", middleware.RequirePermission(rbac.PermVehiclesRead), adminMaintenanceHandler.GetSchedule)
+				}
+
+				// Client management
+				clients := protected.Group("/clients", middleware.RequireModule(moduleService, module.KeyClientManagement))
+				{
+					clients.POST("", middleware.RequirePermission(rbac.PermClientsWrite), adminClientHandler.CreateClient)
+					clients.GET("", middleware.RequirePermission(rbac.PermClientsRead), adminClientHandler.ListClients)
+					clients.GET("/:id", middleware.RequirePermission(rbac.PermClientsRead), adminClientHandler.GetClient)
+					clients.PUT("/:id", middleware.RequirePermission(rbac.PermClientsWrite), adminClientHandler.UpdateClient)
+					clients.DELETE("/:id", middleware.RequirePermission(rbac.PermClientsWrite), adminClientHandler.DeleteClient)
+					clients.POST("/:id/merge", middleware.RequirePermission(rbac.PermClientsWrite), adminClientHandler.MergeClients)
+					clients.POST("/:id/addresses", middleware.RequirePermission(rbac.PermClientsWrite), adminClientHandler.AddAddress)
+					clients.GET("/:id/addresses", middleware.RequirePermission(rbac.PermClientsRead), adminClientHandler.ListAddresses)
+					clients.PUT("/:id/addresses/:address_id", middleware.RequirePermission(rbac.PermClientsWrite), adminClientHandler.UpdateAddress)
+					clients.DELETE("/:id/addresses/:address_id", middleware.RequirePermission(rbac.PermClientsWrite), adminClientHandler.DeleteAddress)
 				}
 
 				// Driver management
-				drivers := protected.Group("/drivers")
+				drivers := protected.Group("/drivers", middleware.RequireModule(moduleService, module.KeyDriverManagement))
 				{
-					drivers.POST("", adminDriverHandler.CreateDriver)
-					drivers.GET("", adminDriverHandler.ListDrivers)
-					drivers.GET("/:id", adminDriverHandler.GetDriver)
-					drivers.PUT("/:id", adminDriverHandler.UpdateDriver)
-					drivers.DELETE("/:id", adminDriverHandler.DeleteDriver)
-					drivers.PUT("/:id/assign-company", adminDriverHandler.AssignDriverToCompany)
-					drivers.PUT("/:id/block", adminDriverHandler.BlockDriver)
-					drivers.PUT("/:id/unblock", adminDriverHandler.UnblockDriver)
-					drivers.GET("/:id/performance", adminDriverHandler.GetDriverPerformance)
-					drivers.GET("/:id/shifts", adminDriverHandler.GetDriverShifts)
+					drivers.POST("", middleware.RequirePermission(rbac.PermDriversWrite), adminDriverHandler.CreateDriver)
+					drivers.GET("", middleware.RequirePermission(rbac.PermDriversRead), adminDriverHandler.ListDrivers)
+					drivers.GET("/:id", middleware.RequirePermission(rbac.PermDriversRead), adminDriverHandler.GetDriver)
+					drivers.PUT("/:id", middleware.RequirePermission(rbac.PermDriversWrite), adminDriverHandler.UpdateDriver)
+					drivers.DELETE("/:id", middleware.RequirePermission(rbac.PermDriversWrite), adminDriverHandler.DeleteDriver)
+					drivers.PUT("/:id/assign-company", middleware.RequirePermission(rbac.PermDriversWrite), adminDriverHandler.AssignDriverToCompany)
+					drivers.PUT("/:id/block", middleware.RequirePermission(rbac.PermDriversWrite), adminDriverHandler.BlockDriver)
+					drivers.PUT("/:id/unblock", middleware.RequirePermission(rbac.PermDriversWrite), adminDriverHandler.UnblockDriver)
+					drivers.GET("/:id/performance", middleware.RequirePermission(rbac.PermDriversRead), adminDriverHandler.GetDriverPerformance)
+					drivers.GET("/:id/shifts",
+						middleware.RequireModule(moduleService, module.KeyShiftManagement),
+						middleware.RequirePermission(rbac.PermShiftsRead),
+						adminDriverHandler.GetDriverShifts,
+					)
+					drivers.GET("/:id/vehicle-assignments",
+						middleware.RequireModule(moduleService, module.KeyVehicleManagement),
+						middleware.RequirePermission(rbac.PermVehiclesRead),
+						adminVehicleHandler.GetDriverAssignments,
+					)
 				}
 
 				// Modules
 				modules := protected.Group("/modules")
 				{
-					modules.GET("", adminModuleHandler.ListAllModules)
+					modules.GET("", middleware.RequirePermission(rbac.PermModulesRead), adminModuleHandler.ListAllModules)
 				}
 			}
 		}
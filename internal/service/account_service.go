@@ -0,0 +1,358 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"my-go-driver/internal/domain/account"
+	"my-go-driver/internal/domain/company"
+	"my-go-driver/internal/domain/quota"
+	"my-go-driver/internal/domain/rbac"
+	"my-go-driver/internal/domain/session"
+	"my-go-driver/internal/transaction"
+	"my-go-driver/pkg/hash"
+	"my-go-driver/pkg/jwt"
+	"my-go-driver/pkg/mail"
+	"my-go-driver/pkg/throttle"
+
+	"gorm.io/gorm"
+)
+
+type accountService struct {
+	repo        account.Repository
+	companyRepo company.Repository
+	companies   company.Service
+	sessions    session.Service
+	quotas      quota.Service
+	mailer      mail.Sender
+	tx          transaction.Transactor
+	linkBaseURL string
+	resetEmails *throttle.Limiter
+	resetIPs    *throttle.Limiter
+}
+
+// NewAccountService creates a new admin account service. Links in emails are
+// built from linkBaseURL, the base URL of the admin dashboard. Password reset
+// requests are counted per email by resetEmails and per client IP address by
+// resetIPs.
+func NewAccountService(repo account.Repository, companyRepo company.Repository, companies company.Service, sessions session.Service, quotas quota.Service, mailer mail.Sender, tx transaction.Transactor, linkBaseURL string, resetEmails, resetIPs *throttle.Limiter) account.Service {
+	return &accountService{
+		repo:        repo,
+		companyRepo: companyRepo,
+		companies:   companies,
+		sessions:    sessions,
+		quotas:      quotas,
+		mailer:      mailer,
+		tx:          tx,
+		linkBaseURL: strings.TrimRight(linkBaseURL, "/"),
+		resetEmails: resetEmails,
+		resetIPs:    resetIPs,
+	}
+}
+
+func (s *accountService) InviteAdmin(ctx context.Context, req account.InviteAdminRequest) (*company.CompanyAdminResponse, error) {
+	// Check the company exists and is visible to the caller
+	if _, err := s.companies.GetCompany(ctx, req.CompanyID); err != nil {
+		return nil, err
+	}
+
+	// Only admins who can manage roles may invite further owners
+	if req.Role == company.AdminRoleOwner {
+		if err := rbac.Check(ctx, rbac.PermRolesManage); err != nil {
+			return nil, err
+		}
+	}
+
+	// Check if admin email already exists
+	existingAdmin, err := s.companyRepo.GetAdminByEmail(ctx, req.Email)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("error checking admin email: %w", err)
+	}
+	// Inviting an admin who has not accepted yet sends them a fresh link
+	pending := existingAdmin != nil && existingAdmin.CompanyID == req.CompanyID && existingAdmin.IsActive && existingAdmin.PasswordHash == ""
+	if existingAdmin != nil && !pending {
+		return nil, fmt.Errorf("admin email already exists")
+	}
+
+	// The admin has no password until the invite is accepted, so they cannot log in
+	admin := &company.CompanyAdmin{
+		CompanyID: req.CompanyID,
+		FullName:  req.FullName,
+		Email:     req.Email,
+		Phone:     req.Phone,
+		Role:      req.Role,
+		IsActive:  true,
+	}
+	if pending {
+		admin = existingAdmin
+		admin.FullName = req.FullName
+		admin.Phone = req.Phone
+		admin.Role = req.Role
+	}
+
+	// The admin is only kept along with a token to accept the invite
+	var token string
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if pending {
+			if err := s.companyRepo.UpdateAdmin(ctx, admin); err != nil {
+				return fmt.Errorf("failed to update admin: %w", err)
+			}
+		} else {
+			// Invited admins take up a seat as soon as they are invited
+			if err := s.quotas.CheckSeats(ctx, req.CompanyID, 1); err != nil {
+				return err
+			}
+			if err := s.companyRepo.CreateAdmin(ctx, admin); err != nil {
+				return fmt.Errorf("failed to create admin: %w", err)
+			}
+		}
+
+		var err error
+		token, err = s.issueToken(ctx, admin.ID, account.PurposeInvite)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.mailer.Send(ctx, mail.Message{
+		To:      []string{admin.Email},
+		Subject: "You have been invited to the TWA Driver dashboard",
+		Body: fmt.Sprintf("Hi %s,\n\nYou have been invited to manage your company on the TWA Driver dashboard.\n"+
+			"Set your password to get started:\n\n%s\n\nThis link expires in %s.\n",
+			admin.FullName, s.link("/accept-invite", token), expiryText(account.PurposeInvite.TTL())),
+	}); err != nil {
+		return nil, err
+	}
+
+	return s.companies.GetAdminProfile(ctx, admin.ID)
+}
+
+func (s *accountService) AcceptInvite(ctx context.Context, req account.AcceptInviteRequest) error {
+	admin, err := s.consumeToken(ctx, req.Token, account.PurposeInvite)
+	if err != nil {
+		return err
+	}
+
+	hashedPassword, err := hash.HashPassword(req.Password)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	// Receiving the invite proves ownership of the email address
+	now := time.Now()
+	admin.PasswordHash = hashedPassword
+	admin.EmailVerifiedAt = &now
+
+	if err := s.companyRepo.UpdateAdmin(ctx, admin); err != nil {
+		return fmt.Errorf("failed to accept invite: %w", err)
+	}
+	return nil
+}
+
+func (s *accountService) ForgotPassword(ctx context.Context, req account.ForgotPasswordRequest) error {
+	// Throttled requests succeed silently too, so the response never differs
+	allowed, err := s.allowResetRequest(ctx, req)
+	if err != nil || !allowed {
+		return err
+	}
+
+	admin, err := s.companyRepo.GetAdminByEmail(ctx, req.Email)
+	if err != nil {
+		// Unknown emails succeed silently so the endpoint cannot be used to discover accounts
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	if !admin.IsActive {
+		return nil
+	}
+
+	token, err := s.issueToken(ctx, admin.ID, account.PurposePasswordReset)
+	if err != nil {
+		return err
+	}
+
+	return s.mailer.Send(ctx, mail.Message{
+		To:      []string{admin.Email},
+		Subject: "Reset your TWA Driver password",
+		Body: fmt.Sprintf("Hi %s,\n\nWe received a request to reset your password. Choose a new one here:\n\n%s\n\n"+
+			"This link expires in %s. If you did not request a reset, you can ignore this email.\n",
+			admin.FullName, s.link("/reset-password", token), expiryText(account.PurposePasswordReset.TTL())),
+	})
+}
+
+func (s *accountService) ResetPassword(ctx context.Context, req account.ResetPasswordRequest) error {
+	admin, err := s.consumeToken(ctx, req.Token, account.PurposePasswordReset)
+	if err != nil {
+		return err
+	}
+
+	hashedPassword, err := hash.HashPassword(req.Password)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	admin.PasswordHash = hashedPassword
+	if admin.EmailVerifiedAt == nil {
+		now := time.Now()
+		admin.EmailVerifiedAt = &now
+	}
+
+	if err := s.companyRepo.UpdateAdmin(ctx, admin); err != nil {
+		return fmt.Errorf("failed to reset password: %w", err)
+	}
+
+	// Sign out every device that used the old password
+	return s.sessions.RevokeAll(ctx, jwt.SubjectAdmin, admin.ID, session.RevokeReasonPasswordReset)
+}
+
+func (s *accountService) SendEmailVerification(ctx context.Context, adminID uint64) error {
+	admin, err := s.companyRepo.GetAdminByID(ctx, adminID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return company.ErrAdminNotFound
+		}
+		return err
+	}
+	if admin.EmailVerifiedAt != nil {
+		return account.ErrEmailAlreadyVerified
+	}
+
+	token, err := s.issueToken(ctx, admin.ID, account.PurposeEmailVerification)
+	if err != nil {
+		return err
+	}
+
+	return s.mailer.Send(ctx, mail.Message{
+		To:      []string{admin.Email},
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf("Hi %s,\n\nPlease confirm your email address:\n\n%s\n\nThis link expires in %s.\n",
+			admin.FullName, s.link("/verify-email", token), expiryText(account.PurposeEmailVerification.TTL())),
+	})
+}
+
+func (s *accountService) VerifyEmail(ctx context.Context, req account.VerifyEmailRequest) error {
+	admin, err := s.consumeToken(ctx, req.Token, account.PurposeEmailVerification)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	admin.EmailVerifiedAt = &now
+
+	if err := s.companyRepo.UpdateAdmin(ctx, admin); err != nil {
+		return fmt.Errorf("failed to verify email: %w", err)
+	}
+	return nil
+}
+
+// Helper methods
+
+// allowResetRequest counts a password reset request against its email and
+// client IP address, and reports whether either is over its limit. Every
+// request counts, so the endpoint cannot be used to flood an inbox or the
+// mail provider.
+func (s *accountService) allowResetRequest(ctx context.Context, req account.ForgotPasswordRequest) (bool, error) {
+	type counter struct {
+		limiter *throttle.Limiter
+		key     string
+	}
+	counters := []counter{{s.resetEmails, "reset:" + strings.ToLower(strings.TrimSpace(req.Email))}}
+	if req.IPAddress != "" {
+		counters = append(counters, counter{s.resetIPs, "reset-ip:" + req.IPAddress})
+	}
+
+	for _, c := range counters {
+		if err := c.limiter.Check(ctx, c.key); err != nil {
+			if errors.Is(err, throttle.ErrLocked) {
+				return false, nil
+			}
+			return false, err
+		}
+	}
+	for _, c := range counters {
+		if _, _, err := c.limiter.Fail(ctx, c.key); err != nil {
+			return false, fmt.Errorf("failed to record password reset request: %w", err)
+		}
+	}
+	return true, nil
+}
+
+// issueToken creates a new token for the admin, invalidating any earlier
+// unused token issued for the same purpose
+func (s *accountService) issueToken(ctx context.Context, adminID uint64, purpose account.Purpose) (string, error) {
+	if err := s.repo.InvalidateUnused(ctx, adminID, purpose); err != nil {
+		return "", fmt.Errorf("failed to invalidate previous tokens: %w", err)
+	}
+
+	token, err := hash.RandomToken(32)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+
+	if err := s.repo.Create(ctx, &account.Token{
+		AdminID:   adminID,
+		Purpose:   purpose,
+		TokenHash: hash.HashToken(token),
+		ExpiresAt: time.Now().Add(purpose.TTL()),
+	}); err != nil {
+		return "", fmt.Errorf("failed to store token: %w", err)
+	}
+
+	return token, nil
+}
+
+// consumeToken validates a token for the purpose, marks it used and returns its admin
+func (s *accountService) consumeToken(ctx context.Context, raw string, purpose account.Purpose) (*company.CompanyAdmin, error) {
+	token, err := s.repo.GetByHash(ctx, hash.HashToken(raw))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, account.ErrInvalidToken
+		}
+		return nil, err
+	}
+
+	if token.Purpose != purpose || token.UsedAt != nil || time.Now().After(token.ExpiresAt) {
+		return nil, account.ErrInvalidToken
+	}
+
+	marked, err := s.repo.MarkUsed(ctx, token.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to consume token: %w", err)
+	}
+	if !marked {
+		return nil, account.ErrInvalidToken
+	}
+
+	admin, err := s.companyRepo.GetAdminByID(ctx, token.AdminID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, account.ErrInvalidToken
+		}
+		return nil, err
+	}
+	if !admin.IsActive {
+		return nil, account.ErrInvalidToken
+	}
+
+	return admin, nil
+}
+
+// expiryText renders a token lifetime for use in emails
+func expiryText(d time.Duration) string {
+	hours := int(d / time.Hour)
+	if hours == 1 {
+		return "1 hour"
+	}
+	return fmt.Sprintf("%d hours", hours)
+}
+
+// link builds a dashboard URL carrying the token
+func (s *accountService) link(path, token string) string {
+	return s.linkBaseURL + path + "?token=" + token
+}
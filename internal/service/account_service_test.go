@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"my-go-driver/internal/domain/account"
+	"my-go-driver/pkg/throttle"
+)
+
+func TestAccountServiceAllowResetRequest(t *testing.T) {
+	policy := func(threshold int) throttle.Policy {
+		return throttle.Policy{Threshold: threshold, BaseDelay: time.Minute, MaxDelay: time.Hour, Window: time.Hour}
+	}
+	request := func(email, ip string) account.ForgotPasswordRequest {
+		return account.ForgotPasswordRequest{Email: email, IPAddress: ip}
+	}
+
+	tests := []struct {
+		name      string
+		requests  []account.ForgotPasswordRequest
+		check     account.ForgotPasswordRequest
+		wantAllow bool
+	}{
+		{
+			name:      "below the email limit",
+			requests:  []account.ForgotPasswordRequest{request("a@example.com", "10.0.0.1")},
+			check:     request("a@example.com", "10.0.0.2"),
+			wantAllow: true,
+		},
+		{
+			name: "email is throttled from every address",
+			requests: []account.ForgotPasswordRequest{
+				request("a@example.com", "10.0.0.1"),
+				request("A@example.com ", "10.0.0.2"),
+			},
+			check: request("a@example.com", "10.0.0.3"),
+		},
+		{
+			name: "address is throttled for every email",
+			requests: []account.ForgotPasswordRequest{
+				request("a@example.com", "10.0.0.1"),
+				request("b@example.com", "10.0.0.1"),
+				request("c@example.com", "10.0.0.1"),
+			},
+			check: request("d@example.com", "10.0.0.1"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := throttle.NewMemoryStore()
+			s := &accountService{
+				resetEmails: throttle.NewLimiter(store, policy(2)),
+				resetIPs:    throttle.NewLimiter(store, policy(3)),
+			}
+			ctx := context.Background()
+
+			for _, req := range tt.requests {
+				if _, err := s.allowResetRequest(ctx, req); err != nil {
+					t.Fatalf("allowResetRequest: %v", err)
+				}
+			}
+
+			allowed, err := s.allowResetRequest(ctx, tt.check)
+			if err != nil {
+				t.Fatalf("allowResetRequest: %v", err)
+			}
+			if allowed != tt.wantAllow {
+				t.Fatalf("allowed = %v, want %v", allowed, tt.wantAllow)
+			}
+		})
+	}
+}
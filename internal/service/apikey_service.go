@@ -0,0 +1,238 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"my-go-driver/internal/domain/apikey"
+	"my-go-driver/internal/domain/company"
+	"my-go-driver/internal/domain/module"
+	"my-go-driver/internal/domain/rbac"
+	"my-go-driver/internal/tenant"
+	"my-go-driver/pkg/hash"
+
+	"gorm.io/gorm"
+)
+
+// lastUsedResolution limits how often last-used tracking writes to the
+// database for a busy key
+const lastUsedResolution = time.Minute
+
+type apiKeyService struct {
+	repo        apikey.Repository
+	companyRepo company.Repository
+	modules     module.Service
+}
+
+// NewAPIKeyService creates a new API key service
+func NewAPIKeyService(repo apikey.Repository, companyRepo company.Repository, modules module.Service) apikey.Service {
+	return &apiKeyService{
+		repo:        repo,
+		companyRepo: companyRepo,
+		modules:     modules,
+	}
+}
+
+func (s *apiKeyService) CreateKey(ctx context.Context, companyID, createdBy uint64, req apikey.CreateAPIKeyRequest) (*apikey.CreatedAPIKeyResponse, error) {
+	if err := s.requireAPIAccess(ctx, companyID); err != nil {
+		return nil, err
+	}
+
+	// A key can never do more than the admin who created it
+	for _, scope := range req.Scopes {
+		if !rbac.IsKnown(scope) {
+			return nil, fmt.Errorf("%w: %s", rbac.ErrUnknownPermission, scope)
+		}
+		if err := rbac.Check(ctx, scope); err != nil {
+			return nil, err
+		}
+	}
+
+	allowedIPs, err := normalizeAllowedIPs(req.AllowedIPs)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return nil, apikey.ErrExpiryInPast
+	}
+
+	secret, err := hash.RandomToken(32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate api key: %w", err)
+	}
+	raw := apikey.KeyPrefix + secret
+
+	key := &apikey.APIKey{
+		CompanyID:  companyID,
+		Name:       req.Name,
+		Prefix:     raw[:len(apikey.KeyPrefix)+8],
+		KeyHash:    hash.HashToken(raw),
+		Scopes:     rbac.Permissions(req.Scopes),
+		AllowedIPs: allowedIPs,
+		ExpiresAt:  req.ExpiresAt,
+	}
+	if createdBy != 0 {
+		key.CreatedBy = &createdBy
+	}
+
+	if err := s.repo.Create(ctx, key); err != nil {
+		return nil, fmt.Errorf("failed to create api key: %w", err)
+	}
+
+	return &apikey.CreatedAPIKeyResponse{
+		APIKeyResponse: s.toResponse(key),
+		Key:            raw,
+	}, nil
+}
+
+func (s *apiKeyService) ListKeys(ctx context.Context, companyID uint64) ([]apikey.APIKeyResponse, error) {
+	if !tenant.Allows(ctx, companyID) {
+		return nil, company.ErrCompanyNotFound
+	}
+
+	keys, err := s.repo.ListByCompany(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]apikey.APIKeyResponse, len(keys))
+	for i := range keys {
+		responses[i] = s.toResponse(&keys[i])
+	}
+	return responses, nil
+}
+
+func (s *apiKeyService) RevokeKey(ctx context.Context, companyID, keyID uint64) error {
+	key, err := s.repo.GetByID(ctx, keyID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apikey.ErrAPIKeyNotFound
+		}
+		return err
+	}
+	if key.CompanyID != companyID || !tenant.Allows(ctx, key.CompanyID) {
+		return apikey.ErrAPIKeyNotFound
+	}
+
+	return s.repo.Revoke(ctx, key.ID)
+}
+
+func (s *apiKeyService) Authenticate(ctx context.Context, rawKey, clientIP string) (*apikey.APIKey, error) {
+	if !strings.HasPrefix(rawKey, apikey.KeyPrefix) {
+		return nil, apikey.ErrInvalidAPIKey
+	}
+
+	key, err := s.repo.GetByHash(ctx, hash.HashToken(rawKey))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apikey.ErrInvalidAPIKey
+		}
+		return nil, err
+	}
+
+	now := time.Now()
+	if !key.IsActive(now) {
+		return nil, apikey.ErrInvalidAPIKey
+	}
+	if !key.AllowsIP(net.ParseIP(clientIP)) {
+		return nil, apikey.ErrIPNotAllowed
+	}
+
+	// Keys of suspended companies stop working, like their admins' sessions
+	c, err := s.companyRepo.GetByID(ctx, key.CompanyID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apikey.ErrInvalidAPIKey
+		}
+		return nil, err
+	}
+	if c.Status != company.CompanyStatusActive {
+		return nil, apikey.ErrInvalidAPIKey
+	}
+
+	enabled, err := s.apiAccessEnabled(ctx, key.CompanyID)
+	if err != nil {
+		return nil, err
+	}
+	if !enabled {
+		return nil, apikey.ErrAPIAccessDisabled
+	}
+
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= lastUsedResolution || key.LastUsedIP != clientIP {
+		if err := s.repo.TouchLastUsed(ctx, key.ID, now, clientIP); err != nil {
+			return nil, fmt.Errorf("failed to record api key use: %w", err)
+		}
+	}
+
+	return key, nil
+}
+
+// Helper methods
+
+func (s *apiKeyService) apiAccessEnabled(ctx context.Context, companyID uint64) (bool, error) {
+	return s.modules.IsEnabled(ctx, companyID, module.KeyAPIAccess)
+}
+
+func (s *apiKeyService) requireAPIAccess(ctx context.Context, companyID uint64) error {
+	if !tenant.Allows(ctx, companyID) {
+		return company.ErrCompanyNotFound
+	}
+
+	enabled, err := s.apiAccessEnabled(ctx, companyID)
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		return apikey.ErrAPIAccessDisabled
+	}
+	return nil
+}
+
+// normalizeAllowedIPs validates allowlist entries, returning them in canonical form
+func normalizeAllowedIPs(entries []string) (apikey.IPList, error) {
+	list := make(apikey.IPList, 0, len(entries))
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if _, network, err := net.ParseCIDR(entry); err == nil {
+			list = append(list, network.String())
+			continue
+		}
+		ip := net.ParseIP(entry)
+		if ip == nil {
+			return nil, fmt.Errorf("%w: %q", apikey.ErrInvalidAllowedIP, entry)
+		}
+		list = append(list, ip.String())
+	}
+	return list, nil
+}
+
+func (s *apiKeyService) toResponse(key *apikey.APIKey) apikey.APIKeyResponse {
+	scopes := []rbac.Permission(key.Scopes)
+	if scopes == nil {
+		scopes = []rbac.Permission{}
+	}
+	allowedIPs := []string(key.AllowedIPs)
+	if allowedIPs == nil {
+		allowedIPs = []string{}
+	}
+
+	return apikey.APIKeyResponse{
+		ID:         key.ID,
+		CompanyID:  key.CompanyID,
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		Scopes:     scopes,
+		AllowedIPs: allowedIPs,
+		ExpiresAt:  key.ExpiresAt,
+		LastUsedAt: key.LastUsedAt,
+		LastUsedIP: key.LastUsedIP,
+		CreatedBy:  key.CreatedBy,
+		RevokedAt:  key.RevokedAt,
+		CreatedAt:  key.CreatedAt,
+	}
+}
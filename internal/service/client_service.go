@@ -0,0 +1,599 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"my-go-driver/internal/domain/client"
+	"my-go-driver/internal/domain/company"
+	"my-go-driver/internal/tenant"
+	"my-go-driver/internal/transaction"
+	"my-go-driver/pkg/geocode"
+
+	"gorm.io/gorm"
+)
+
+type clientService struct {
+	repo     client.Repository
+	geocoder geocode.Geocoder
+	tx       transaction.Transactor
+}
+
+// NewClientService creates a new client service
+func NewClientService(repo client.Repository, geocoder geocode.Geocoder, tx transaction.Transactor) client.Service {
+	return &clientService{
+		repo:     repo,
+		geocoder: geocoder,
+		tx:       tx,
+	}
+}
+
+func (s *clientService) CreateClient(ctx context.Context, req client.CreateClientRequest) (*client.ClientResponse, error) {
+	if !tenant.Allows(ctx, req.CompanyID) {
+		return nil, company.ErrCompanyNotFound
+	}
+
+	phone, err := normalizePhone(req.Phone)
+	if err != nil {
+		return nil, err
+	}
+
+	// Geocode before the transaction, geocoders may be slow
+	addresses := make([]*client.Address, len(req.Addresses))
+	defaultIndex := 0
+	for i, a := range req.Addresses {
+		if addresses[i], err = s.newAddress(ctx, a); err != nil {
+			return nil, err
+		}
+		if a.IsDefault && !req.Addresses[defaultIndex].IsDefault {
+			defaultIndex = i
+		}
+	}
+
+	newClient := &client.Client{
+		CompanyID: req.CompanyID,
+		Name:      req.Name,
+		Phone:     phone,
+		Email:     req.Email,
+	}
+
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.checkPhone(ctx, phone, req.CompanyID, 0); err != nil {
+			return err
+		}
+		if err := s.repo.Create(ctx, newClient); err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		var defaultID uint64
+		for i, a := range addresses {
+			a.ClientID = newClient.ID
+			if err := s.repo.CreateAddress(ctx, a); err != nil {
+				return fmt.Errorf("failed to create client address: %w", err)
+			}
+			if i == defaultIndex {
+				defaultID = a.ID
+			}
+		}
+		_, err := s.refreshDefaultAddress(ctx, newClient, defaultID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.clientResponse(ctx, newClient)
+}
+
+func (s *clientService) GetClient(ctx context.Context, id uint64) (*client.ClientResponse, error) {
+	c, err := s.findClient(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.clientResponse(ctx, c)
+}
+
+func (s *clientService) UpdateClient(ctx context.Context, id uint64, req client.UpdateClientRequest) (*client.ClientResponse, error) {
+	c, err := s.findClient(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Update fields
+	if req.Name != "" {
+		c.Name = req.Name
+	}
+	if req.Phone != "" {
+		phone, err := normalizePhone(req.Phone)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.checkPhone(ctx, phone, c.CompanyID, c.ID); err != nil {
+			return nil, err
+		}
+		c.Phone = phone
+	}
+	if req.Email != "" {
+		c.Email = req.Email
+	}
+
+	if err := s.repo.Update(ctx, c); err != nil {
+		return nil, fmt.Errorf("failed to update client: %w", err)
+	}
+
+	return s.clientResponse(ctx, c)
+}
+
+func (s *clientService) DeleteClient(ctx context.Context, id uint64) error {
+	if _, err := s.findClient(ctx, id); err != nil {
+		return err
+	}
+
+	// Orders would be deleted with the client by the foreign key
+	orders, err := s.repo.CountOrders(ctx, id)
+	if err != nil {
+		return fmt.Errorf("error checking client orders: %w", err)
+	}
+	if orders > 0 {
+		return client.ErrClientHasOrders
+	}
+
+	return s.repo.Delete(ctx, id)
+}
+
+func (s *clientService) ListClients(ctx context.Context, query client.ListClientsQuery) (*client.PaginatedClientsResponse, error) {
+	// Company admins only ever see their own clients
+	if companyID, ok := tenant.CompanyID(ctx); ok {
+		query.CompanyID = companyID
+	}
+	if phone := client.NormalizePhone(query.Phone); phone != "" {
+		query.Phone = phone
+	}
+
+	clients, total, err := s.repo.List(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set defaults
+	if query.Page <= 0 {
+		query.Page = 1
+	}
+	if query.Limit <= 0 {
+		query.Limit = 10
+	}
+
+	addresses := make(map[uint64][]client.Address, len(clients))
+	if len(clients) > 0 {
+		ids := make([]uint64, len(clients))
+		for i, c := range clients {
+			ids[i] = c.ID
+		}
+		list, err := s.repo.ListAddresses(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range list {
+			addresses[a.ClientID] = append(addresses[a.ClientID], a)
+		}
+	}
+
+	responses := make([]client.ClientResponse, len(clients))
+	for i, c := range clients {
+		responses[i] = s.toClientResponse(&c, addresses[c.ID])
+	}
+
+	totalPages := int(math.Ceil(float64(total) / float64(query.Limit)))
+
+	return &client.PaginatedClientsResponse{
+		Clients:    responses,
+		TotalCount: total,
+		Page:       query.Page,
+		Limit:      query.Limit,
+		TotalPages: totalPages,
+	}, nil
+}
+
+func (s *clientService) MergeClients(ctx context.Context, id uint64, req client.MergeClientsRequest) (*client.ClientResponse, error) {
+	duplicateIDs := make([]uint64, 0, len(req.DuplicateIDs))
+	seen := make(map[uint64]bool, len(req.DuplicateIDs))
+	for _, dupID := range req.DuplicateIDs {
+		if dupID == id {
+			return nil, client.ErrMergeIntoSelf
+		}
+		if !seen[dupID] {
+			seen[dupID] = true
+			duplicateIDs = append(duplicateIDs, dupID)
+		}
+	}
+	// Lock the duplicates in a stable order
+	sort.Slice(duplicateIDs, func(i, j int) bool { return duplicateIDs[i] < duplicateIDs[j] })
+
+	var target *client.Client
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if target, err = s.lockClient(ctx, id); err != nil {
+			return err
+		}
+
+		duplicates := make([]*client.Client, len(duplicateIDs))
+		for i, dupID := range duplicateIDs {
+			dup, err := s.lockClient(ctx, dupID)
+			if err != nil {
+				return err
+			}
+			if dup.CompanyID != target.CompanyID {
+				return client.ErrClientNotFound
+			}
+			duplicates[i] = dup
+		}
+
+		if err := s.repo.ReassignOrders(ctx, duplicateIDs, target.ID); err != nil {
+			return fmt.Errorf("failed to move client orders: %w", err)
+		}
+
+		// Move the duplicates' addresses, dropping those the client already has
+		kept, err := s.repo.ListAddresses(ctx, []uint64{target.ID})
+		if err != nil {
+			return err
+		}
+		moved, err := s.repo.ListAddresses(ctx, duplicateIDs)
+		if err != nil {
+			return err
+		}
+		for i := range moved {
+			a := &moved[i]
+			if containsAddress(kept, a) {
+				if err := s.repo.DeleteAddress(ctx, a.ID); err != nil {
+					return fmt.Errorf("failed to delete client address: %w", err)
+				}
+				continue
+			}
+			a.ClientID = target.ID
+			a.IsDefault = false
+			if err := s.repo.UpdateAddress(ctx, a); err != nil {
+				return fmt.Errorf("failed to move client address: %w", err)
+			}
+			kept = append(kept, *a)
+		}
+
+		for _, dup := range duplicates {
+			if target.Email == "" {
+				target.Email = dup.Email
+			}
+			if err := s.repo.Delete(ctx, dup.ID); err != nil {
+				return fmt.Errorf("failed to delete client: %w", err)
+			}
+		}
+
+		_, err = s.refreshDefaultAddress(ctx, target, 0)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.clientResponse(ctx, target)
+}
+
+func (s *clientService) AddAddress(ctx context.Context, clientID uint64, req client.AddressRequest) (*client.AddressResponse, error) {
+	if _, err := s.findClient(ctx, clientID); err != nil {
+		return nil, err
+	}
+
+	address, err := s.newAddress(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		c, err := s.lockClient(ctx, clientID)
+		if err != nil {
+			return err
+		}
+
+		address.ClientID = c.ID
+		if err := s.repo.CreateAddress(ctx, address); err != nil {
+			return fmt.Errorf("failed to create client address: %w", err)
+		}
+
+		var preferredID uint64
+		if req.IsDefault {
+			preferredID = address.ID
+		}
+		defaultID, err := s.refreshDefaultAddress(ctx, c, preferredID)
+		address.IsDefault = defaultID == address.ID
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := s.toAddressResponse(address)
+	return &response, nil
+}
+
+func (s *clientService) ListAddresses(ctx context.Context, clientID uint64) ([]client.AddressResponse, error) {
+	if _, err := s.findClient(ctx, clientID); err != nil {
+		return nil, err
+	}
+
+	addresses, err := s.repo.ListAddresses(ctx, []uint64{clientID})
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]client.AddressResponse, len(addresses))
+	for i, a := range addresses {
+		responses[i] = s.toAddressResponse(&a)
+	}
+	return responses, nil
+}
+
+func (s *clientService) UpdateAddress(ctx context.Context, clientID, addressID uint64, req client.UpdateAddressRequest) (*client.AddressResponse, error) {
+	c, err := s.findClient(ctx, clientID)
+	if err != nil {
+		return nil, err
+	}
+	address, err := s.findAddress(ctx, c, addressID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Update fields
+	if req.Label != "" {
+		address.Label = req.Label
+	}
+	relocated := req.Address != "" && req.Address != address.Address
+	if relocated {
+		address.Address = req.Address
+	}
+	if relocated || req.Latitude != nil || req.Longitude != nil {
+		if address.Latitude, address.Longitude, err = s.locate(ctx, address.Address, req.Latitude, req.Longitude); err != nil {
+			return nil, err
+		}
+	}
+
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		c, err := s.lockClient(ctx, clientID)
+		if err != nil {
+			return err
+		}
+
+		if err := s.repo.UpdateAddress(ctx, address); err != nil {
+			return fmt.Errorf("failed to update client address: %w", err)
+		}
+
+		var preferredID uint64
+		if req.IsDefault {
+			preferredID = address.ID
+		}
+		defaultID, err := s.refreshDefaultAddress(ctx, c, preferredID)
+		address.IsDefault = defaultID == address.ID
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := s.toAddressResponse(address)
+	return &response, nil
+}
+
+func (s *clientService) DeleteAddress(ctx context.Context, clientID, addressID uint64) error {
+	c, err := s.findClient(ctx, clientID)
+	if err != nil {
+		return err
+	}
+	if _, err := s.findAddress(ctx, c, addressID); err != nil {
+		return err
+	}
+
+	return s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		c, err := s.lockClient(ctx, clientID)
+		if err != nil {
+			return err
+		}
+
+		if err := s.repo.DeleteAddress(ctx, addressID); err != nil {
+			return fmt.Errorf("failed to delete client address: %w", err)
+		}
+
+		// The oldest remaining address takes over from a deleted default
+		_, err = s.refreshDefaultAddress(ctx, c, 0)
+		return err
+	})
+}
+
+// Helper methods
+
+// findClient loads a client, hiding clients outside the caller's tenant
+func (s *clientService) findClient(ctx context.Context, id uint64) (*client.Client, error) {
+	return s.loadClient(ctx, s.repo.GetByID, id)
+}
+
+// lockClient loads a client for update within the caller's transaction
+func (s *clientService) lockClient(ctx context.Context, id uint64) (*client.Client, error) {
+	return s.loadClient(ctx, s.repo.GetByIDForUpdate, id)
+}
+
+func (s *clientService) loadClient(ctx context.Context, get func(context.Context, uint64) (*client.Client, error), id uint64) (*client.Client, error) {
+	c, err := get(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, client.ErrClientNotFound
+		}
+		return nil, err
+	}
+	if !tenant.Allows(ctx, c.CompanyID) {
+		return nil, client.ErrClientNotFound
+	}
+	return c, nil
+}
+
+// findAddress loads a saved address of the client
+func (s *clientService) findAddress(ctx context.Context, c *client.Client, id uint64) (*client.Address, error) {
+	a, err := s.repo.GetAddress(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, client.ErrAddressNotFound
+		}
+		return nil, err
+	}
+	if a.ClientID != c.ID {
+		return nil, client.ErrAddressNotFound
+	}
+	return a, nil
+}
+
+// newAddress builds a saved address from a request, geocoding it when no
+// coordinates are given
+func (s *clientService) newAddress(ctx context.Context, req client.AddressRequest) (*client.Address, error) {
+	latitude, longitude, err := s.locate(ctx, req.Address, req.Latitude, req.Longitude)
+	if err != nil {
+		return nil, err
+	}
+	return &client.Address{
+		Label:     req.Label,
+		Address:   req.Address,
+		Latitude:  latitude,
+		Longitude: longitude,
+	}, nil
+}
+
+// locate returns the given coordinates, or geocodes the address when there
+// are none. Addresses the geocoder does not know are left without coordinates.
+func (s *clientService) locate(ctx context.Context, address string, latitude, longitude *float64) (*float64, *float64, error) {
+	if (latitude == nil) != (longitude == nil) {
+		return nil, nil, client.ErrIncompleteCoordinates
+	}
+	if latitude != nil {
+		return latitude, longitude, nil
+	}
+
+	loc, err := s.geocoder.Geocode(ctx, address)
+	if errors.Is(err, geocode.ErrNotFound) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to geocode address: %w", err)
+	}
+	return &loc.Latitude, &loc.Longitude, nil
+}
+
+// refreshDefaultAddress makes preferredID, or else the current default or
+// the oldest address, the client's default address and mirrors it on the
+// client. It returns the default address ID, 0 when the client has none.
+func (s *clientService) refreshDefaultAddress(ctx context.Context, c *client.Client, preferredID uint64) (uint64, error) {
+	addresses, err := s.repo.ListAddresses(ctx, []uint64{c.ID})
+	if err != nil {
+		return 0, err
+	}
+
+	var chosen *client.Address
+	for i := range addresses {
+		if addresses[i].ID == preferredID {
+			chosen = &addresses[i]
+		}
+	}
+	// Addresses are listed default first, then oldest first
+	if chosen == nil && len(addresses) > 0 {
+		chosen = &addresses[0]
+	}
+
+	c.Address, c.Latitude, c.Longitude = "", nil, nil
+	var defaultID uint64
+	if chosen != nil {
+		if err := s.repo.SetDefaultAddress(ctx, c.ID, chosen.ID); err != nil {
+			return 0, fmt.Errorf("failed to set default address: %w", err)
+		}
+		c.Address, c.Latitude, c.Longitude = chosen.Address, chosen.Latitude, chosen.Longitude
+		defaultID = chosen.ID
+	}
+
+	if err := s.repo.Update(ctx, c); err != nil {
+		return 0, fmt.Errorf("failed to update client: %w", err)
+	}
+	return defaultID, nil
+}
+
+// checkPhone verifies that no other client of the company uses the phone number
+func (s *clientService) checkPhone(ctx context.Context, phone string, companyID, clientID uint64) error {
+	existing, err := s.repo.GetByPhone(ctx, phone, companyID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("error checking phone number: %w", err)
+	}
+	if existing != nil && existing.ID != clientID {
+		return client.ErrPhoneTaken
+	}
+	return nil
+}
+
+// normalizePhone normalizes a phone number, rejecting numbers without digits
+func normalizePhone(phone string) (string, error) {
+	normalized := client.NormalizePhone(phone)
+	if normalized == "" {
+		return "", client.ErrInvalidPhone
+	}
+	return normalized, nil
+}
+
+// containsAddress reports whether addresses has one reading the same as a
+func containsAddress(addresses []client.Address, a *client.Address) bool {
+	for i := range addresses {
+		if addresses[i].SameAs(a) {
+			return true
+		}
+	}
+	return false
+}
+
+// clientResponse loads the client's addresses into its response
+func (s *clientService) clientResponse(ctx context.Context, c *client.Client) (*client.ClientResponse, error) {
+	addresses, err := s.repo.ListAddresses(ctx, []uint64{c.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	response := s.toClientResponse(c, addresses)
+	return &response, nil
+}
+
+func (s *clientService) toClientResponse(c *client.Client, addresses []client.Address) client.ClientResponse {
+	responses := make([]client.AddressResponse, len(addresses))
+	for i, a := range addresses {
+		responses[i] = s.toAddressResponse(&a)
+	}
+
+	return client.ClientResponse{
+		ID:        c.ID,
+		CompanyID: c.CompanyID,
+		Name:      c.Name,
+		Phone:     c.Phone,
+		Email:     c.Email,
+		Address:   c.Address,
+		Latitude:  c.Latitude,
+		Longitude: c.Longitude,
+		Addresses: responses,
+		CreatedAt: c.CreatedAt,
+		UpdatedAt: c.UpdatedAt,
+	}
+}
+
+func (s *clientService) toAddressResponse(a *client.Address) client.AddressResponse {
+	return client.AddressResponse{
+		ID:        a.ID,
+		ClientID:  a.ClientID,
+		Label:     a.Label,
+		Address:   a.Address,
+		Latitude:  a.Latitude,
+		Longitude: a.Longitude,
+		IsDefault: a.IsDefault,
+		CreatedAt: a.CreatedAt,
+		UpdatedAt: a.UpdatedAt,
+	}
+}
@@ -0,0 +1,385 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+
+	"my-go-driver/internal/domain/client"
+	"my-go-driver/internal/tenant"
+	"my-go-driver/pkg/geocode"
+
+	"gorm.io/gorm"
+)
+
+// memoryClientRepository keeps clients, their addresses and the client of
+// each order in memory
+type memoryClientRepository struct {
+	clients   map[uint64]client.Client
+	addresses map[uint64]client.Address
+	orders    map[uint64]uint64
+	nextID    uint64
+}
+
+func newMemoryClientRepository() *memoryClientRepository {
+	return &memoryClientRepository{
+		clients:   make(map[uint64]client.Client),
+		addresses: make(map[uint64]client.Address),
+		orders:    make(map[uint64]uint64),
+	}
+}
+
+func (r *memoryClientRepository) id() uint64 {
+	r.nextID++
+	return r.nextID
+}
+
+func (r *memoryClientRepository) Create(ctx context.Context, c *client.Client) error {
+	c.ID = r.id()
+	r.clients[c.ID] = *c
+	return nil
+}
+
+func (r *memoryClientRepository) GetByID(ctx context.Context, id uint64) (*client.Client, error) {
+	c, ok := r.clients[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &c, nil
+}
+
+func (r *memoryClientRepository) GetByIDForUpdate(ctx context.Context, id uint64) (*client.Client, error) {
+	return r.GetByID(ctx, id)
+}
+
+func (r *memoryClientRepository) GetByPhone(ctx context.Context, phone string, companyID uint64) (*client.Client, error) {
+	for _, c := range r.clients {
+		if c.Phone == phone && c.CompanyID == companyID {
+			return &c, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *memoryClientRepository) Update(ctx context.Context, c *client.Client) error {
+	r.clients[c.ID] = *c
+	return nil
+}
+
+func (r *memoryClientRepository) Delete(ctx context.Context, id uint64) error {
+	delete(r.clients, id)
+	return nil
+}
+
+func (r *memoryClientRepository) List(ctx context.Context, query client.ListClientsQuery) ([]client.Client, int64, error) {
+	return nil, 0, errors.New("not implemented")
+}
+
+func (r *memoryClientRepository) CountOrders(ctx context.Context, clientID uint64) (int64, error) {
+	var count int64
+	for _, owner := range r.orders {
+		if owner == clientID {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *memoryClientRepository) ReassignOrders(ctx context.Context, fromIDs []uint64, toID uint64) error {
+	for orderID, owner := range r.orders {
+		for _, from := range fromIDs {
+			if owner == from {
+				r.orders[orderID] = toID
+			}
+		}
+	}
+	return nil
+}
+
+func (r *memoryClientRepository) CreateAddress(ctx context.Context, a *client.Address) error {
+	a.ID = r.id()
+	r.addresses[a.ID] = *a
+	return nil
+}
+
+func (r *memoryClientRepository) GetAddress(ctx context.Context, id uint64) (*client.Address, error) {
+	a, ok := r.addresses[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &a, nil
+}
+
+func (r *memoryClientRepository) ListAddresses(ctx context.Context, clientIDs []uint64) ([]client.Address, error) {
+	addresses := []client.Address{}
+	for _, a := range r.addresses {
+		for _, id := range clientIDs {
+			if a.ClientID == id {
+				addresses = append(addresses, a)
+			}
+		}
+	}
+	sort.Slice(addresses, func(i, j int) bool {
+		if addresses[i].IsDefault != addresses[j].IsDefault {
+			return addresses[i].IsDefault
+		}
+		return addresses[i].ID < addresses[j].ID
+	})
+	return addresses, nil
+}
+
+func (r *memoryClientRepository) UpdateAddress(ctx context.Context, a *client.Address) error {
+	r.addresses[a.ID] = *a
+	return nil
+}
+
+func (r *memoryClientRepository) DeleteAddress(ctx context.Context, id uint64) error {
+	delete(r.addresses, id)
+	return nil
+}
+
+func (r *memoryClientRepository) SetDefaultAddress(ctx context.Context, clientID, addressID uint64) error {
+	for id, a := range r.addresses {
+		if a.ClientID == clientID {
+			a.IsDefault = id == addressID
+			r.addresses[id] = a
+		}
+	}
+	return nil
+}
+
+// directTransactor runs the unit of work without a transaction
+type directTransactor struct{}
+
+func (directTransactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// addAddresses saves addresses for the client in order, marking the first
+// one default, and returns their IDs
+func (r *memoryClientRepository) addAddresses(t *testing.T, clientID uint64, addresses ...string) []uint64 {
+	t.Helper()
+	ids := make([]uint64, len(addresses))
+	for i, address := range addresses {
+		a := &client.Address{ClientID: clientID, Address: address, IsDefault: i == 0}
+		if err := r.CreateAddress(context.Background(), a); err != nil {
+			t.Fatal(err)
+		}
+		ids[i] = a.ID
+	}
+	return ids
+}
+
+func float64Ptr(f float64) *float64 {
+	return &f
+}
+
+func TestClientServiceLocate(t *testing.T) {
+	geocoder := geocode.NewMemoryGeocoder()
+	geocoder.Add("12 Main St, Springfield", geocode.Location{Latitude: 39.78, Longitude: -89.65})
+	s := &clientService{geocoder: geocoder}
+
+	tests := []struct {
+		name      string
+		address   string
+		latitude  *float64
+		longitude *float64
+		want      []float64
+		err       error
+	}{
+		{
+			name:      "given coordinates are kept",
+			address:   "12 Main St, Springfield",
+			latitude:  float64Ptr(1),
+			longitude: float64Ptr(2),
+			want:      []float64{1, 2},
+		},
+		{
+			name:     "latitude without longitude",
+			address:  "12 Main St, Springfield",
+			latitude: float64Ptr(1),
+			err:      client.ErrIncompleteCoordinates,
+		},
+		{
+			name:      "longitude without latitude",
+			address:   "12 Main St, Springfield",
+			longitude: float64Ptr(2),
+			err:       client.ErrIncompleteCoordinates,
+		},
+		{
+			name:    "known address is geocoded",
+			address: "12 main st. springfield",
+			want:    []float64{39.78, -89.65},
+		},
+		{
+			name:    "unknown address has no coordinates",
+			address: "99 Nowhere Rd",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			latitude, longitude, err := s.locate(context.Background(), tt.address, tt.latitude, tt.longitude)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("locate() error = %v, want %v", err, tt.err)
+			}
+			if tt.want == nil {
+				if latitude != nil || longitude != nil {
+					t.Errorf("locate() = %v, %v, want no coordinates", latitude, longitude)
+				}
+				return
+			}
+			if latitude == nil || longitude == nil || *latitude != tt.want[0] || *longitude != tt.want[1] {
+				t.Errorf("locate() = %v, %v, want %v", latitude, longitude, tt.want)
+			}
+		})
+	}
+}
+
+func TestClientServiceRefreshDefaultAddress(t *testing.T) {
+	tests := []struct {
+		name        string
+		addresses   []string
+		deleted     []int
+		preferred   int
+		wantDefault int
+	}{
+		{
+			name:        "current default is kept",
+			addresses:   []string{"1 First St", "2 Second St"},
+			preferred:   -1,
+			wantDefault: 0,
+		},
+		{
+			name:        "preferred address becomes default",
+			addresses:   []string{"1 First St", "2 Second St", "3 Third St"},
+			preferred:   2,
+			wantDefault: 2,
+		},
+		{
+			name:        "deleted default passes to the oldest remaining address",
+			addresses:   []string{"1 First St", "2 Second St", "3 Third St"},
+			deleted:     []int{0},
+			preferred:   -1,
+			wantDefault: 1,
+		},
+		{
+			name:        "no address left",
+			addresses:   []string{"1 First St"},
+			deleted:     []int{0},
+			preferred:   -1,
+			wantDefault: -1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newMemoryClientRepository()
+			s := &clientService{repo: repo}
+			ctx := context.Background()
+
+			c := &client.Client{CompanyID: 1, Name: "Jane", Phone: "+1555"}
+			if err := repo.Create(ctx, c); err != nil {
+				t.Fatal(err)
+			}
+			ids := repo.addAddresses(t, c.ID, tt.addresses...)
+			for _, i := range tt.deleted {
+				if err := repo.DeleteAddress(ctx, ids[i]); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			var preferredID uint64
+			if tt.preferred >= 0 {
+				preferredID = ids[tt.preferred]
+			}
+			got, err := s.refreshDefaultAddress(ctx, c, preferredID)
+			if err != nil {
+				t.Fatalf("refreshDefaultAddress() error = %v", err)
+			}
+
+			stored := repo.clients[c.ID]
+			if tt.wantDefault < 0 {
+				if got != 0 || stored.Address != "" {
+					t.Errorf("refreshDefaultAddress() = %d with address %q, want none", got, stored.Address)
+				}
+				return
+			}
+			if want := ids[tt.wantDefault]; got != want {
+				t.Errorf("refreshDefaultAddress() = %d, want %d", got, want)
+			}
+			if want := tt.addresses[tt.wantDefault]; stored.Address != want {
+				t.Errorf("client address = %q, want %q", stored.Address, want)
+			}
+			for id, a := range repo.addresses {
+				if a.IsDefault != (id == got) {
+					t.Errorf("address %d is_default = %v", id, a.IsDefault)
+				}
+			}
+		})
+	}
+}
+
+func TestClientServiceMergeClients(t *testing.T) {
+	repo := newMemoryClientRepository()
+	s := NewClientService(repo, geocode.NewMemoryGeocoder(), directTransactor{})
+	ctx := tenant.WithCompany(context.Background(), 1)
+
+	newClient := func(companyID uint64, phone, email string, addresses ...string) uint64 {
+		c := &client.Client{CompanyID: companyID, Name: "Jane", Phone: phone, Email: email}
+		if err := repo.Create(ctx, c); err != nil {
+			t.Fatal(err)
+		}
+		repo.addAddresses(t, c.ID, addresses...)
+		return c.ID
+	}
+	target := newClient(1, "+1555", "", "12 Main St")
+	first := newClient(1, "+1556", "jane@example.com", "12  main st", "3 Side St")
+	second := newClient(1, "+1557", "", "3 side st", "7 Park Ave")
+	other := newClient(2, "+1558", "", "1 Elsewhere")
+	repo.orders[100] = first
+	repo.orders[101] = second
+	repo.orders[102] = target
+	repo.orders[103] = other
+
+	if _, err := s.MergeClients(ctx, target, client.MergeClientsRequest{DuplicateIDs: []uint64{first, target}}); !errors.Is(err, client.ErrMergeIntoSelf) {
+		t.Errorf("MergeClients() into self error = %v, want %v", err, client.ErrMergeIntoSelf)
+	}
+	if _, err := s.MergeClients(ctx, target, client.MergeClientsRequest{DuplicateIDs: []uint64{other}}); !errors.Is(err, client.ErrClientNotFound) {
+		t.Errorf("MergeClients() from another company error = %v, want %v", err, client.ErrClientNotFound)
+	}
+
+	got, err := s.MergeClients(ctx, target, client.MergeClientsRequest{DuplicateIDs: []uint64{second, first, second}})
+	if err != nil {
+		t.Fatalf("MergeClients() error = %v", err)
+	}
+
+	addresses := []string{}
+	for _, a := range got.Addresses {
+		addresses = append(addresses, a.Address)
+	}
+	if want := []string{"12 Main St", "3 side st", "7 Park Ave"}; !reflect.DeepEqual(addresses, want) {
+		t.Errorf("addresses = %v, want %v", addresses, want)
+	}
+	if !got.Addresses[0].IsDefault || got.Address != "12 Main St" {
+		t.Errorf("default address = %q, want the target's", got.Address)
+	}
+	if got.Email != "jane@example.com" {
+		t.Errorf("email = %q, want the duplicate's", got.Email)
+	}
+
+	wantOrders := map[uint64]uint64{100: target, 101: target, 102: target, 103: other}
+	if !reflect.DeepEqual(repo.orders, wantOrders) {
+		t.Errorf("orders = %v, want %v", repo.orders, wantOrders)
+	}
+	for _, id := range []uint64{first, second} {
+		if _, ok := repo.clients[id]; ok {
+			t.Errorf("duplicate %d was not deleted", id)
+		}
+	}
+	if _, ok := repo.clients[other]; !ok {
+		t.Error("client of another company was deleted")
+	}
+}
@@ -7,6 +7,15 @@ import (
 	"math"
 
 	"my-go-driver/internal/domain/company"
+	"my-go-driver/internal/domain/loginguard"
+	"my-go-driver/internal/domain/mfa"
+	"my-go-driver/internal/domain/module"
+	"my-go-driver/internal/domain/plan"
+	"my-go-driver/internal/domain/quota"
+	"my-go-driver/internal/domain/rbac"
+	"my-go-driver/internal/domain/session"
+	"my-go-driver/internal/tenant"
+	"my-go-driver/internal/transaction"
 	"my-go-driver/pkg/hash"
 	"my-go-driver/pkg/jwt"
 
@@ -14,19 +23,35 @@ import (
 )
 
 type companyService struct {
-	repo      company.Repository
-	jwtSecret string
+	repo     company.Repository
+	sessions session.Service
+	mfa      mfa.Service
+	guard    loginguard.Service
+	quotas   quota.Service
+	plans    plan.Service
+	modules  module.Service
+	tx       transaction.Transactor
 }
 
 // NewCompanyService creates a new company service
-func NewCompanyService(repo company.Repository, jwtSecret string) company.Service {
+func NewCompanyService(repo company.Repository, sessions session.Service, mfaService mfa.Service, guard loginguard.Service, quotas quota.Service, plans plan.Service, modules module.Service, tx transaction.Transactor) company.Service {
 	return &companyService{
-		repo:      repo,
-		jwtSecret: jwtSecret,
+		repo:     repo,
+		sessions: sessions,
+		mfa:      mfaService,
+		guard:    guard,
+		quotas:   quotas,
+		plans:    plans,
+		modules:  modules,
+		tx:       tx,
 	}
 }
 
 func (s *companyService) CreateCompany(ctx context.Context, req company.CreateCompanyRequest) (*company.CompanyWithOwnerResponse, error) {
+	if err := tenant.RequirePlatform(ctx); err != nil {
+		return nil, err
+	}
+
 	// Check if admin email already exists
 	existingAdmin, err := s.repo.GetAdminByEmail(ctx, req.OwnerEmail)
 	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
@@ -74,13 +99,17 @@ func (s *companyService) CreateCompany(ctx context.Context, req company.CreateCo
 	if newCompany.BillingCycle == "" {
 		newCompany.BillingCycle = company.CycleMonthly
 	}
-	if newCompany.MaxAllowedDrivers == 0 {
-		newCompany.MaxAllowedDrivers = 10
-	}
 
-	if err := s.repo.Create(ctx, newCompany); err != nil {
-		return nil, fmt.Errorf("failed to create company: %w", err)
+	// Quotas start at the plan's defaults
+	p, ok := plan.Get(newCompany.Plan)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", plan.ErrUnknownPlan, newCompany.Plan)
+	}
+	if newCompany.MaxAllowedDrivers == 0 {
+		newCompany.MaxAllowedDrivers = p.MaxAllowedDrivers
 	}
+	newCompany.SeatsLimit = p.SeatsLimit
+	newCompany.APIRateLimit = p.APIRateLimit
 
 	// Hash password for owner
 	hashedPassword, err := hash.HashPassword(req.OwnerPassword)
@@ -88,9 +117,7 @@ func (s *companyService) CreateCompany(ctx context.Context, req company.CreateCo
 		return nil, fmt.Errorf("failed to hash password: %w", err)
 	}
 
-	// Create company owner
 	owner := &company.CompanyAdmin{
-		CompanyID:    newCompany.ID,
 		FullName:     req.OwnerName,
 		Email:        req.OwnerEmail,
 		Phone:        req.OwnerPhone,
@@ -99,10 +126,22 @@ func (s *companyService) CreateCompany(ctx context.Context, req company.CreateCo
 		IsActive:     true,
 	}
 
-	if err := s.repo.CreateAdmin(ctx, owner); err != nil {
-		// Rollback: delete the company
-		_ = s.repo.Delete(ctx, newCompany.ID)
-		return nil, fmt.Errorf("failed to create owner: %w", err)
+	// The company, its owner and its default modules are created together
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Create(ctx, newCompany); err != nil {
+			return fmt.Errorf("failed to create company: %w", err)
+		}
+
+		owner.CompanyID = newCompany.ID
+		if err := s.repo.CreateAdmin(ctx, owner); err != nil {
+			return fmt.Errorf("failed to create owner: %w", err)
+		}
+
+		_, err := s.modules.ProvisionDefaultModules(ctx, newCompany.ID, newCompany.Plan)
+		return err
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return &company.CompanyWithOwnerResponse{
@@ -112,11 +151,8 @@ func (s *companyService) CreateCompany(ctx context.Context, req company.CreateCo
 }
 
 func (s *companyService) GetCompany(ctx context.Context, id uint64) (*company.CompanyResponse, error) {
-	c, err := s.repo.GetByID(ctx, id)
+	c, err := s.findCompany(ctx, id)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, fmt.Errorf("company not found")
-		}
 		return nil, err
 	}
 
@@ -125,14 +161,29 @@ func (s *companyService) GetCompany(ctx context.Context, id uint64) (*company.Co
 }
 
 func (s *companyService) UpdateCompany(ctx context.Context, id uint64, req company.UpdateCompanyRequest) (*company.CompanyResponse, error) {
-	c, err := s.repo.GetByID(ctx, id)
+	c, err := s.findCompany(ctx, id)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, fmt.Errorf("company not found")
-		}
 		return nil, err
 	}
 
+	// Plan and limits are managed by platform operators only
+	if req.Plan != "" || req.SeatsLimit != nil || req.APIRateLimit != nil || req.MaxAllowedDrivers != nil {
+		if err := tenant.RequirePlatform(ctx); err != nil {
+			return nil, err
+		}
+	}
+	if req.BillingCycle != "" {
+		if err := rbac.Check(ctx, rbac.PermCompaniesBilling); err != nil {
+			return nil, err
+		}
+	}
+	// Only admins who can manage roles may change how owners must log in
+	if req.RequireOwnerMFA != nil {
+		if err := rbac.Check(ctx, rbac.PermRolesManage); err != nil {
+			return nil, err
+		}
+	}
+
 	// Update fields
 	if req.Name != "" {
 		c.Name = req.Name
@@ -197,8 +248,35 @@ func (s *companyService) UpdateCompany(ctx context.Context, id uint64, req compa
 	if req.MaxAllowedDrivers != nil {
 		c.MaxAllowedDrivers = *req.MaxAllowedDrivers
 	}
-	if req.Plan != "" {
+
+	// A new plan must include every assigned module. Quotas not set in the
+	// request and still at the old plan's defaults move to the new plan's.
+	var planChange *company.PlanChange
+	if req.Plan != "" && req.Plan != c.Plan {
+		planChange, err = s.plans.PreviewChange(ctx, c.ID, c.Plan, req.Plan)
+		if err != nil {
+			return nil, err
+		}
+		if len(planChange.ModulesToRemove) > 0 {
+			return nil, &plan.ModulesNotInPlanError{
+				Code:            plan.CodeModulesNotInPlan,
+				Plan:            req.Plan,
+				ModulesToRemove: planChange.ModulesToRemove,
+			}
+		}
+
+		from, _ := plan.Get(c.Plan)
+		to, _ := plan.Get(req.Plan)
 		c.Plan = req.Plan
+		if req.MaxAllowedDrivers == nil {
+			c.MaxAllowedDrivers = planDefault(planChange, "max_allowed_drivers", c.MaxAllowedDrivers, from.MaxAllowedDrivers, to.MaxAllowedDrivers)
+		}
+		if req.SeatsLimit == nil {
+			c.SeatsLimit = planDefault(planChange, "seats_limit", c.SeatsLimit, from.SeatsLimit, to.SeatsLimit)
+		}
+		if req.APIRateLimit == nil {
+			c.APIRateLimit = planDefault(planChange, "api_rate_limit", c.APIRateLimit, from.APIRateLimit, to.APIRateLimit)
+		}
 	}
 	if req.BillingCycle != "" {
 		c.BillingCycle = req.BillingCycle
@@ -209,21 +287,38 @@ func (s *companyService) UpdateCompany(ctx context.Context, id uint64, req compa
 	if req.APIRateLimit != nil {
 		c.APIRateLimit = *req.APIRateLimit
 	}
+	if req.RequireOwnerMFA != nil {
+		c.RequireOwnerMFA = *req.RequireOwnerMFA
+	}
+
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		// Limits cannot be lowered below what the company already uses
+		if req.MaxAllowedDrivers != nil || req.SeatsLimit != nil || planChange != nil {
+			if err := s.quotas.CheckLimits(ctx, c.ID, c.MaxAllowedDrivers, c.SeatsLimit); err != nil {
+				return err
+			}
+		}
 
-	if err := s.repo.Update(ctx, c); err != nil {
-		return nil, fmt.Errorf("failed to update company: %w", err)
+		if err := s.repo.Update(ctx, c); err != nil {
+			return fmt.Errorf("failed to update company: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	response := s.toCompanyResponse(c)
+	response.PlanChange = planChange
 	return &response, nil
 }
 
 func (s *companyService) DeleteCompany(ctx context.Context, id uint64) error {
-	_, err := s.repo.GetByID(ctx, id)
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return fmt.Errorf("company not found")
-		}
+	if err := tenant.RequirePlatform(ctx); err != nil {
+		return err
+	}
+
+	if _, err := s.findCompany(ctx, id); err != nil {
 		return err
 	}
 
@@ -231,6 +326,11 @@ func (s *companyService) DeleteCompany(ctx context.Context, id uint64) error {
 }
 
 func (s *companyService) ListCompanies(ctx context.Context, query company.ListCompaniesQuery) (*company.PaginatedCompaniesResponse, error) {
+	// Company admins only ever see their own company
+	if companyID, ok := tenant.CompanyID(ctx); ok {
+		query.ID = companyID
+	}
+
 	companies, total, err := s.repo.List(ctx, query)
 	if err != nil {
 		return nil, err
@@ -261,10 +361,11 @@ func (s *companyService) ListCompanies(ctx context.Context, query company.ListCo
 }
 
 func (s *companyService) UpdateBranding(ctx context.Context, id uint64, req company.UpdateBrandingRequest) (*company.CompanyResponse, error) {
+	if _, err := s.findCompany(ctx, id); err != nil {
+		return nil, err
+	}
+
 	if err := s.repo.UpdateBranding(ctx, id, req); err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, fmt.Errorf("company not found")
-		}
 		return nil, fmt.Errorf("failed to update branding: %w", err)
 	}
 
@@ -273,23 +374,51 @@ func (s *companyService) UpdateBranding(ctx context.Context, id uint64, req comp
 }
 
 func (s *companyService) SuspendCompany(ctx context.Context, id uint64) error {
-	return s.repo.UpdateStatus(ctx, id, company.CompanyStatusSuspended)
+	if err := tenant.RequirePlatform(ctx); err != nil {
+		return err
+	}
+
+	if _, err := s.findCompany(ctx, id); err != nil {
+		return err
+	}
+
+	if err := s.repo.UpdateStatus(ctx, id, company.CompanyStatusSuspended); err != nil {
+		return err
+	}
+
+	// End every admin and driver session belonging to the company
+	return s.sessions.RevokeCompany(ctx, id, session.RevokeReasonCompanySuspended)
 }
 
 func (s *companyService) ActivateCompany(ctx context.Context, id uint64) error {
+	if err := tenant.RequirePlatform(ctx); err != nil {
+		return err
+	}
+
+	if _, err := s.findCompany(ctx, id); err != nil {
+		return err
+	}
+
 	return s.repo.UpdateStatus(ctx, id, company.CompanyStatusActive)
 }
 
+func (s *companyService) CheckCompanyActive(ctx context.Context, id uint64) error {
+	return checkCompanyActive(ctx, s.repo, id)
+}
+
 func (s *companyService) CreateAdmin(ctx context.Context, req company.CreateAdminRequest) (*company.CompanyAdminResponse, error) {
 	// Check if company exists
-	_, err := s.repo.GetByID(ctx, req.CompanyID)
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, fmt.Errorf("company not found")
-		}
+	if _, err := s.findCompany(ctx, req.CompanyID); err != nil {
 		return nil, err
 	}
 
+	// Only admins who can manage roles may create further owners
+	if req.Role == company.AdminRoleOwner {
+		if err := rbac.Check(ctx, rbac.PermRolesManage); err != nil {
+			return nil, err
+		}
+	}
+
 	// Check if admin email already exists
 	existingAdmin, err := s.repo.GetAdminByEmail(ctx, req.Email)
 	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
@@ -316,8 +445,18 @@ func (s *companyService) CreateAdmin(ctx context.Context, req company.CreateAdmi
 		IsActive:     true,
 	}
 
-	if err := s.repo.CreateAdmin(ctx, admin); err != nil {
-		return nil, fmt.Errorf("failed to create admin: %w", err)
+	// Count the company's seats and add the admin under the company lock
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.quotas.CheckSeats(ctx, req.CompanyID, 1); err != nil {
+			return err
+		}
+		if err := s.repo.CreateAdmin(ctx, admin); err != nil {
+			return fmt.Errorf("failed to create admin: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	response := s.toAdminResponse(admin)
@@ -325,50 +464,276 @@ func (s *companyService) CreateAdmin(ctx context.Context, req company.CreateAdmi
 }
 
 func (s *companyService) LoginAdmin(ctx context.Context, req company.LoginRequest) (*company.LoginResponse, error) {
+	attempt := loginguard.Attempt{
+		SubjectType: jwt.SubjectAdmin,
+		Identifier:  req.Email,
+		IPAddress:   req.IPAddress,
+		UserAgent:   req.UserAgent,
+	}
+	if err := s.guard.Check(ctx, attempt); err != nil {
+		return nil, err
+	}
+
 	admin, err := s.repo.GetAdminByEmail(ctx, req.Email)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, fmt.Errorf("invalid email or password")
+			hash.CheckDummy(req.Password)
+			return nil, s.loginFailed(ctx, attempt)
 		}
 		return nil, err
 	}
+	attempt.CompanyID = &admin.CompanyID
+	attempt.SubjectID = &admin.ID
 
-	// Check if admin is active
+	// Verify password
+	if !hash.CheckPasswordHash(req.Password, admin.PasswordHash) {
+		return nil, s.loginFailed(ctx, attempt)
+	}
+
+	// Inactive accounts fail like wrong passwords, so they cannot be told apart
 	if !admin.IsActive {
-		return nil, fmt.Errorf("account is inactive")
+		return nil, s.loginFailed(ctx, attempt)
 	}
 
-	// Verify password
-	if !hash.CheckPasswordHash(req.Password, admin.PasswordHash) {
-		return nil, fmt.Errorf("invalid email or password")
+	if err := checkCompanyActive(ctx, s.repo, admin.CompanyID); err != nil {
+		return nil, err
 	}
 
-	// Generate JWT token
-	token, err := jwt.GenerateToken(admin.ID, s.jwtSecret)
+	// Admins with two-factor authentication get a challenge instead of tokens.
+	// Failures are only cleared once the second factor has been passed too.
+	challenge, err := s.mfa.BeginLogin(ctx, admin)
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate token: %w", err)
+		return nil, err
+	}
+	if challenge != nil {
+		return &company.LoginResponse{
+			MFARequired: true,
+			MFA:         challenge,
+		}, nil
 	}
 
-	return &company.LoginResponse{
-		Admin: s.toAdminResponse(admin),
-		Token: token,
-	}, nil
+	if err := s.guard.Succeeded(ctx, attempt); err != nil {
+		return nil, err
+	}
+	return s.startSession(ctx, admin)
 }
 
-func (s *companyService) GetAdminProfile(ctx context.Context, adminID uint64) (*company.CompanyAdminResponse, error) {
+func (s *companyService) CompleteMFALogin(ctx context.Context, req company.MFALoginRequest) (*company.LoginResponse, error) {
+	adminID, err := s.mfa.ChallengeAdmin(ctx, req.MFAToken)
+	if err != nil {
+		return nil, err
+	}
+
 	admin, err := s.repo.GetAdminByID(ctx, adminID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, fmt.Errorf("admin not found")
+			return nil, mfa.ErrInvalidChallenge
 		}
 		return nil, err
 	}
 
+	// Wrong codes count towards the same lockout as wrong passwords
+	attempt := loginguard.Attempt{
+		SubjectType: jwt.SubjectAdmin,
+		Identifier:  admin.Email,
+		IPAddress:   req.IPAddress,
+		UserAgent:   req.UserAgent,
+		CompanyID:   &admin.CompanyID,
+		SubjectID:   &admin.ID,
+	}
+	if err := s.guard.Check(ctx, attempt); err != nil {
+		return nil, err
+	}
+
+	// The account may have been deactivated since the password step
+	if !admin.IsActive {
+		return nil, fmt.Errorf("account is inactive")
+	}
+	if err := checkCompanyActive(ctx, s.repo, admin.CompanyID); err != nil {
+		return nil, err
+	}
+
+	recoveryCodes, err := s.mfa.CompleteChallenge(ctx, req.MFAToken, req.Code)
+	if err != nil {
+		if errors.Is(err, mfa.ErrInvalidCode) {
+			if err := s.guard.Failed(ctx, attempt); err != nil {
+				return nil, err
+			}
+		}
+		return nil, err
+	}
+
+	if err := s.guard.Succeeded(ctx, attempt); err != nil {
+		return nil, err
+	}
+
+	response, err := s.startSession(ctx, admin)
+	if err != nil {
+		return nil, err
+	}
+	response.RecoveryCodes = recoveryCodes
+	return response, nil
+}
+
+func (s *companyService) GetAdminProfile(ctx context.Context, adminID uint64) (*company.CompanyAdminResponse, error) {
+	admin, err := s.findAdmin(ctx, adminID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := s.toAdminResponse(admin)
+	return &response, nil
+}
+
+func (s *companyService) DeactivateAdmin(ctx context.Context, adminID uint64) (*company.CompanyAdminResponse, error) {
+	admin, err := s.findAdmin(ctx, adminID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only admins who can manage roles may deactivate owners
+	if admin.Role == company.AdminRoleOwner {
+		if err := rbac.Check(ctx, rbac.PermRolesManage); err != nil {
+			return nil, err
+		}
+	}
+
+	admin.IsActive = false
+	if err := s.repo.UpdateAdmin(ctx, admin); err != nil {
+		return nil, fmt.Errorf("failed to deactivate admin: %w", err)
+	}
+
+	if err := s.sessions.RevokeAll(ctx, jwt.SubjectAdmin, admin.ID, session.RevokeReasonDeactivated); err != nil {
+		return nil, err
+	}
+
+	response := s.toAdminResponse(admin)
+	return &response, nil
+}
+
+func (s *companyService) ActivateAdmin(ctx context.Context, adminID uint64) (*company.CompanyAdminResponse, error) {
+	admin, err := s.findAdmin(ctx, adminID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only admins who can manage roles may reactivate owners
+	if admin.Role == company.AdminRoleOwner {
+		if err := rbac.Check(ctx, rbac.PermRolesManage); err != nil {
+			return nil, err
+		}
+	}
+
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		// A reactivated admin takes up a seat again
+		if !admin.IsActive {
+			if err := s.quotas.CheckSeats(ctx, admin.CompanyID, 1); err != nil {
+				return err
+			}
+		}
+
+		admin.IsActive = true
+		if err := s.repo.UpdateAdmin(ctx, admin); err != nil {
+			return fmt.Errorf("failed to activate admin: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
 	response := s.toAdminResponse(admin)
 	return &response, nil
 }
 
 // Helper methods
+
+// planDefault returns a quota after a plan change. A quota still at the old
+// plan's default moves to the new plan's, and the change is recorded; a quota
+// negotiated for the company is kept.
+func planDefault(change *company.PlanChange, quota string, current, from, to int) int {
+	if current != from || from == to {
+		return current
+	}
+	change.QuotaChanges = append(change.QuotaChanges, company.QuotaChange{Quota: quota, From: from, To: to})
+	return to
+}
+
+// loginFailed records a failed login and returns the error shown to the caller
+func (s *companyService) loginFailed(ctx context.Context, attempt loginguard.Attempt) error {
+	if err := s.guard.Failed(ctx, attempt); err != nil {
+		return err
+	}
+	return fmt.Errorf("invalid email or password")
+}
+
+// checkCompanyActive returns ErrCompanySuspended unless the company is active
+func checkCompanyActive(ctx context.Context, companies company.Repository, companyID uint64) error {
+	c, err := companies.GetByID(ctx, companyID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return company.ErrCompanyNotFound
+		}
+		return err
+	}
+	if c.Status != company.CompanyStatusActive {
+		return company.ErrCompanySuspended
+	}
+	return nil
+}
+
+// startSession starts a session for an authenticated admin and issues tokens
+func (s *companyService) startSession(ctx context.Context, admin *company.CompanyAdmin) (*company.LoginResponse, error) {
+	tokens, err := s.sessions.Issue(ctx, jwt.Subject{
+		Type:      jwt.SubjectAdmin,
+		ID:        admin.ID,
+		CompanyID: admin.CompanyID,
+		Role:      string(admin.Role),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := s.toAdminResponse(admin)
+	return &company.LoginResponse{
+		Admin:        &response,
+		Token:        tokens.Token,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresAt:    &tokens.ExpiresAt,
+	}, nil
+}
+
+// findAdmin loads a company admin, hiding admins outside the caller's tenant
+func (s *companyService) findAdmin(ctx context.Context, id uint64) (*company.CompanyAdmin, error) {
+	admin, err := s.repo.GetAdminByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, company.ErrAdminNotFound
+		}
+		return nil, err
+	}
+	if !tenant.Allows(ctx, admin.CompanyID) {
+		return nil, company.ErrAdminNotFound
+	}
+	return admin, nil
+}
+
+// findCompany loads a company, hiding companies outside the caller's tenant
+func (s *companyService) findCompany(ctx context.Context, id uint64) (*company.Company, error) {
+	if !tenant.Allows(ctx, id) {
+		return nil, company.ErrCompanyNotFound
+	}
+
+	c, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, company.ErrCompanyNotFound
+		}
+		return nil, err
+	}
+	return c, nil
+}
+
 func (s *companyService) toCompanyResponse(c *company.Company) company.CompanyResponse {
 	return company.CompanyResponse{
 		ID:                    c.ID,
@@ -403,6 +768,7 @@ func (s *companyService) toCompanyResponse(c *company.Company) company.CompanyRe
 		BillingCycle:          c.BillingCycle,
 		SeatsLimit:            c.SeatsLimit,
 		APIRateLimit:          c.APIRateLimit,
+		RequireOwnerMFA:       c.RequireOwnerMFA,
 		Status:                c.Status,
 		CreatedAt:             c.CreatedAt,
 		UpdatedAt:             c.UpdatedAt,
@@ -411,14 +777,16 @@ func (s *companyService) toCompanyResponse(c *company.Company) company.CompanyRe
 
 func (s *companyService) toAdminResponse(admin *company.CompanyAdmin) company.CompanyAdminResponse {
 	return company.CompanyAdminResponse{
-		ID:        admin.ID,
-		CompanyID: admin.CompanyID,
-		FullName:  admin.FullName,
-		Email:     admin.Email,
-		Phone:     admin.Phone,
-		Role:      admin.Role,
-		IsActive:  admin.IsActive,
-		CreatedAt: admin.CreatedAt,
-		UpdatedAt: admin.UpdatedAt,
+		ID:              admin.ID,
+		CompanyID:       admin.CompanyID,
+		FullName:        admin.FullName,
+		Email:           admin.Email,
+		Phone:           admin.Phone,
+		Role:            admin.Role,
+		CustomRoleID:    admin.CustomRoleID,
+		IsActive:        admin.IsActive,
+		EmailVerifiedAt: admin.EmailVerifiedAt,
+		CreatedAt:       admin.CreatedAt,
+		UpdatedAt:       admin.UpdatedAt,
 	}
 }
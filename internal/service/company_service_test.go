@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"my-go-driver/internal/domain/company"
+	"my-go-driver/internal/domain/rbac"
+	"my-go-driver/internal/tenant"
+
+	"gorm.io/gorm"
+)
+
+// memoryCompanyRepository keeps companies and their admins in memory
+type memoryCompanyRepository struct {
+	companies map[uint64]company.Company
+	admins    map[uint64]company.CompanyAdmin
+}
+
+func newMemoryCompanyRepository(companies ...company.Company) *memoryCompanyRepository {
+	r := &memoryCompanyRepository{
+		companies: make(map[uint64]company.Company),
+		admins:    make(map[uint64]company.CompanyAdmin),
+	}
+	for _, c := range companies {
+		r.companies[c.ID] = c
+	}
+	return r
+}
+
+func (r *memoryCompanyRepository) Create(ctx context.Context, c *company.Company) error {
+	c.ID = uint64(len(r.companies) + 1)
+	r.companies[c.ID] = *c
+	return nil
+}
+
+func (r *memoryCompanyRepository) GetByID(ctx context.Context, id uint64) (*company.Company, error) {
+	c, ok := r.companies[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &c, nil
+}
+
+func (r *memoryCompanyRepository) GetByIDForUpdate(ctx context.Context, id uint64) (*company.Company, error) {
+	return r.GetByID(ctx, id)
+}
+
+func (r *memoryCompanyRepository) Update(ctx context.Context, c *company.Company) error {
+	r.companies[c.ID] = *c
+	return nil
+}
+
+func (r *memoryCompanyRepository) Delete(ctx context.Context, id uint64) error {
+	delete(r.companies, id)
+	return nil
+}
+
+func (r *memoryCompanyRepository) List(ctx context.Context, query company.ListCompaniesQuery) ([]company.Company, int64, error) {
+	return nil, 0, errors.New("not implemented")
+}
+
+func (r *memoryCompanyRepository) UpdateBranding(ctx context.Context, id uint64, branding company.UpdateBrandingRequest) error {
+	return errors.New("not implemented")
+}
+
+func (r *memoryCompanyRepository) UpdateStatus(ctx context.Context, id uint64, status company.CompanyStatus) error {
+	c, ok := r.companies[id]
+	if !ok {
+		return gorm.ErrRecordNotFound
+	}
+	c.Status = status
+	r.companies[id] = c
+	return nil
+}
+
+func (r *memoryCompanyRepository) CreateAdmin(ctx context.Context, admin *company.CompanyAdmin) error {
+	admin.ID = uint64(len(r.admins) + 1)
+	r.admins[admin.ID] = *admin
+	return nil
+}
+
+func (r *memoryCompanyRepository) GetAdminByEmail(ctx context.Context, email string) (*company.CompanyAdmin, error) {
+	for _, admin := range r.admins {
+		if admin.Email == email {
+			return &admin, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *memoryCompanyRepository) GetAdminByID(ctx context.Context, id uint64) (*company.CompanyAdmin, error) {
+	admin, ok := r.admins[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &admin, nil
+}
+
+func (r *memoryCompanyRepository) UpdateAdmin(ctx context.Context, admin *company.CompanyAdmin) error {
+	r.admins[admin.ID] = *admin
+	return nil
+}
+
+func (r *memoryCompanyRepository) ListAdmins(ctx context.Context, companyID uint64) ([]company.CompanyAdmin, error) {
+	var admins []company.CompanyAdmin
+	for _, admin := range r.admins {
+		if admin.CompanyID == companyID {
+			admins = append(admins, admin)
+		}
+	}
+	return admins, nil
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func TestCompanyServiceUpdateRequireOwnerMFA(t *testing.T) {
+	tests := []struct {
+		name        string
+		permissions []rbac.Permission
+		wantErr     error
+	}{
+		{
+			name:        "companies.write alone is not enough",
+			permissions: []rbac.Permission{rbac.PermCompaniesWrite},
+			wantErr:     rbac.ErrPermissionDenied,
+		},
+		{
+			name:        "roles.manage may change it",
+			permissions: []rbac.Permission{rbac.PermCompaniesWrite, rbac.PermRolesManage},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newMemoryCompanyRepository(company.Company{ID: 1, Name: "Acme", RequireOwnerMFA: true})
+			s := NewCompanyService(repo, nil, nil, nil, nil, nil, nil, directTransactor{})
+
+			ctx := tenant.WithCompany(context.Background(), 1)
+			ctx = rbac.WithPermissions(ctx, rbac.NewPermissionSet(tt.permissions))
+			_, err := s.UpdateCompany(ctx, 1, company.UpdateCompanyRequest{RequireOwnerMFA: boolPtr(false)})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("UpdateCompany error = %v, want %v", err, tt.wantErr)
+			}
+
+			wantRequired := tt.wantErr != nil
+			if got := repo.companies[1].RequireOwnerMFA; got != wantRequired {
+				t.Fatalf("RequireOwnerMFA = %v, want %v", got, wantRequired)
+			}
+		})
+	}
+}
@@ -6,27 +6,56 @@ import (
 	"fmt"
 	"math"
 
+	"my-go-driver/internal/domain/company"
 	"my-go-driver/internal/domain/driver"
+	"my-go-driver/internal/domain/loginguard"
+	"my-go-driver/internal/domain/quota"
+	"my-go-driver/internal/domain/session"
 	"my-go-driver/internal/domain/shift"
+	"my-go-driver/internal/domain/store"
+	"my-go-driver/internal/domain/vehicle"
+	"my-go-driver/internal/tenant"
+	"my-go-driver/internal/transaction"
 	"my-go-driver/pkg/hash"
+	"my-go-driver/pkg/jwt"
 
 	"gorm.io/gorm"
 )
 
 type driverService struct {
-	repo      driver.Repository
-	shiftRepo shift.Repository
+	repo        driver.Repository
+	companyRepo company.Repository
+	shiftRepo   shift.Repository
+	sessions    session.Service
+	guard       loginguard.Service
+	quotas      quota.Service
+	stores      store.Service
+	vehicles    vehicle.Service
+	tx          transaction.Transactor
 }
 
-// NewDriverService creates a new driver service
-func NewDriverService(repo driver.Repository, shiftRepo shift.Repository) driver.Service {
+// NewDriverService creates a new driver service. Drivers can only be assigned
+// to active stores of their own company, and lose their vehicle when they are
+// blocked or deleted.
+func NewDriverService(repo driver.Repository, companyRepo company.Repository, shiftRepo shift.Repository, sessions session.Service, guard loginguard.Service, quotas quota.Service, stores store.Service, vehicles vehicle.Service, tx transaction.Transactor) driver.Service {
 	return &driverService{
-		repo:      repo,
-		shiftRepo: shiftRepo,
+		repo:        repo,
+		companyRepo: companyRepo,
+		shiftRepo:   shiftRepo,
+		sessions:    sessions,
+		guard:       guard,
+		quotas:      quotas,
+		stores:      stores,
+		vehicles:    vehicles,
+		tx:          tx,
 	}
 }
 
 func (s *driverService) CreateDriver(ctx context.Context, req driver.CreateDriverRequest) (*driver.DriverResponse, error) {
+	if !tenant.Allows(ctx, req.CompanyID) {
+		return nil, company.ErrCompanyNotFound
+	}
+
 	// Check if phone already exists for this company
 	existingDriver, err := s.repo.GetByPhone(ctx, req.Phone, req.CompanyID)
 	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
@@ -36,6 +65,10 @@ func (s *driverService) CreateDriver(ctx context.Context, req driver.CreateDrive
 		return nil, fmt.Errorf("driver with this phone already exists in this company")
 	}
 
+	if err := s.checkStore(ctx, req.CompanyID, req.StoreID); err != nil {
+		return nil, err
+	}
+
 	// Hash password
 	hashedPassword, err := hash.HashPassword(req.Password)
 	if err != nil {
@@ -56,8 +89,18 @@ func (s *driverService) CreateDriver(ctx context.Context, req driver.CreateDrive
 		Rating:       0.0,
 	}
 
-	if err := s.repo.Create(ctx, newDriver); err != nil {
-		return nil, fmt.Errorf("failed to create driver: %w", err)
+	// Count the company's drivers and add the driver under the company lock
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.quotas.CheckDrivers(ctx, req.CompanyID, 1); err != nil {
+			return err
+		}
+		if err := s.repo.Create(ctx, newDriver); err != nil {
+			return fmt.Errorf("failed to create driver: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	response := s.toDriverResponse(newDriver)
@@ -65,11 +108,8 @@ func (s *driverService) CreateDriver(ctx context.Context, req driver.CreateDrive
 }
 
 func (s *driverService) GetDriver(ctx context.Context, id uint64) (*driver.DriverResponse, error) {
-	d, err := s.repo.GetByID(ctx, id)
+	d, err := s.findDriver(ctx, id)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, fmt.Errorf("driver not found")
-		}
 		return nil, err
 	}
 
@@ -78,11 +118,8 @@ func (s *driverService) GetDriver(ctx context.Context, id uint64) (*driver.Drive
 }
 
 func (s *driverService) UpdateDriver(ctx context.Context, id uint64, req driver.UpdateDriverRequest) (*driver.DriverResponse, error) {
-	d, err := s.repo.GetByID(ctx, id)
+	d, err := s.findDriver(ctx, id)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, fmt.Errorf("driver not found")
-		}
 		return nil, err
 	}
 
@@ -104,15 +141,30 @@ func (s *driverService) UpdateDriver(ctx context.Context, id uint64, req driver.
 	if req.Email != "" {
 		d.Email = req.Email
 	}
-	if req.StoreID != nil {
+	storeChanged := req.StoreID != nil && !sameStore(d.StoreID, req.StoreID)
+	if storeChanged {
+		if err := s.checkStore(ctx, d.CompanyID, req.StoreID); err != nil {
+			return nil, err
+		}
 		d.StoreID = req.StoreID
 	}
 	if req.ProfilePhoto != "" {
 		d.ProfilePhoto = req.ProfilePhoto
 	}
 
-	if err := s.repo.Update(ctx, d); err != nil {
-		return nil, fmt.Errorf("failed to update driver: %w", err)
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if storeChanged {
+			if err := s.vehicles.ReleaseAutoAssignment(ctx, d.ID); err != nil {
+				return err
+			}
+		}
+		if err := s.repo.Update(ctx, d); err != nil {
+			return fmt.Errorf("failed to update driver: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	response := s.toDriverResponse(d)
@@ -120,18 +172,25 @@ func (s *driverService) UpdateDriver(ctx context.Context, id uint64, req driver.
 }
 
 func (s *driverService) DeleteDriver(ctx context.Context, id uint64) error {
-	_, err := s.repo.GetByID(ctx, id)
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return fmt.Errorf("driver not found")
-		}
+	if _, err := s.findDriver(ctx, id); err != nil {
 		return err
 	}
 
-	return s.repo.Delete(ctx, id)
+	return s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		// Free the driver's vehicle for other drivers
+		if err := s.vehicles.ReleaseDriver(ctx, id); err != nil {
+			return err
+		}
+		return s.repo.Delete(ctx, id)
+	})
 }
 
 func (s *driverService) ListDrivers(ctx context.Context, query driver.ListDriversQuery) (*driver.PaginatedDriversResponse, error) {
+	// Company admins only ever see their own drivers
+	if companyID, ok := tenant.CompanyID(ctx); ok {
+		query.CompanyID = companyID
+	}
+
 	drivers, total, err := s.repo.List(ctx, query)
 	if err != nil {
 		return nil, err
@@ -162,19 +221,50 @@ func (s *driverService) ListDrivers(ctx context.Context, query driver.ListDriver
 }
 
 func (s *driverService) AssignToCompany(ctx context.Context, driverID uint64, req driver.AssignDriverToCompanyRequest) (*driver.DriverResponse, error) {
-	d, err := s.repo.GetByID(ctx, driverID)
+	d, err := s.findDriver(ctx, driverID)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, fmt.Errorf("driver not found")
-		}
 		return nil, err
 	}
 
-	d.CompanyID = req.CompanyID
-	d.StoreID = req.StoreID
+	if !tenant.Allows(ctx, req.CompanyID) {
+		return nil, company.ErrCompanyNotFound
+	}
 
-	if err := s.repo.Update(ctx, d); err != nil {
-		return nil, fmt.Errorf("failed to assign driver: %w", err)
+	if err := s.checkStore(ctx, req.CompanyID, req.StoreID); err != nil {
+		return nil, err
+	}
+
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		// Suspended drivers do not count towards the new company's quota
+		if d.CompanyID != req.CompanyID && d.Status != driver.DriverStatusSuspended {
+			if err := s.quotas.CheckDrivers(ctx, req.CompanyID, 1); err != nil {
+				return err
+			}
+		}
+
+		// Vehicles belong to the old company; a vehicle picked at shift start
+		// belongs to the old store
+		switch {
+		case d.CompanyID != req.CompanyID:
+			if err := s.vehicles.ReleaseDriver(ctx, d.ID); err != nil {
+				return err
+			}
+		case !sameStore(d.StoreID, req.StoreID):
+			if err := s.vehicles.ReleaseAutoAssignment(ctx, d.ID); err != nil {
+				return err
+			}
+		}
+
+		d.CompanyID = req.CompanyID
+		d.StoreID = req.StoreID
+
+		if err := s.repo.Update(ctx, d); err != nil {
+			return fmt.Errorf("failed to assign driver: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	response := s.toDriverResponse(d)
@@ -182,20 +272,46 @@ func (s *driverService) AssignToCompany(ctx context.Context, driverID uint64, re
 }
 
 func (s *driverService) BlockDriver(ctx context.Context, driverID uint64) error {
-	return s.repo.UpdateStatus(ctx, driverID, driver.DriverStatusSuspended)
+	if _, err := s.findDriver(ctx, driverID); err != nil {
+		return err
+	}
+
+	return s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.UpdateStatus(ctx, driverID, driver.DriverStatusSuspended); err != nil {
+			return err
+		}
+
+		// Free the driver's vehicle for other drivers
+		if err := s.vehicles.ReleaseDriver(ctx, driverID); err != nil {
+			return err
+		}
+
+		// Log the driver out of every device
+		return s.sessions.RevokeAll(ctx, jwt.SubjectDriver, driverID, session.RevokeReasonDriverBlocked)
+	})
 }
 
 func (s *driverService) UnblockDriver(ctx context.Context, driverID uint64) error {
-	return s.repo.UpdateStatus(ctx, driverID, driver.DriverStatusActive)
+	d, err := s.findDriver(ctx, driverID)
+	if err != nil {
+		return err
+	}
+
+	return s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		// An unblocked driver counts towards the quota again
+		if d.Status == driver.DriverStatusSuspended {
+			if err := s.quotas.CheckDrivers(ctx, d.CompanyID, 1); err != nil {
+				return err
+			}
+		}
+
+		return s.repo.UpdateStatus(ctx, driverID, driver.DriverStatusActive)
+	})
 }
 
 func (s *driverService) GetDriverPerformance(ctx context.Context, driverID uint64) (*driver.DriverPerformance, error) {
 	// Check if driver exists
-	_, err := s.repo.GetByID(ctx, driverID)
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, fmt.Errorf("driver not found")
-		}
+	if _, err := s.findDriver(ctx, driverID); err != nil {
 		return nil, err
 	}
 
@@ -207,7 +323,150 @@ func (s *driverService) GetDriverPerformance(ctx context.Context, driverID uint6
 	return performance, nil
 }
 
+func (s *driverService) LoginDriver(ctx context.Context, req driver.LoginRequest) (*driver.LoginResponse, error) {
+	// Phone numbers are only unique within a company
+	attempt := loginguard.Attempt{
+		SubjectType: jwt.SubjectDriver,
+		Identifier:  fmt.Sprintf("%d:%s", req.CompanyID, req.Phone),
+		IPAddress:   req.IPAddress,
+		UserAgent:   req.UserAgent,
+	}
+	if err := s.guard.Check(ctx, attempt); err != nil {
+		return nil, err
+	}
+
+	d, err := s.repo.GetByPhone(ctx, req.Phone, req.CompanyID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			hash.CheckDummy(req.Password)
+			return nil, s.loginFailed(ctx, attempt)
+		}
+		return nil, err
+	}
+	attempt.CompanyID = &d.CompanyID
+	attempt.SubjectID = &d.ID
+
+	// Verify password
+	if !hash.CheckPasswordHash(req.Password, d.PasswordHash) {
+		return nil, s.loginFailed(ctx, attempt)
+	}
+
+	// Suspended drivers fail like wrong passwords, so they cannot be told apart
+	if d.Status == driver.DriverStatusSuspended {
+		return nil, s.loginFailed(ctx, attempt)
+	}
+	if err := checkCompanyActive(ctx, s.companyRepo, d.CompanyID); err != nil {
+		return nil, err
+	}
+
+	if err := s.guard.Succeeded(ctx, attempt); err != nil {
+		return nil, err
+	}
+
+	// Start a driver session and issue tokens
+	tokens, err := s.sessions.Issue(ctx, jwt.Subject{
+		Type:      jwt.SubjectDriver,
+		ID:        d.ID,
+		CompanyID: d.CompanyID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &driver.LoginResponse{
+		Driver:       s.toDriverResponse(d),
+		Token:        tokens.Token,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresAt:    tokens.ExpiresAt,
+	}, nil
+}
+
+func (s *driverService) UpdateProfile(ctx context.Context, driverID uint64, req driver.UpdateProfileRequest) (*driver.DriverResponse, error) {
+	d, err := s.findDriver(ctx, driverID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Update fields
+	if req.FullName != "" {
+		d.FullName = req.FullName
+	}
+	if req.Email != "" {
+		d.Email = req.Email
+	}
+	if req.ProfilePhoto != "" {
+		d.ProfilePhoto = req.ProfilePhoto
+	}
+
+	if err := s.repo.Update(ctx, d); err != nil {
+		return nil, fmt.Errorf("failed to update profile: %w", err)
+	}
+
+	response := s.toDriverResponse(d)
+	return &response, nil
+}
+
+func (s *driverService) SetOnlineStatus(ctx context.Context, driverID uint64, status driver.OnlineStatus) (*driver.DriverResponse, error) {
+	d, err := s.findDriver(ctx, driverID)
+	if err != nil {
+		return nil, err
+	}
+
+	if d.Status == driver.DriverStatusSuspended {
+		return nil, driver.ErrDriverSuspended
+	}
+
+	if err := s.repo.UpdateOnlineStatus(ctx, driverID, status); err != nil {
+		return nil, fmt.Errorf("failed to update online status: %w", err)
+	}
+
+	d.OnlineStatus = status
+	response := s.toDriverResponse(d)
+	return &response, nil
+}
+
 // Helper methods
+
+// loginFailed records a failed login and returns the error shown to the caller
+func (s *driverService) loginFailed(ctx context.Context, attempt loginguard.Attempt) error {
+	if err := s.guard.Failed(ctx, attempt); err != nil {
+		return err
+	}
+	return fmt.Errorf("invalid phone or password")
+}
+
+// checkStore verifies that a driver of the company can be assigned to the
+// store, if one is given
+func (s *driverService) checkStore(ctx context.Context, companyID uint64, storeID *uint64) error {
+	if storeID == nil {
+		return nil
+	}
+	return s.stores.CheckAssignable(ctx, companyID, *storeID)
+}
+
+// sameStore reports whether both store IDs are unset or equal
+func sameStore(a, b *uint64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// findDriver loads a driver, hiding drivers outside the caller's tenant
+func (s *driverService) findDriver(ctx context.Context, id uint64) (*driver.Driver, error) {
+	d, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, driver.ErrDriverNotFound
+		}
+		return nil, err
+	}
+	if !tenant.Allows(ctx, d.CompanyID) {
+		return nil, driver.ErrDriverNotFound
+	}
+	return d, nil
+}
+
 func (s *driverService) toDriverResponse(d *driver.Driver) driver.DriverResponse {
 	return driver.DriverResponse{
 		ID:           d.ID,
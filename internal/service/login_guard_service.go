@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"my-go-driver/internal/domain/activity"
+	"my-go-driver/internal/domain/company"
+	"my-go-driver/internal/domain/loginguard"
+	"my-go-driver/pkg/jwt"
+	"my-go-driver/pkg/throttle"
+)
+
+type loginGuardService struct {
+	accounts *throttle.Limiter
+	ips      *throttle.Limiter
+	activity activity.Repository
+}
+
+// NewLoginGuardService creates a new login guard. Failures are counted per
+// account by accounts and per client IP address by ips. The IP address comes
+// from gin's ClientIP, so the IP lockout is only as trustworthy as the
+// SERVER_TRUSTED_PROXIES setting: trusting a proxy that passes client supplied
+// X-Forwarded-For headers through lets every attempt use a fresh counter.
+func NewLoginGuardService(accounts, ips *throttle.Limiter, activityRepo activity.Repository) loginguard.Service {
+	return &loginGuardService{
+		accounts: accounts,
+		ips:      ips,
+		activity: activityRepo,
+	}
+}
+
+func (s *loginGuardService) Check(ctx context.Context, attempt loginguard.Attempt) error {
+	if err := s.accounts.Check(ctx, accountKey(attempt)); err != nil {
+		return err
+	}
+	if attempt.IPAddress == "" {
+		return nil
+	}
+	return s.ips.Check(ctx, ipKey(attempt))
+}
+
+func (s *loginGuardService) Failed(ctx context.Context, attempt loginguard.Attempt) error {
+	state, locked, err := s.accounts.Fail(ctx, accountKey(attempt))
+	if err != nil {
+		return fmt.Errorf("failed to record login failure: %w", err)
+	}
+	if locked {
+		if err := s.logLockout(ctx, attempt, "account", state); err != nil {
+			return err
+		}
+	}
+
+	if attempt.IPAddress == "" {
+		return nil
+	}
+
+	state, locked, err = s.ips.Fail(ctx, ipKey(attempt))
+	if err != nil {
+		return fmt.Errorf("failed to record login failure: %w", err)
+	}
+	if locked {
+		return s.logLockout(ctx, attempt, "ip", state)
+	}
+	return nil
+}
+
+func (s *loginGuardService) Succeeded(ctx context.Context, attempt loginguard.Attempt) error {
+	// The IP counter is left alone so one valid account cannot be used to
+	// reset it while guessing the passwords of others
+	return s.accounts.Reset(ctx, accountKey(attempt))
+}
+
+// Helper methods
+
+// logLockout records a lockout in the activity log
+func (s *loginGuardService) logLockout(ctx context.Context, attempt loginguard.Attempt, scope string, state throttle.State) error {
+	entry := &activity.Log{
+		CompanyID: attempt.CompanyID,
+		Action:    activity.ActionLoginLockout,
+		EntityID:  attempt.SubjectID,
+		Details: company.JSONMap{
+			"scope":        scope,
+			"subject_type": attempt.SubjectType,
+			"identifier":   attempt.Identifier,
+			"failures":     state.Failures,
+			"locked_until": state.LockedUntil.UTC().Format(time.RFC3339),
+		},
+		IPAddress: attempt.IPAddress,
+		UserAgent: attempt.UserAgent,
+	}
+
+	switch attempt.SubjectType {
+	case jwt.SubjectAdmin:
+		entry.EntityType = "company_admin"
+		entry.UserID = attempt.SubjectID
+	case jwt.SubjectDriver:
+		entry.EntityType = "driver"
+		entry.DriverID = attempt.SubjectID
+	case jwt.SubjectPlatform:
+		entry.EntityType = "platform_admin"
+	}
+
+	if err := s.activity.Create(ctx, entry); err != nil {
+		return fmt.Errorf("failed to log lockout: %w", err)
+	}
+	return nil
+}
+
+func accountKey(attempt loginguard.Attempt) string {
+	return string(attempt.SubjectType) + ":" + strings.ToLower(strings.TrimSpace(attempt.Identifier))
+}
+
+func ipKey(attempt loginguard.Attempt) string {
+	return "ip:" + attempt.IPAddress
+}
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"my-go-driver/internal/domain/activity"
+	"my-go-driver/internal/domain/loginguard"
+	"my-go-driver/pkg/jwt"
+	"my-go-driver/pkg/throttle"
+)
+
+// memoryActivityRepository records the activity log entries it is given
+type memoryActivityRepository struct {
+	logs []activity.Log
+}
+
+func (r *memoryActivityRepository) Create(ctx context.Context, log *activity.Log) error {
+	r.logs = append(r.logs, *log)
+	return nil
+}
+
+func newTestLoginGuard(accountThreshold, ipThreshold int) (loginguard.Service, *memoryActivityRepository) {
+	store := throttle.NewMemoryStore()
+	policy := func(threshold int) throttle.Policy {
+		return throttle.Policy{Threshold: threshold, BaseDelay: time.Minute, MaxDelay: time.Hour, Window: time.Hour}
+	}
+	activityRepo := &memoryActivityRepository{}
+	guard := NewLoginGuardService(
+		throttle.NewLimiter(store, policy(accountThreshold)),
+		throttle.NewLimiter(store, policy(ipThreshold)),
+		activityRepo,
+	)
+	return guard, activityRepo
+}
+
+func TestLoginGuardServiceLockout(t *testing.T) {
+	subjectID := uint64(7)
+	attempt := func(identifier, ip string) loginguard.Attempt {
+		return loginguard.Attempt{SubjectType: jwt.SubjectAdmin, Identifier: identifier, IPAddress: ip, SubjectID: &subjectID}
+	}
+
+	tests := []struct {
+		name       string
+		failures   []loginguard.Attempt
+		check      loginguard.Attempt
+		wantLocked bool
+		wantScopes []string
+	}{
+		{
+			name:     "below the account threshold",
+			failures: []loginguard.Attempt{attempt("a@example.com", "10.0.0.1"), attempt("a@example.com", "10.0.0.2")},
+			check:    attempt("a@example.com", "10.0.0.3"),
+		},
+		{
+			name: "account is locked from every address",
+			failures: []loginguard.Attempt{
+				attempt("a@example.com", "10.0.0.1"),
+				attempt("A@example.com ", "10.0.0.2"),
+				attempt("a@example.com", "10.0.0.3"),
+			},
+			check:      attempt("a@example.com", "10.0.0.4"),
+			wantLocked: true,
+			wantScopes: []string{"account"},
+		},
+		{
+			name: "address is locked for every account",
+			failures: []loginguard.Attempt{
+				attempt("a@example.com", "10.0.0.1"),
+				attempt("b@example.com", "10.0.0.1"),
+				attempt("c@example.com", "10.0.0.1"),
+				attempt("d@example.com", "10.0.0.1"),
+				attempt("e@example.com", "10.0.0.1"),
+			},
+			check:      attempt("f@example.com", "10.0.0.1"),
+			wantLocked: true,
+			wantScopes: []string{"ip"},
+		},
+		{
+			name:     "failures without an address only count for the account",
+			failures: []loginguard.Attempt{attempt("a@example.com", ""), attempt("b@example.com", ""), attempt("c@example.com", ""), attempt("d@example.com", ""), attempt("e@example.com", "")},
+			check:    attempt("f@example.com", ""),
+		},
+		{
+			name:       "same identifier of another subject type",
+			failures:   []loginguard.Attempt{attempt("a@example.com", ""), attempt("a@example.com", ""), attempt("a@example.com", "")},
+			check:      loginguard.Attempt{SubjectType: jwt.SubjectPlatform, Identifier: "a@example.com"},
+			wantScopes: []string{"account"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			guard, activityRepo := newTestLoginGuard(3, 5)
+
+			for _, a := range tt.failures {
+				if err := guard.Failed(ctx, a); err != nil {
+					t.Fatalf("Failed() error = %v", err)
+				}
+			}
+
+			err := guard.Check(ctx, tt.check)
+			if locked := errors.Is(err, throttle.ErrLocked); locked != tt.wantLocked {
+				t.Errorf("Check() error = %v, want locked %v", err, tt.wantLocked)
+			}
+
+			var scopes []string
+			for _, log := range activityRepo.logs {
+				if log.Action != activity.ActionLoginLockout {
+					t.Errorf("logged action %s, want %s", log.Action, activity.ActionLoginLockout)
+				}
+				scopes = append(scopes, log.Details["scope"].(string))
+			}
+			if !reflect.DeepEqual(scopes, tt.wantScopes) {
+				t.Errorf("logged lockouts = %v, want %v", scopes, tt.wantScopes)
+			}
+		})
+	}
+}
+
+func TestLoginGuardServiceSucceeded(t *testing.T) {
+	ctx := context.Background()
+	guard, _ := newTestLoginGuard(3, 4)
+	failed := loginguard.Attempt{SubjectType: jwt.SubjectDriver, Identifier: "1:+1555", IPAddress: "10.0.0.1"}
+
+	for i := 0; i < 3; i++ {
+		if err := guard.Failed(ctx, failed); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := guard.Succeeded(ctx, failed); err != nil {
+		t.Fatal(err)
+	}
+	if err := guard.Check(ctx, failed); err != nil {
+		t.Errorf("Check() after Succeeded() error = %v", err)
+	}
+
+	// Success clears the account but not the address
+	if err := guard.Failed(ctx, loginguard.Attempt{SubjectType: jwt.SubjectDriver, Identifier: "1:+1556", IPAddress: "10.0.0.1"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := guard.Check(ctx, failed); !errors.Is(err, throttle.ErrLocked) {
+		t.Errorf("Check() error = %v, want the address locked", err)
+	}
+}
@@ -0,0 +1,494 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"my-go-driver/internal/domain/company"
+	"my-go-driver/internal/domain/maintenance"
+	"my-go-driver/internal/domain/notification"
+	"my-go-driver/internal/domain/shift"
+	"my-go-driver/internal/domain/vehicle"
+	"my-go-driver/internal/tenant"
+	"my-go-driver/internal/transaction"
+
+	"gorm.io/gorm"
+)
+
+type maintenanceService struct {
+	repo          maintenance.Repository
+	vehicleRepo   vehicle.Repository
+	vehicles      vehicle.Service
+	shiftRepo     shift.Repository
+	notifications notification.Service
+	tx            transaction.Transactor
+}
+
+// NewMaintenanceService creates a new maintenance service
+func NewMaintenanceService(repo maintenance.Repository, vehicleRepo vehicle.Repository, vehicles vehicle.Service, shiftRepo shift.Repository, notifications notification.Service, tx transaction.Transactor) maintenance.Service {
+	return &maintenanceService{
+		repo:          repo,
+		vehicleRepo:   vehicleRepo,
+		vehicles:      vehicles,
+		shiftRepo:     shiftRepo,
+		notifications: notifications,
+		tx:            tx,
+	}
+}
+
+func (s *maintenanceService) CreateRule(ctx context.Context, req maintenance.CreateRuleRequest) (*maintenance.RuleResponse, error) {
+	if !tenant.Allows(ctx, req.CompanyID) {
+		return nil, company.ErrCompanyNotFound
+	}
+	if req.IntervalDays == nil && req.IntervalDistance == nil {
+		return nil, maintenance.ErrNoInterval
+	}
+
+	existing, err := s.repo.GetRuleByServiceType(ctx, req.CompanyID, req.ServiceType)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("error checking service type: %w", err)
+	}
+	if existing != nil {
+		return nil, maintenance.ErrRuleExists
+	}
+
+	rule := &maintenance.Rule{
+		CompanyID:        req.CompanyID,
+		ServiceType:      req.ServiceType,
+		IntervalDays:     req.IntervalDays,
+		IntervalDistance: req.IntervalDistance,
+		Action:           req.Action,
+	}
+	if rule.Action == "" {
+		rule.Action = maintenance.ActionFlag
+	}
+
+	if err := s.repo.CreateRule(ctx, rule); err != nil {
+		return nil, fmt.Errorf("failed to create maintenance rule: %w", err)
+	}
+
+	response := s.toRuleResponse(rule)
+	return &response, nil
+}
+
+func (s *maintenanceService) ListRules(ctx context.Context, query maintenance.ListRulesQuery) ([]maintenance.RuleResponse, error) {
+	// Company admins only ever see their own rules
+	if companyID, ok := tenant.CompanyID(ctx); ok {
+		query.CompanyID = companyID
+	}
+
+	rules, err := s.repo.ListRules(ctx, query.CompanyID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]maintenance.RuleResponse, len(rules))
+	for i, rule := range rules {
+		responses[i] = s.toRuleResponse(&rule)
+	}
+	return responses, nil
+}
+
+func (s *maintenanceService) UpdateRule(ctx context.Context, id uint64, req maintenance.UpdateRuleRequest) (*maintenance.RuleResponse, error) {
+	rule, err := s.findRule(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Update fields
+	if req.IntervalDays != nil {
+		rule.IntervalDays = req.IntervalDays
+		if *req.IntervalDays == 0 {
+			rule.IntervalDays = nil
+		}
+	}
+	if req.IntervalDistance != nil {
+		rule.IntervalDistance = req.IntervalDistance
+		if *req.IntervalDistance == 0 {
+			rule.IntervalDistance = nil
+		}
+	}
+	if req.Action != "" {
+		rule.Action = req.Action
+	}
+
+	if rule.IntervalDays == nil && rule.IntervalDistance == nil {
+		return nil, maintenance.ErrNoInterval
+	}
+
+	if err := s.repo.UpdateRule(ctx, rule); err != nil {
+		return nil, fmt.Errorf("failed to update maintenance rule: %w", err)
+	}
+
+	response := s.toRuleResponse(rule)
+	return &response, nil
+}
+
+func (s *maintenanceService) DeleteRule(ctx context.Context, id uint64) error {
+	if _, err := s.findRule(ctx, id); err != nil {
+		return err
+	}
+
+	// Vehicles flagged for the service are cleared by the next check
+	return s.repo.DeleteRule(ctx, id)
+}
+
+func (s *maintenanceService) RecordService(ctx context.Context, vehicleID uint64, req maintenance.CreateRecordRequest) (*maintenance.RecordResponse, error) {
+	servicedAt, err := time.Parse(vehicle.DateFormat, req.ServicedAt)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date %q: %w", req.ServicedAt, err)
+	}
+	if servicedAt.After(time.Now()) {
+		return nil, maintenance.ErrServiceDateInFuture
+	}
+
+	var record *maintenance.Record
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		// Lock the vehicle so concurrent records update its due services in turn
+		v, err := s.findVehicle(ctx, s.vehicleRepo.GetByIDForUpdate, vehicleID)
+		if err != nil {
+			return err
+		}
+
+		record = &maintenance.Record{
+			CompanyID:   v.CompanyID,
+			VehicleID:   v.ID,
+			ServiceType: req.ServiceType,
+			Odometer:    req.Odometer,
+			Cost:        req.Cost,
+			ServicedAt:  servicedAt,
+			Notes:       req.Notes,
+		}
+		if err := s.repo.CreateRecord(ctx, record); err != nil {
+			return fmt.Errorf("failed to create maintenance record: %w", err)
+		}
+
+		if req.ServiceType == maintenance.ServiceTypeOilChange && (v.LastOilChange == nil || servicedAt.After(*v.LastOilChange)) {
+			if err := s.vehicleRepo.UpdateLastOilChange(ctx, v.ID, servicedAt); err != nil {
+				return fmt.Errorf("failed to update last oil change: %w", err)
+			}
+		}
+
+		if v.MaintenanceDue.Contains(req.ServiceType) {
+			var due vehicle.ServiceTypes
+			for _, serviceType := range v.MaintenanceDue {
+				if serviceType != req.ServiceType {
+					due = append(due, serviceType)
+				}
+			}
+			return s.vehicleRepo.SetMaintenanceDue(ctx, v.ID, due)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := s.toRecordResponse(record)
+	return &response, nil
+}
+
+func (s *maintenanceService) ListRecords(ctx context.Context, query maintenance.ListRecordsQuery) (*maintenance.PaginatedRecordsResponse, error) {
+	if _, err := s.vehicles.GetVehicle(ctx, query.VehicleID); err != nil {
+		return nil, err
+	}
+
+	records, total, err := s.repo.ListRecords(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set defaults
+	if query.Page <= 0 {
+		query.Page = 1
+	}
+	if query.Limit <= 0 {
+		query.Limit = 10
+	}
+
+	responses := make([]maintenance.RecordResponse, len(records))
+	for i, record := range records {
+		responses[i] = s.toRecordResponse(&record)
+	}
+
+	totalPages := int(math.Ceil(float64(total) / float64(query.Limit)))
+
+	return &maintenance.PaginatedRecordsResponse{
+		Records:    responses,
+		TotalCount: total,
+		Page:       query.Page,
+		Limit:      query.Limit,
+		TotalPages: totalPages,
+	}, nil
+}
+
+func (s *maintenanceService) GetSchedule(ctx context.Context, vehicleID uint64) ([]maintenance.ScheduleItem, error) {
+	v, err := s.findVehicle(ctx, s.vehicleRepo.GetByID, vehicleID)
+	if err != nil {
+		return nil, err
+	}
+
+	rules, err := s.repo.ListRules(ctx, v.CompanyID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.schedule(ctx, v, rules, time.Now())
+}
+
+func (s *maintenanceService) CheckCompany(ctx context.Context, companyID uint64) (*maintenance.CheckResult, error) {
+	if !tenant.Allows(ctx, companyID) {
+		return nil, company.ErrCompanyNotFound
+	}
+
+	result := &maintenance.CheckResult{
+		CompanyID:          companyID,
+		VehiclesDue:        []uint64{},
+		MovedToMaintenance: []uint64{},
+	}
+
+	rules, err := s.repo.ListRules(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+	actions := make(map[string]maintenance.Action, len(rules))
+	for _, rule := range rules {
+		actions[rule.ServiceType] = rule.Action
+	}
+
+	vehicles, err := s.vehicleRepo.ListByCompany(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for i := range vehicles {
+		v := &vehicles[i]
+		result.VehiclesChecked++
+
+		items, err := s.schedule(ctx, v, rules, now)
+		if err != nil {
+			return nil, err
+		}
+
+		due, newlyDue, moveToMaintenance := dueServices(v.MaintenanceDue, items, actions)
+
+		// The flag and the reminder are saved together, so a reminder that
+		// fails leaves the services newly due for the next check
+		err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+			if len(due) != len(v.MaintenanceDue) || len(newlyDue) > 0 {
+				if err := s.vehicleRepo.SetMaintenanceDue(ctx, v.ID, due); err != nil {
+					return fmt.Errorf("failed to flag vehicle %d: %w", v.ID, err)
+				}
+			}
+			if len(newlyDue) == 0 {
+				return nil
+			}
+
+			sent, err := s.notifications.Notify(ctx, maintenanceReminder(v, newlyDue))
+			if err != nil {
+				return err
+			}
+			if sent {
+				result.RemindersSent++
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(due) == 0 {
+			continue
+		}
+		result.VehiclesDue = append(result.VehiclesDue, v.ID)
+
+		if moveToMaintenance && v.Status == vehicle.VehicleStatusActive {
+			moved, err := s.moveToMaintenance(ctx, v)
+			if err != nil {
+				return nil, err
+			}
+			if moved {
+				result.MovedToMaintenance = append(result.MovedToMaintenance, v.ID)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// Helper methods
+
+// findRule loads a maintenance rule, hiding rules outside the caller's tenant
+func (s *maintenanceService) findRule(ctx context.Context, id uint64) (*maintenance.Rule, error) {
+	rule, err := s.repo.GetRuleByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, maintenance.ErrRuleNotFound
+		}
+		return nil, err
+	}
+	if !tenant.Allows(ctx, rule.CompanyID) {
+		return nil, maintenance.ErrRuleNotFound
+	}
+	return rule, nil
+}
+
+// findVehicle loads a vehicle with load once the vehicle service has checked
+// that the caller may see it
+func (s *maintenanceService) findVehicle(ctx context.Context, load func(context.Context, uint64) (*vehicle.Vehicle, error), id uint64) (*vehicle.Vehicle, error) {
+	if _, err := s.vehicles.GetVehicle(ctx, id); err != nil {
+		return nil, err
+	}
+
+	v, err := load(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, vehicle.ErrVehicleNotFound
+		}
+		return nil, err
+	}
+	return v, nil
+}
+
+// schedule evaluates each rule for the vehicle. A service is counted from its
+// latest record, or from when the vehicle was added when it has none.
+func (s *maintenanceService) schedule(ctx context.Context, v *vehicle.Vehicle, rules []maintenance.Rule, now time.Time) ([]maintenance.ScheduleItem, error) {
+	lastDates, err := s.repo.LastServiceDates(ctx, v.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]maintenance.ScheduleItem, len(rules))
+	for i, rule := range rules {
+		item := maintenance.ScheduleItem{
+			ServiceType: rule.ServiceType,
+			Action:      rule.Action,
+		}
+
+		since, serviced := lastServiced(v, rule.ServiceType, lastDates)
+		if serviced {
+			formatted := since.Format(vehicle.DateFormat)
+			item.LastServicedAt = &formatted
+		} else {
+			since = v.CreatedAt
+		}
+
+		distance, err := s.shiftRepo.SumVehicleDistance(ctx, v.ID, since)
+		if err != nil {
+			return nil, err
+		}
+		item.DistanceSince = distance
+
+		if due := rule.NextDueDate(since); due != nil {
+			formatted := due.Format(vehicle.DateFormat)
+			item.NextDueDate = &formatted
+		}
+		if rule.IntervalDistance != nil {
+			left := math.Max(*rule.IntervalDistance-distance, 0)
+			item.DistanceLeft = &left
+		}
+		item.IsDue = rule.IsDue(since, distance, now)
+
+		items[i] = item
+	}
+	return items, nil
+}
+
+// lastServiced returns when the vehicle last had the service according to
+// lastDates, its latest record of each service. The vehicle's last oil change
+// counts as an oil change record.
+func lastServiced(v *vehicle.Vehicle, serviceType string, lastDates map[string]time.Time) (time.Time, bool) {
+	since, serviced := lastDates[serviceType]
+	if serviceType == maintenance.ServiceTypeOilChange && v.LastOilChange != nil && v.LastOilChange.After(since) {
+		return *v.LastOilChange, true
+	}
+	return since, serviced
+}
+
+// dueServices returns the scheduled services that are due and those among
+// them not yet in flagged, the vehicle's current maintenance_due. move reports
+// whether a due service's rule moves the vehicle to maintenance.
+func dueServices(flagged vehicle.ServiceTypes, items []maintenance.ScheduleItem, actions map[string]maintenance.Action) (due, newlyDue vehicle.ServiceTypes, move bool) {
+	for _, item := range items {
+		if !item.IsDue {
+			continue
+		}
+		due = append(due, item.ServiceType)
+		if !flagged.Contains(item.ServiceType) {
+			newlyDue = append(newlyDue, item.ServiceType)
+		}
+		if actions[item.ServiceType] == maintenance.ActionMaintenance {
+			move = true
+		}
+	}
+	return due, newlyDue, move
+}
+
+// moveToMaintenance takes a due vehicle out of service, ending its
+// assignment. A vehicle whose driver is on a shift is moved by a later check,
+// once the shift has ended.
+func (s *maintenanceService) moveToMaintenance(ctx context.Context, v *vehicle.Vehicle) (bool, error) {
+	assignment, err := s.vehicleRepo.GetActiveAssignmentByVehicle(ctx, v.ID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, err
+	}
+	if assignment != nil {
+		if _, err := s.shiftRepo.GetOngoing(ctx, assignment.DriverID); err == nil {
+			return false, nil
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, err
+		}
+	}
+
+	_, err = s.vehicles.ChangeStatus(ctx, v.ID, vehicle.ChangeStatusRequest{Status: vehicle.VehicleStatusMaintenance})
+	if err != nil {
+		return false, fmt.Errorf("failed to move vehicle %d to maintenance: %w", v.ID, err)
+	}
+	return true, nil
+}
+
+// maintenanceReminder builds the notification for a vehicle that became due
+// for services
+func maintenanceReminder(v *vehicle.Vehicle, serviceTypes vehicle.ServiceTypes) *notification.Notification {
+	return &notification.Notification{
+		CompanyID: v.CompanyID,
+		Type:      notification.TypeMaintenanceDue,
+		Title:     fmt.Sprintf("Vehicle %s is due for maintenance", v.PlateNumber),
+		Body:      fmt.Sprintf("Vehicle %s is due for: %s.", v.PlateNumber, strings.Join(serviceTypes, ", ")),
+		Data: company.JSONMap{
+			"vehicle_id":    v.ID,
+			"plate_number":  v.PlateNumber,
+			"service_types": []string(serviceTypes),
+		},
+	}
+}
+
+func (s *maintenanceService) toRuleResponse(rule *maintenance.Rule) maintenance.RuleResponse {
+	return maintenance.RuleResponse{
+		ID:               rule.ID,
+		CompanyID:        rule.CompanyID,
+		ServiceType:      rule.ServiceType,
+		IntervalDays:     rule.IntervalDays,
+		IntervalDistance: rule.IntervalDistance,
+		Action:           rule.Action,
+		CreatedAt:        rule.CreatedAt,
+		UpdatedAt:        rule.UpdatedAt,
+	}
+}
+
+func (s *maintenanceService) toRecordResponse(record *maintenance.Record) maintenance.RecordResponse {
+	return maintenance.RecordResponse{
+		ID:          record.ID,
+		VehicleID:   record.VehicleID,
+		ServiceType: record.ServiceType,
+		Odometer:    record.Odometer,
+		Cost:        record.Cost,
+		ServicedAt:  record.ServicedAt.Format(vehicle.DateFormat),
+		Notes:       record.Notes,
+		CreatedAt:   record.CreatedAt,
+	}
+}
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"my-go-driver/internal/domain/maintenance"
+	"my-go-driver/internal/domain/vehicle"
+)
+
+func TestLastServiced(t *testing.T) {
+	recorded := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	earlier := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	later := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+	lastDates := map[string]time.Time{
+		maintenance.ServiceTypeOilChange: recorded,
+		"tires":                          recorded,
+	}
+
+	tests := []struct {
+		name         string
+		serviceType  string
+		lastDates    map[string]time.Time
+		oilChange    *time.Time
+		wantSince    time.Time
+		wantServiced bool
+	}{
+		{
+			name:        "never serviced",
+			serviceType: "brakes",
+			lastDates:   lastDates,
+		},
+		{
+			name:         "latest record",
+			serviceType:  "tires",
+			lastDates:    lastDates,
+			oilChange:    &later,
+			wantSince:    recorded,
+			wantServiced: true,
+		},
+		{
+			name:         "last oil change newer than the records",
+			serviceType:  maintenance.ServiceTypeOilChange,
+			lastDates:    lastDates,
+			oilChange:    &later,
+			wantSince:    later,
+			wantServiced: true,
+		},
+		{
+			name:         "last oil change older than the records",
+			serviceType:  maintenance.ServiceTypeOilChange,
+			lastDates:    lastDates,
+			oilChange:    &earlier,
+			wantSince:    recorded,
+			wantServiced: true,
+		},
+		{
+			name:         "last oil change without records",
+			serviceType:  maintenance.ServiceTypeOilChange,
+			oilChange:    &earlier,
+			wantSince:    earlier,
+			wantServiced: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := &vehicle.Vehicle{LastOilChange: tt.oilChange}
+			since, serviced := lastServiced(v, tt.serviceType, tt.lastDates)
+			if !since.Equal(tt.wantSince) || serviced != tt.wantServiced {
+				t.Fatalf("lastServiced = %v, %v, want %v, %v", since, serviced, tt.wantSince, tt.wantServiced)
+			}
+		})
+	}
+}
+
+func TestDueServices(t *testing.T) {
+	actions := map[string]maintenance.Action{
+		"tires":                          maintenance.ActionFlag,
+		maintenance.ServiceTypeOilChange: maintenance.ActionMaintenance,
+	}
+	item := func(serviceType string, isDue bool) maintenance.ScheduleItem {
+		return maintenance.ScheduleItem{ServiceType: serviceType, IsDue: isDue}
+	}
+
+	tests := []struct {
+		name         string
+		flagged      vehicle.ServiceTypes
+		items        []maintenance.ScheduleItem
+		wantDue      vehicle.ServiceTypes
+		wantNewlyDue vehicle.ServiceTypes
+		wantMove     bool
+	}{
+		{
+			name:  "nothing due",
+			items: []maintenance.ScheduleItem{item("tires", false), item(maintenance.ServiceTypeOilChange, false)},
+		},
+		{
+			name:         "flag rule only flags",
+			items:        []maintenance.ScheduleItem{item("tires", true), item(maintenance.ServiceTypeOilChange, false)},
+			wantDue:      vehicle.ServiceTypes{"tires"},
+			wantNewlyDue: vehicle.ServiceTypes{"tires"},
+		},
+		{
+			name:         "maintenance rule moves the vehicle",
+			items:        []maintenance.ScheduleItem{item("tires", true), item(maintenance.ServiceTypeOilChange, true)},
+			wantDue:      vehicle.ServiceTypes{"tires", maintenance.ServiceTypeOilChange},
+			wantNewlyDue: vehicle.ServiceTypes{"tires", maintenance.ServiceTypeOilChange},
+			wantMove:     true,
+		},
+		{
+			name:     "already flagged services are not newly due",
+			flagged:  vehicle.ServiceTypes{maintenance.ServiceTypeOilChange},
+			items:    []maintenance.ScheduleItem{item(maintenance.ServiceTypeOilChange, true)},
+			wantDue:  vehicle.ServiceTypes{maintenance.ServiceTypeOilChange},
+			wantMove: true,
+		},
+		{
+			name:    "serviced flags are dropped",
+			flagged: vehicle.ServiceTypes{"tires", maintenance.ServiceTypeOilChange},
+			items:   []maintenance.ScheduleItem{item("tires", true), item(maintenance.ServiceTypeOilChange, false)},
+			wantDue: vehicle.ServiceTypes{"tires"},
+		},
+		{
+			name:         "services without a rule action are flagged",
+			items:        []maintenance.ScheduleItem{item("brakes", true)},
+			wantDue:      vehicle.ServiceTypes{"brakes"},
+			wantNewlyDue: vehicle.ServiceTypes{"brakes"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			due, newlyDue, move := dueServices(tt.flagged, tt.items, actions)
+			if !reflect.DeepEqual(due, tt.wantDue) {
+				t.Errorf("due = %v, want %v", due, tt.wantDue)
+			}
+			if !reflect.DeepEqual(newlyDue, tt.wantNewlyDue) {
+				t.Errorf("newlyDue = %v, want %v", newlyDue, tt.wantNewlyDue)
+			}
+			if move != tt.wantMove {
+				t.Errorf("move = %v, want %v", move, tt.wantMove)
+			}
+		})
+	}
+}
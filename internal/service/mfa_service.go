@@ -0,0 +1,398 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"my-go-driver/internal/domain/company"
+	"my-go-driver/internal/domain/mfa"
+	"my-go-driver/pkg/hash"
+	"my-go-driver/pkg/totp"
+
+	"gorm.io/gorm"
+)
+
+// totpSkew is the number of time steps of clock drift accepted either way
+const totpSkew = 1
+
+type mfaService struct {
+	repo        mfa.Repository
+	companyRepo company.Repository
+	issuer      string
+}
+
+// NewMFAService creates a new MFA service. The issuer is the account name
+// shown in authenticator apps.
+func NewMFAService(repo mfa.Repository, companyRepo company.Repository, issuer string) mfa.Service {
+	return &mfaService{
+		repo:        repo,
+		companyRepo: companyRepo,
+		issuer:      issuer,
+	}
+}
+
+func (s *mfaService) GetStatus(ctx context.Context, adminID uint64) (*mfa.StatusResponse, error) {
+	admin, err := s.findAdmin(ctx, adminID)
+	if err != nil {
+		return nil, err
+	}
+
+	required, err := s.required(ctx, admin)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &mfa.StatusResponse{Required: required}
+
+	e, err := s.findEnrollment(ctx, adminID)
+	if err != nil && !errors.Is(err, mfa.ErrNotEnrolled) {
+		return nil, err
+	}
+	if e.IsEnabled() {
+		status.Enabled = true
+		status.ConfirmedAt = e.ConfirmedAt
+		if status.RecoveryCodesRemaining, err = s.repo.CountUnusedRecoveryCodes(ctx, adminID); err != nil {
+			return nil, err
+		}
+	}
+
+	return status, nil
+}
+
+func (s *mfaService) Enroll(ctx context.Context, adminID uint64) (*mfa.EnrollResponse, error) {
+	admin, err := s.findAdmin(ctx, adminID)
+	if err != nil {
+		return nil, err
+	}
+	return s.enroll(ctx, admin)
+}
+
+func (s *mfaService) Confirm(ctx context.Context, adminID uint64, req mfa.CodeRequest) (*mfa.RecoveryCodesResponse, error) {
+	e, err := s.findEnrollment(ctx, adminID)
+	if err != nil {
+		return nil, err
+	}
+	if e.IsEnabled() {
+		return nil, mfa.ErrAlreadyEnabled
+	}
+
+	ok, err := s.verifyCode(ctx, e, req.Code, false)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, mfa.ErrInvalidCode
+	}
+
+	codes, err := s.confirm(ctx, e)
+	if err != nil {
+		return nil, err
+	}
+	return &mfa.RecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+func (s *mfaService) Disable(ctx context.Context, adminID uint64, req mfa.CodeRequest) error {
+	admin, err := s.findAdmin(ctx, adminID)
+	if err != nil {
+		return err
+	}
+
+	e, err := s.findEnrollment(ctx, adminID)
+	if err != nil {
+		return err
+	}
+	if !e.IsEnabled() {
+		return mfa.ErrNotEnrolled
+	}
+
+	required, err := s.required(ctx, admin)
+	if err != nil {
+		return err
+	}
+	if required {
+		return mfa.ErrMFARequired
+	}
+
+	ok, err := s.verifyCode(ctx, e, req.Code, true)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return mfa.ErrInvalidCode
+	}
+
+	return s.repo.DeleteEnrollment(ctx, adminID)
+}
+
+func (s *mfaService) RegenerateRecoveryCodes(ctx context.Context, adminID uint64, req mfa.CodeRequest) (*mfa.RecoveryCodesResponse, error) {
+	e, err := s.findEnrollment(ctx, adminID)
+	if err != nil {
+		return nil, err
+	}
+	if !e.IsEnabled() {
+		return nil, mfa.ErrNotEnrolled
+	}
+
+	// Only a TOTP code proves the admin still holds their authenticator
+	ok, err := s.verifyCode(ctx, e, req.Code, false)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, mfa.ErrInvalidCode
+	}
+
+	codes, err := s.issueRecoveryCodes(ctx, adminID)
+	if err != nil {
+		return nil, err
+	}
+	return &mfa.RecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+func (s *mfaService) BeginLogin(ctx context.Context, admin *company.CompanyAdmin) (*company.MFAChallenge, error) {
+	e, err := s.findEnrollment(ctx, admin.ID)
+	if err != nil && !errors.Is(err, mfa.ErrNotEnrolled) {
+		return nil, err
+	}
+
+	enabled := e.IsEnabled()
+	if !enabled {
+		required, err := s.required(ctx, admin)
+		if err != nil {
+			return nil, err
+		}
+		if !required {
+			return nil, nil
+		}
+	}
+
+	token, err := hash.RandomToken(32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate mfa token: %w", err)
+	}
+
+	challenge := &mfa.Challenge{
+		AdminID:   admin.ID,
+		TokenHash: hash.HashToken(token),
+		ExpiresAt: time.Now().Add(mfa.ChallengeTTL),
+	}
+	if err := s.repo.CreateChallenge(ctx, challenge); err != nil {
+		return nil, fmt.Errorf("failed to store mfa challenge: %w", err)
+	}
+
+	return &company.MFAChallenge{
+		Token:              token,
+		ExpiresAt:          challenge.ExpiresAt,
+		EnrollmentRequired: !enabled,
+	}, nil
+}
+
+func (s *mfaService) EnrollWithChallenge(ctx context.Context, req mfa.ChallengeEnrollRequest) (*mfa.EnrollResponse, error) {
+	challenge, err := s.findChallenge(ctx, req.MFAToken)
+	if err != nil {
+		return nil, err
+	}
+
+	admin, err := s.findAdmin(ctx, challenge.AdminID)
+	if err != nil {
+		return nil, err
+	}
+	return s.enroll(ctx, admin)
+}
+
+func (s *mfaService) ChallengeAdmin(ctx context.Context, token string) (uint64, error) {
+	challenge, err := s.findChallenge(ctx, token)
+	if err != nil {
+		return 0, err
+	}
+	return challenge.AdminID, nil
+}
+
+func (s *mfaService) CompleteChallenge(ctx context.Context, token, code string) ([]string, error) {
+	challenge, err := s.findChallenge(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	e, err := s.findEnrollment(ctx, challenge.AdminID)
+	if err != nil {
+		return nil, err
+	}
+
+	ok, err := s.verifyCode(ctx, e, code, true)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		if err := s.repo.IncrementChallengeAttempts(ctx, challenge.ID); err != nil {
+			return nil, err
+		}
+		return nil, mfa.ErrInvalidCode
+	}
+
+	marked, err := s.repo.MarkChallengeUsed(ctx, challenge.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to consume mfa challenge: %w", err)
+	}
+	if !marked {
+		return nil, mfa.ErrInvalidChallenge
+	}
+
+	// A login that required enrollment confirms it with its first code
+	if !e.IsEnabled() {
+		return s.confirm(ctx, e)
+	}
+	return nil, nil
+}
+
+// Helper methods
+
+// enroll replaces any pending enrollment of the admin with a new secret
+func (s *mfaService) enroll(ctx context.Context, admin *company.CompanyAdmin) (*mfa.EnrollResponse, error) {
+	existing, err := s.findEnrollment(ctx, admin.ID)
+	if err != nil && !errors.Is(err, mfa.ErrNotEnrolled) {
+		return nil, err
+	}
+	if existing.IsEnabled() {
+		return nil, mfa.ErrAlreadyEnabled
+	}
+	if existing != nil {
+		if err := s.repo.DeleteEnrollment(ctx, admin.ID); err != nil {
+			return nil, fmt.Errorf("failed to reset enrollment: %w", err)
+		}
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate totp secret: %w", err)
+	}
+
+	if err := s.repo.CreateEnrollment(ctx, &mfa.Enrollment{
+		AdminID: admin.ID,
+		Secret:  secret,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to store enrollment: %w", err)
+	}
+
+	return &mfa.EnrollResponse{
+		Secret:          secret,
+		ProvisioningURI: totp.ProvisioningURI(s.issuer, admin.Email, secret),
+	}, nil
+}
+
+// confirm enables a pending enrollment and issues its recovery codes
+func (s *mfaService) confirm(ctx context.Context, e *mfa.Enrollment) ([]string, error) {
+	now := time.Now()
+	if err := s.repo.ConfirmEnrollment(ctx, e.AdminID, now); err != nil {
+		return nil, fmt.Errorf("failed to confirm enrollment: %w", err)
+	}
+	return s.issueRecoveryCodes(ctx, e.AdminID)
+}
+
+// verifyCode checks a TOTP code, and optionally a recovery code, consuming
+// whichever matched so it cannot be used again
+func (s *mfaService) verifyCode(ctx context.Context, e *mfa.Enrollment, code string, allowRecovery bool) (bool, error) {
+	if step, ok := totp.Validate(e.Secret, code, time.Now(), totpSkew); ok {
+		return s.repo.AdvanceLastUsedStep(ctx, e.AdminID, step)
+	}
+	if !allowRecovery || !e.IsEnabled() {
+		return false, nil
+	}
+	return s.repo.UseRecoveryCode(ctx, e.AdminID, hash.HashToken(normalizeRecoveryCode(code)))
+}
+
+// issueRecoveryCodes replaces the admin's recovery codes with a fresh set
+func (s *mfaService) issueRecoveryCodes(ctx context.Context, adminID uint64) ([]string, error) {
+	codes := make([]string, mfa.RecoveryCodeCount)
+	records := make([]mfa.RecoveryCode, mfa.RecoveryCodeCount)
+	for i := range codes {
+		code, err := newRecoveryCode()
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate recovery code: %w", err)
+		}
+		codes[i] = code
+		records[i] = mfa.RecoveryCode{
+			AdminID:  adminID,
+			CodeHash: hash.HashToken(normalizeRecoveryCode(code)),
+		}
+	}
+
+	if err := s.repo.ReplaceRecoveryCodes(ctx, adminID, records); err != nil {
+		return nil, fmt.Errorf("failed to store recovery codes: %w", err)
+	}
+	return codes, nil
+}
+
+// required reports whether the admin's company requires MFA for their role
+func (s *mfaService) required(ctx context.Context, admin *company.CompanyAdmin) (bool, error) {
+	if admin.Role != company.AdminRoleOwner {
+		return false, nil
+	}
+
+	c, err := s.companyRepo.GetByID(ctx, admin.CompanyID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, company.ErrCompanyNotFound
+		}
+		return false, err
+	}
+	return c.RequireOwnerMFA, nil
+}
+
+func (s *mfaService) findAdmin(ctx context.Context, adminID uint64) (*company.CompanyAdmin, error) {
+	admin, err := s.companyRepo.GetAdminByID(ctx, adminID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, company.ErrAdminNotFound
+		}
+		return nil, err
+	}
+	return admin, nil
+}
+
+func (s *mfaService) findEnrollment(ctx context.Context, adminID uint64) (*mfa.Enrollment, error) {
+	e, err := s.repo.GetEnrollment(ctx, adminID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, mfa.ErrNotEnrolled
+		}
+		return nil, err
+	}
+	return e, nil
+}
+
+// findChallenge loads a login challenge that can still be answered
+func (s *mfaService) findChallenge(ctx context.Context, token string) (*mfa.Challenge, error) {
+	challenge, err := s.repo.GetChallengeByHash(ctx, hash.HashToken(token))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, mfa.ErrInvalidChallenge
+		}
+		return nil, err
+	}
+	if !challenge.IsUsable(time.Now()) {
+		return nil, mfa.ErrInvalidChallenge
+	}
+	return challenge, nil
+}
+
+// newRecoveryCode returns a random code formatted as xxxx-xxxx
+func newRecoveryCode() (string, error) {
+	b := make([]byte, 5)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	code := strings.ToLower(base32.StdEncoding.EncodeToString(b))
+	return code[:4] + "-" + code[4:], nil
+}
+
+// normalizeRecoveryCode makes recovery codes case and separator insensitive
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
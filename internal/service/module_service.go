@@ -1,22 +1,69 @@
 package service
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
+	"sync"
+	"time"
 
+	"my-go-driver/internal/domain/company"
 	"my-go-driver/internal/domain/module"
+	"my-go-driver/internal/domain/plan"
+	"my-go-driver/internal/domain/store"
+	"my-go-driver/internal/tenant"
+	"my-go-driver/internal/transaction"
+	"my-go-driver/pkg/jsonschema"
 
 	"gorm.io/gorm"
 )
 
+// enabledModulesTTL bounds how long a company's enabled modules are cached.
+// Changes made through this instance invalidate the cache at once; other
+// instances see them within the TTL.
+const enabledModulesTTL = time.Minute
+
+// enabledModules is a cached set of a company's enabled module keys
+type enabledModules struct {
+	keys      map[string]bool
+	expiresAt time.Time
+}
+
+// storeOverrides is a cached set of the module keys a store has turned off
+type storeOverrides struct {
+	companyID uint64
+	disabled  map[string]bool
+	expiresAt time.Time
+}
+
 type moduleService struct {
-	repo module.Repository
+	repo        module.Repository
+	companyRepo company.Repository
+	stores      store.Repository
+	plans       plan.Service
+	tx          transaction.Transactor
+
+	cacheMu    sync.Mutex
+	cache      map[uint64]enabledModules
+	storeCache map[uint64]storeOverrides
 }
 
-// NewModuleService creates a new module service
-func NewModuleService(repo module.Repository) module.Service {
-	return &moduleService{repo: repo}
+// NewModuleService creates a new module service. Modules can only be
+// assigned to companies whose plan includes them, together with the modules
+// they require.
+func NewModuleService(repo module.Repository, companyRepo company.Repository, stores store.Repository, plans plan.Service, tx transaction.Transactor) module.Service {
+	return &moduleService{
+		repo:        repo,
+		companyRepo: companyRepo,
+		stores:      stores,
+		plans:       plans,
+		tx:          tx,
+		cache:       make(map[uint64]enabledModules),
+		storeCache:  make(map[uint64]storeOverrides),
+	}
 }
 
 func (s *moduleService) ListAllModules(ctx context.Context) ([]module.ModuleResponse, error) {
@@ -34,22 +81,67 @@ func (s *moduleService) ListAllModules(ctx context.Context) ([]module.ModuleResp
 }
 
 func (s *moduleService) AssignModuleToCompany(ctx context.Context, companyID uint64, req module.AssignModuleRequest) (*module.CompanyModuleResponse, error) {
+	// Module entitlements are managed by platform operators only
+	if err := tenant.RequirePlatform(ctx); err != nil {
+		return nil, err
+	}
+
 	// Check if module exists
 	mod, err := s.repo.GetModuleByID(ctx, req.ModuleID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, fmt.Errorf("module not found")
+			return nil, module.ErrModuleNotFound
 		}
 		return nil, err
 	}
 
+	if err := s.plans.CheckModule(ctx, companyID, mod.ModuleKey); err != nil {
+		return nil, err
+	}
+
 	// Check if already assigned
 	existing, err := s.repo.GetCompanyModule(ctx, companyID, req.ModuleID)
 	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, err
 	}
 	if existing != nil {
-		return nil, fmt.Errorf("module already assigned to company")
+		return nil, module.ErrModuleAlreadyAssigned
+	}
+
+	config := module.ModuleConfig(mergePatch(mod.DefaultConfig, req.Config))
+	if err := validateConfig(mod, config, "config"); err != nil {
+		return nil, err
+	}
+
+	graph, err := s.moduleGraph(ctx)
+	if err != nil {
+		return nil, err
+	}
+	required, err := requiredModules(graph, mod.ModuleKey)
+	if err != nil {
+		return nil, err
+	}
+
+	// Dependencies and conflicts only matter for enabled modules
+	var missing []*module.ModuleMaster
+	var assigned map[string]*module.CompanyModule
+	if req.IsEnabled {
+		assigned, err = s.assignedModules(ctx, companyID)
+		if err != nil {
+			return nil, err
+		}
+		missing, err = checkDependencies(graph[mod.ModuleKey], required, assigned)
+		if err != nil {
+			return nil, err
+		}
+		if len(missing) > 0 && !req.AutoEnableDependencies {
+			return nil, &module.DependencyError{Code: module.CodeMissingDependencies, Module: mod.ModuleKey, Modules: moduleKeys(missing)}
+		}
+		for _, dep := range missing {
+			if err := s.plans.CheckModule(ctx, companyID, dep.ModuleKey); err != nil {
+				return nil, err
+			}
+		}
 	}
 
 	// Create company module
@@ -57,20 +149,35 @@ func (s *moduleService) AssignModuleToCompany(ctx context.Context, companyID uin
 		CompanyID: companyID,
 		ModuleID:  req.ModuleID,
 		IsEnabled: req.IsEnabled,
-		Config:    req.Config,
+		Config:    config,
 	}
 
-	if err := s.repo.AssignModule(ctx, companyModule); err != nil {
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		for _, dep := range missing {
+			if err := s.enableDependency(ctx, companyID, dep, assigned[dep.ModuleKey]); err != nil {
+				return err
+			}
+		}
+		return s.repo.AssignModule(ctx, companyModule)
+	})
+	if err != nil {
 		return nil, fmt.Errorf("failed to assign module: %w", err)
 	}
+	s.invalidate(companyID)
 
 	// Reload with module details
 	companyModule.Module = mod
 
-	return s.toCompanyModuleResponse(companyModule), nil
+	response := s.toCompanyModuleResponse(companyModule)
+	response.EnabledDependencies = moduleKeys(missing)
+	return response, nil
 }
 
 func (s *moduleService) GetCompanyModules(ctx context.Context, companyID uint64) ([]module.CompanyModuleResponse, error) {
+	if !tenant.Allows(ctx, companyID) {
+		return nil, company.ErrCompanyNotFound
+	}
+
 	modules, err := s.repo.GetCompanyModules(ctx, companyID)
 	if err != nil {
 		return nil, err
@@ -85,19 +192,795 @@ func (s *moduleService) GetCompanyModules(ctx context.Context, companyID uint64)
 }
 
 func (s *moduleService) RemoveModuleFromCompany(ctx context.Context, companyID, moduleID uint64) error {
+	// Module entitlements are managed by platform operators only
+	if err := tenant.RequirePlatform(ctx); err != nil {
+		return err
+	}
+
 	// Check if assigned
-	_, err := s.repo.GetCompanyModule(ctx, companyID, moduleID)
+	assigned, err := s.repo.GetCompanyModules(ctx, companyID)
+	if err != nil {
+		return err
+	}
+	var target *module.CompanyModule
+	for i := range assigned {
+		if assigned[i].ModuleID == moduleID {
+			target = &assigned[i]
+		}
+	}
+	if target == nil {
+		return module.ErrModuleNotAssigned
+	}
+
+	// Enabled modules must keep the modules they require
+	if target.Module != nil {
+		var dependents []string
+		for _, cm := range assigned {
+			if cm.IsEnabled && cm.Module != nil && cm.Module.Requires.Contains(target.Module.ModuleKey) {
+				dependents = append(dependents, cm.Module.ModuleKey)
+			}
+		}
+		if len(dependents) > 0 {
+			sort.Strings(dependents)
+			return &module.DependencyError{Code: module.CodeModuleRequired, Module: target.Module.ModuleKey, Modules: dependents}
+		}
+	}
+
+	// Store overrides would otherwise come back if the module is reassigned
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.RemoveCompanyStoreModules(ctx, companyID, moduleID); err != nil {
+			return err
+		}
+		return s.repo.RemoveModule(ctx, companyID, moduleID)
+	})
+	if err != nil {
+		return err
+	}
+	s.invalidate(companyID)
+	return nil
+}
+
+func (s *moduleService) UpdateModuleConfig(ctx context.Context, companyID, moduleID uint64, req module.UpdateModuleConfigRequest) (*module.CompanyModuleResponse, error) {
+	if !tenant.Allows(ctx, companyID) {
+		return nil, company.ErrCompanyNotFound
+	}
+
+	companyModule, err := s.repo.GetCompanyModule(ctx, companyID, moduleID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, module.ErrModuleNotAssigned
+		}
+		return nil, err
+	}
+
+	mod, err := s.repo.GetModuleByID(ctx, moduleID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, module.ErrModuleNotFound
+		}
+		return nil, err
+	}
+
+	config := module.ModuleConfig(mergePatch(companyModule.Config, req.Config))
+	if err := validateConfig(mod, config, "config"); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdateModuleConfig(ctx, companyModule.ID, config); err != nil {
+		return nil, fmt.Errorf("failed to update module config: %w", err)
+	}
+
+	companyModule.Config = config
+	companyModule.Module = mod
+	return s.toCompanyModuleResponse(companyModule), nil
+}
+
+// desiredModule is a module of a declarative assignment, resolved against the catalog
+type desiredModule struct {
+	mod       *module.ModuleMaster
+	isEnabled bool
+	config    module.ModuleConfig
+}
+
+func (s *moduleService) SetCompanyModules(ctx context.Context, companyID uint64, req module.SetCompanyModulesRequest) (*module.SetCompanyModulesResponse, error) {
+	// Module entitlements are managed by platform operators only
+	if err := tenant.RequirePlatform(ctx); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.companyRepo.GetByID(ctx, companyID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, company.ErrCompanyNotFound
+		}
+		return nil, err
+	}
+
+	graph, err := s.moduleGraph(ctx)
+	if err != nil {
+		return nil, err
+	}
+	assigned, err := s.assignedModules(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Resolve and validate the desired set
+	desired := make(map[string]*desiredModule, len(req.Modules))
+	var configErrs []jsonschema.FieldError
+	for i, m := range req.Modules {
+		mod, ok := graph[m.ModuleKey]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", module.ErrModuleNotFound, m.ModuleKey)
+		}
+		if desired[m.ModuleKey] != nil {
+			return nil, fmt.Errorf("%w: %s", module.ErrDuplicateModule, m.ModuleKey)
+		}
+		config := module.ModuleConfig(mergePatch(mod.DefaultConfig, m.Config))
+		configErrs = append(configErrs, configFieldErrors(mod, config, fmt.Sprintf("modules[%d].config", i))...)
+		desired[m.ModuleKey] = &desiredModule{mod: mod, isEnabled: m.IsEnabled, config: config}
+	}
+	if len(configErrs) > 0 {
+		return nil, &module.ConfigError{Errors: configErrs}
+	}
+
+	enabled := make(map[string]*module.ModuleMaster, len(desired))
+	for key, d := range desired {
+		if d.isEnabled {
+			enabled[key] = d.mod
+		}
+	}
+	if err := checkModuleSet(graph, enabled); err != nil {
+		return nil, err
+	}
+
+	// Diff against the current state
+	response := &module.SetCompanyModulesResponse{
+		DryRun:  req.DryRun,
+		Added:   []module.ModuleChange{},
+		Updated: []module.ModuleChange{},
+		Removed: []string{},
+	}
+	var adds, updates, removes []*module.CompanyModule
+	for _, m := range req.Modules {
+		d := desired[m.ModuleKey]
+		change := module.ModuleChange{ModuleKey: m.ModuleKey, IsEnabled: d.isEnabled, Config: d.config}
+
+		current := assigned[m.ModuleKey]
+		switch {
+		case current == nil:
+			if err := s.plans.CheckModule(ctx, companyID, m.ModuleKey); err != nil {
+				return nil, err
+			}
+			adds = append(adds, &module.CompanyModule{
+				CompanyID: companyID,
+				ModuleID:  d.mod.ID,
+				IsEnabled: d.isEnabled,
+				Config:    d.config,
+			})
+			response.Added = append(response.Added, change)
+		case current.IsEnabled != d.isEnabled || !sameConfig(current.Config, d.config):
+			current.IsEnabled = d.isEnabled
+			current.Config = d.config
+			updates = append(updates, current)
+			response.Updated = append(response.Updated, change)
+		}
+	}
+	for key, current := range assigned {
+		if desired[key] == nil {
+			removes = append(removes, current)
+			response.Removed = append(response.Removed, key)
+		}
+	}
+	sort.Strings(response.Removed)
+
+	if req.DryRun {
+		return response, nil
+	}
+
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		for _, cm := range removes {
+			if err := s.repo.RemoveCompanyStoreModules(ctx, companyID, cm.ModuleID); err != nil {
+				return err
+			}
+			if err := s.repo.RemoveModule(ctx, companyID, cm.ModuleID); err != nil {
+				return err
+			}
+		}
+		for _, cm := range updates {
+			if err := s.repo.UpdateCompanyModule(ctx, cm.ID, cm.IsEnabled, cm.Config); err != nil {
+				return err
+			}
+		}
+		for _, cm := range adds {
+			if err := s.repo.AssignModule(ctx, cm); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply modules: %w", err)
+	}
+	s.invalidate(companyID)
+
+	modules, err := s.GetCompanyModules(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+	response.Modules = modules
+
+	return response, nil
+}
+
+func (s *moduleService) ProvisionDefaultModules(ctx context.Context, companyID uint64, billingPlan company.BillingPlan) ([]module.CompanyModuleResponse, error) {
+	// Module entitlements are managed by platform operators only
+	if err := tenant.RequirePlatform(ctx); err != nil {
+		return nil, err
+	}
+
+	p, ok := plan.Get(billingPlan)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", plan.ErrUnknownPlan, billingPlan)
+	}
+
+	defaults, err := s.repo.ListDefaultModules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	assigned, err := s.repo.GetCompanyModules(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+	existing := make(map[uint64]bool, len(assigned))
+	enabled := make(map[string]*module.ModuleMaster, len(assigned))
+	for i := range assigned {
+		cm := &assigned[i]
+		existing[cm.ModuleID] = true
+		if cm.IsEnabled && cm.Module != nil {
+			enabled[cm.Module.ModuleKey] = cm.Module
+		}
+	}
+
+	candidates := make(map[string]*module.ModuleMaster, len(defaults))
+	for i := range defaults {
+		mod := &defaults[i]
+		if !existing[mod.ID] && p.Includes(mod.ModuleKey) {
+			candidates[mod.ModuleKey] = mod
+		}
+	}
+	// Skip defaults whose dependencies the company will not have or that
+	// conflict with its modules, repeating since skipping one module can
+	// leave another without its dependency
+	for changed := true; changed; {
+		changed = false
+		for key, mod := range candidates {
+			if !provisionable(mod, enabled, candidates) {
+				delete(candidates, key)
+				changed = true
+			}
+		}
+	}
+
+	provisioned := []module.CompanyModuleResponse{}
+	for i := range defaults {
+		mod := &defaults[i]
+		if candidates[mod.ModuleKey] == nil {
+			continue
+		}
+
+		companyModule := &module.CompanyModule{
+			CompanyID: companyID,
+			ModuleID:  mod.ID,
+			IsEnabled: true,
+			Config:    module.ModuleConfig(mergePatch(mod.DefaultConfig, nil)),
+		}
+		if err := s.repo.AssignModule(ctx, companyModule); err != nil {
+			return nil, fmt.Errorf("failed to provision module %s: %w", mod.ModuleKey, err)
+		}
+		companyModule.Module = mod
+		provisioned = append(provisioned, *s.toCompanyModuleResponse(companyModule))
+	}
+	s.invalidate(companyID)
+
+	return provisioned, nil
+}
+
+func (s *moduleService) IsEnabled(ctx context.Context, companyID uint64, moduleKey string) (bool, error) {
+	keys, err := s.enabledKeys(ctx, companyID)
+	if err != nil {
+		return false, err
+	}
+	return keys[moduleKey], nil
+}
+
+func (s *moduleService) RequireEnabled(ctx context.Context, companyID uint64, moduleKey string) error {
+	enabled, err := s.IsEnabled(ctx, companyID, moduleKey)
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		return module.NewDisabledError(moduleKey)
+	}
+	return nil
+}
+
+func (s *moduleService) GetStoreModules(ctx context.Context, companyID, storeID uint64) ([]module.EffectiveModuleResponse, error) {
+	if err := s.checkStore(ctx, companyID, storeID); err != nil {
+		return nil, err
+	}
+
+	companyModules, err := s.repo.GetCompanyModules(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	overrides, err := s.repo.ListStoreModules(ctx, storeID)
+	if err != nil {
+		return nil, err
+	}
+	byModule := make(map[uint64]*module.StoreModule, len(overrides))
+	for i := range overrides {
+		byModule[overrides[i].ModuleID] = &overrides[i]
+	}
+
+	responses := make([]module.EffectiveModuleResponse, len(companyModules))
+	for i := range companyModules {
+		cm := &companyModules[i]
+		responses[i] = s.toEffectiveModuleResponse(cm, byModule[cm.ModuleID])
+	}
+
+	return responses, nil
+}
+
+func (s *moduleService) SetStoreModule(ctx context.Context, companyID, storeID, moduleID uint64, req module.SetStoreModuleRequest) (*module.EffectiveModuleResponse, error) {
+	if err := s.checkStore(ctx, companyID, storeID); err != nil {
+		return nil, err
+	}
+
+	// Stores can only override modules the company has
+	companyModule, err := s.repo.GetCompanyModule(ctx, companyID, moduleID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, module.ErrModuleNotAssigned
+		}
+		return nil, err
+	}
+
+	mod, err := s.repo.GetModuleByID(ctx, moduleID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return fmt.Errorf("module not assigned to company")
+			return nil, module.ErrModuleNotFound
+		}
+		return nil, err
+	}
+	companyModule.Module = mod
+
+	// A store can turn a module off, but not on against the company
+	if *req.IsEnabled && !companyModule.IsEnabled {
+		return nil, module.ErrModuleNotEnabled
+	}
+
+	effective := module.ModuleConfig(mergePatch(companyModule.Config, req.Config))
+	if err := validateConfig(mod, effective, "config"); err != nil {
+		return nil, err
+	}
+
+	storeModule, err := s.repo.GetStoreModule(ctx, storeID, moduleID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	if storeModule == nil {
+		storeModule = &module.StoreModule{StoreID: storeID, ModuleID: moduleID}
+	}
+	storeModule.IsEnabled = *req.IsEnabled
+	storeModule.Config = req.Config
+
+	if err := s.repo.SaveStoreModule(ctx, storeModule); err != nil {
+		return nil, fmt.Errorf("failed to save store module: %w", err)
+	}
+	s.invalidateStore(storeID)
+
+	response := s.toEffectiveModuleResponse(companyModule, storeModule)
+	return &response, nil
+}
+
+func (s *moduleService) RemoveStoreModule(ctx context.Context, companyID, storeID, moduleID uint64) error {
+	if err := s.checkStore(ctx, companyID, storeID); err != nil {
+		return err
+	}
+
+	if _, err := s.repo.GetStoreModule(ctx, storeID, moduleID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return module.ErrNoStoreOverride
 		}
 		return err
 	}
 
-	return s.repo.RemoveModule(ctx, companyID, moduleID)
+	if err := s.repo.RemoveStoreModule(ctx, storeID, moduleID); err != nil {
+		return err
+	}
+	s.invalidateStore(storeID)
+	return nil
+}
+
+func (s *moduleService) IsEnabledForStore(ctx context.Context, companyID, storeID uint64, moduleKey string) (bool, error) {
+	enabled, err := s.IsEnabled(ctx, companyID, moduleKey)
+	if err != nil || !enabled {
+		return false, err
+	}
+
+	disabled, err := s.storeDisabledKeys(ctx, companyID, storeID)
+	if err != nil {
+		return false, err
+	}
+	return !disabled[moduleKey], nil
+}
+
+func (s *moduleService) RequireEnabledForStore(ctx context.Context, companyID, storeID uint64, moduleKey string) error {
+	enabled, err := s.IsEnabledForStore(ctx, companyID, storeID, moduleKey)
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		return module.NewDisabledError(moduleKey)
+	}
+	return nil
 }
 
 // Helper methods
+
+// moduleGraph returns every module by key
+func (s *moduleService) moduleGraph(ctx context.Context) (map[string]*module.ModuleMaster, error) {
+	modules, err := s.repo.ListAllModules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	graph := make(map[string]*module.ModuleMaster, len(modules))
+	for i := range modules {
+		graph[modules[i].ModuleKey] = &modules[i]
+	}
+	return graph, nil
+}
+
+// assignedModules returns the company's modules by module key
+func (s *moduleService) assignedModules(ctx context.Context, companyID uint64) (map[string]*module.CompanyModule, error) {
+	modules, err := s.repo.GetCompanyModules(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+	assigned := make(map[string]*module.CompanyModule, len(modules))
+	for i := range modules {
+		if modules[i].Module != nil {
+			assigned[modules[i].Module.ModuleKey] = &modules[i]
+		}
+	}
+	return assigned, nil
+}
+
+// enableDependency enables a required module, assigning it with its default
+// config when the company does not have it yet
+func (s *moduleService) enableDependency(ctx context.Context, companyID uint64, dep *module.ModuleMaster, existing *module.CompanyModule) error {
+	if existing != nil {
+		return s.repo.SetModuleEnabled(ctx, existing.ID, true)
+	}
+	return s.repo.AssignModule(ctx, &module.CompanyModule{
+		CompanyID: companyID,
+		ModuleID:  dep.ID,
+		IsEnabled: true,
+		Config:    module.ModuleConfig(mergePatch(dep.DefaultConfig, nil)),
+	})
+}
+
+// requiredModules returns the modules root requires, directly or through
+// other modules, with every module listed after the modules it requires
+func requiredModules(graph map[string]*module.ModuleMaster, root string) ([]*module.ModuleMaster, error) {
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := make(map[string]int)
+	var path []string
+	var order []*module.ModuleMaster
+
+	var visit func(key string) error
+	visit = func(key string) error {
+		switch state[key] {
+		case visited:
+			return nil
+		case visiting:
+			start := 0
+			for path[start] != key {
+				start++
+			}
+			cycle := append(append([]string{}, path[start:]...), key)
+			return &module.DependencyError{Code: module.CodeDependencyCycle, Module: root, Modules: cycle}
+		}
+
+		mod, ok := graph[key]
+		if !ok {
+			return fmt.Errorf("%w: %s", module.ErrModuleNotFound, key)
+		}
+
+		state[key] = visiting
+		path = append(path, key)
+		for _, dep := range mod.Requires {
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		state[key] = visited
+
+		if key != root {
+			order = append(order, mod)
+		}
+		return nil
+	}
+
+	if err := visit(root); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// checkDependencies returns the required modules that are not enabled for the
+// company, or a conflict error when the module or one of them conflicts with
+// an enabled module or with each other
+func checkDependencies(mod *module.ModuleMaster, required []*module.ModuleMaster, assigned map[string]*module.CompanyModule) ([]*module.ModuleMaster, error) {
+	enabling := append([]*module.ModuleMaster{mod}, required...)
+
+	conflicting := make(map[string]bool)
+	for _, m := range enabling {
+		for key, cm := range assigned {
+			if cm.IsEnabled && conflicts(m, cm.Module) {
+				conflicting[key] = true
+			}
+		}
+		for _, other := range enabling {
+			if conflicts(m, other) {
+				conflicting[other.ModuleKey] = true
+			}
+		}
+	}
+	if len(conflicting) > 0 {
+		keys := make([]string, 0, len(conflicting))
+		for key := range conflicting {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		return nil, &module.DependencyError{Code: module.CodeModuleConflict, Module: mod.ModuleKey, Modules: keys}
+	}
+
+	var missing []*module.ModuleMaster
+	for _, dep := range required {
+		if cm := assigned[dep.ModuleKey]; cm == nil || !cm.IsEnabled {
+			missing = append(missing, dep)
+		}
+	}
+	return missing, nil
+}
+
+// checkModuleSet verifies that a set of enabled modules holds every module
+// they require, contains no conflicting modules and has no dependency cycles
+func checkModuleSet(graph map[string]*module.ModuleMaster, enabled map[string]*module.ModuleMaster) error {
+	keys := make([]string, 0, len(enabled))
+	for key := range enabled {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		mod := enabled[key]
+		if _, err := requiredModules(graph, key); err != nil {
+			return err
+		}
+
+		var missing, conflicting []string
+		for _, dep := range mod.Requires {
+			if enabled[dep] == nil {
+				missing = append(missing, dep)
+			}
+		}
+		if len(missing) > 0 {
+			return &module.DependencyError{Code: module.CodeMissingDependencies, Module: key, Modules: missing}
+		}
+		for _, other := range keys {
+			if conflicts(mod, enabled[other]) {
+				conflicting = append(conflicting, other)
+			}
+		}
+		if len(conflicting) > 0 {
+			return &module.DependencyError{Code: module.CodeModuleConflict, Module: key, Modules: conflicting}
+		}
+	}
+	return nil
+}
+
+// provisionable reports whether a default module can be provisioned next to
+// the company's enabled modules and the other defaults being provisioned
+func provisionable(mod *module.ModuleMaster, enabled, candidates map[string]*module.ModuleMaster) bool {
+	for _, dep := range mod.Requires {
+		if enabled[dep] == nil && candidates[dep] == nil {
+			return false
+		}
+	}
+	for _, other := range enabled {
+		if conflicts(mod, other) {
+			return false
+		}
+	}
+	return true
+}
+
+// conflicts reports whether either module declares a conflict with the other
+func conflicts(a, b *module.ModuleMaster) bool {
+	return a.Conflicts.Contains(b.ModuleKey) || b.Conflicts.Contains(a.ModuleKey)
+}
+
+func moduleKeys(modules []*module.ModuleMaster) []string {
+	if len(modules) == 0 {
+		return nil
+	}
+	keys := make([]string, len(modules))
+	for i, m := range modules {
+		keys[i] = m.ModuleKey
+	}
+	return keys
+}
+
+// checkStore verifies the caller may act on the company and that the store belongs to it
+func (s *moduleService) checkStore(ctx context.Context, companyID, storeID uint64) error {
+	if !tenant.Allows(ctx, companyID) {
+		return company.ErrCompanyNotFound
+	}
+
+	st, err := s.stores.GetByID(ctx, storeID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return store.ErrStoreNotFound
+		}
+		return err
+	}
+	if st.CompanyID != companyID {
+		return store.ErrStoreNotFound
+	}
+	return nil
+}
+
+// storeDisabledKeys returns the module keys the store has turned off, from the cache when fresh
+func (s *moduleService) storeDisabledKeys(ctx context.Context, companyID, storeID uint64) (map[string]bool, error) {
+	now := time.Now()
+
+	s.cacheMu.Lock()
+	entry, ok := s.storeCache[storeID]
+	s.cacheMu.Unlock()
+	if ok && now.Before(entry.expiresAt) {
+		return entry.disabled, nil
+	}
+
+	list, err := s.repo.ListStoreDisabledModuleKeys(ctx, storeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load store modules: %w", err)
+	}
+	disabled := make(map[string]bool, len(list))
+	for _, key := range list {
+		disabled[key] = true
+	}
+
+	s.cacheMu.Lock()
+	s.storeCache[storeID] = storeOverrides{companyID: companyID, disabled: disabled, expiresAt: now.Add(enabledModulesTTL)}
+	s.cacheMu.Unlock()
+
+	return disabled, nil
+}
+
+// enabledKeys returns the company's enabled module keys, from the cache when fresh
+func (s *moduleService) enabledKeys(ctx context.Context, companyID uint64) (map[string]bool, error) {
+	now := time.Now()
+
+	s.cacheMu.Lock()
+	entry, ok := s.cache[companyID]
+	s.cacheMu.Unlock()
+	if ok && now.Before(entry.expiresAt) {
+		return entry.keys, nil
+	}
+
+	list, err := s.repo.ListEnabledModuleKeys(ctx, companyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load enabled modules: %w", err)
+	}
+	keys := make(map[string]bool, len(list))
+	for _, key := range list {
+		keys[key] = true
+	}
+
+	s.cacheMu.Lock()
+	s.cache[companyID] = enabledModules{keys: keys, expiresAt: now.Add(enabledModulesTTL)}
+	s.cacheMu.Unlock()
+
+	return keys, nil
+}
+
+// invalidate drops the company's cached enabled modules, and those of its
+// stores, after a change
+func (s *moduleService) invalidate(companyID uint64) {
+	s.cacheMu.Lock()
+	delete(s.cache, companyID)
+	for storeID, entry := range s.storeCache {
+		if entry.companyID == companyID {
+			delete(s.storeCache, storeID)
+		}
+	}
+	s.cacheMu.Unlock()
+}
+
+// invalidateStore drops the store's cached overrides after a change
+func (s *moduleService) invalidateStore(storeID uint64) {
+	s.cacheMu.Lock()
+	delete(s.storeCache, storeID)
+	s.cacheMu.Unlock()
+}
+
+// validateConfig checks config against the module's config schema, if it has
+// one. field is where the config sits in the request body and prefixes the
+// paths of the errors.
+func validateConfig(mod *module.ModuleMaster, config module.ModuleConfig, field string) error {
+	if errs := configFieldErrors(mod, config, field); len(errs) > 0 {
+		return &module.ConfigError{Errors: errs}
+	}
+	return nil
+}
+
+// configFieldErrors returns the schema errors of config with field paths
+// relative to the request body
+func configFieldErrors(mod *module.ModuleMaster, config module.ModuleConfig, field string) []jsonschema.FieldError {
+	if mod.ConfigSchema == nil {
+		return nil
+	}
+	errs := mod.ConfigSchema.Validate(map[string]interface{}(config))
+	for i := range errs {
+		if errs[i].Field == "" {
+			errs[i].Field = field
+		} else {
+			errs[i].Field = field + "." + errs[i].Field
+		}
+	}
+	return errs
+}
+
+// sameConfig reports whether two configs hold the same JSON
+func sameConfig(a, b module.ModuleConfig) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+	aJSON, errA := json.Marshal(a)
+	bJSON, errB := json.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(aJSON, bJSON)
+}
+
+// mergePatch applies a JSON merge patch (RFC 7386) to target without
+// modifying it: null values remove keys and nested objects are merged
+func mergePatch(target, patch map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(target)+len(patch))
+	for key, value := range target {
+		merged[key] = value
+	}
+	for key, value := range patch {
+		if value == nil {
+			delete(merged, key)
+			continue
+		}
+		if nested, ok := value.(map[string]interface{}); ok {
+			current, _ := merged[key].(map[string]interface{})
+			merged[key] = mergePatch(current, nested)
+			continue
+		}
+		merged[key] = value
+	}
+	return merged
+}
+
 func (s *moduleService) toModuleResponse(m *module.ModuleMaster) module.ModuleResponse {
 	return module.ModuleResponse{
 		ID:             m.ID,
@@ -106,6 +989,10 @@ func (s *moduleService) toModuleResponse(m *module.ModuleMaster) module.ModuleRe
 		Category:       m.Category,
 		Description:    m.Description,
 		DefaultEnabled: m.DefaultEnabled,
+		ConfigSchema:   m.ConfigSchema,
+		DefaultConfig:  m.DefaultConfig,
+		Requires:       m.Requires,
+		Conflicts:      m.Conflicts,
 		CreatedAt:      m.CreatedAt,
 	}
 }
@@ -126,3 +1013,28 @@ func (s *moduleService) toCompanyModuleResponse(cm *module.CompanyModule) *modul
 
 	return response
 }
+
+// toEffectiveModuleResponse layers a store override, which may be nil, over
+// the company module: the module is enabled only when both allow it, and the
+// override config is merged over the company config
+func (s *moduleService) toEffectiveModuleResponse(cm *module.CompanyModule, override *module.StoreModule) module.EffectiveModuleResponse {
+	response := module.EffectiveModuleResponse{
+		IsEnabled: cm.IsEnabled,
+		Config:    cm.Config,
+	}
+	if cm.Module != nil {
+		response.Module = s.toModuleResponse(cm.Module)
+	}
+
+	if override != nil {
+		response.IsEnabled = cm.IsEnabled && override.IsEnabled
+		response.Config = module.ModuleConfig(mergePatch(cm.Config, override.Config))
+		response.Override = &module.StoreModuleOverride{
+			IsEnabled: override.IsEnabled,
+			Config:    override.Config,
+			UpdatedAt: override.UpdatedAt,
+		}
+	}
+
+	return response
+}
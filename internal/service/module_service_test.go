@@ -0,0 +1,249 @@
+package service
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"my-go-driver/internal/domain/module"
+)
+
+func TestMergePatch(t *testing.T) {
+	tests := []struct {
+		name   string
+		target map[string]interface{}
+		patch  map[string]interface{}
+		want   map[string]interface{}
+	}{
+		{
+			name:   "adds and replaces values",
+			target: map[string]interface{}{"a": "b", "c": 1.0},
+			patch:  map[string]interface{}{"a": "z", "d": true},
+			want:   map[string]interface{}{"a": "z", "c": 1.0, "d": true},
+		},
+		{
+			name:   "null removes a key",
+			target: map[string]interface{}{"a": "b", "c": "d"},
+			patch:  map[string]interface{}{"a": nil},
+			want:   map[string]interface{}{"c": "d"},
+		},
+		{
+			name:   "null for a missing key is ignored",
+			target: map[string]interface{}{"a": "b"},
+			patch:  map[string]interface{}{"x": nil},
+			want:   map[string]interface{}{"a": "b"},
+		},
+		{
+			name:   "nested objects are merged",
+			target: map[string]interface{}{"a": map[string]interface{}{"b": "c", "d": "e"}},
+			patch:  map[string]interface{}{"a": map[string]interface{}{"b": "x", "d": nil}},
+			want:   map[string]interface{}{"a": map[string]interface{}{"b": "x"}},
+		},
+		{
+			name:   "object replaces a scalar without its nulls",
+			target: map[string]interface{}{"a": "b"},
+			patch:  map[string]interface{}{"a": map[string]interface{}{"c": "d", "e": nil}},
+			want:   map[string]interface{}{"a": map[string]interface{}{"c": "d"}},
+		},
+		{
+			name:   "arrays are replaced",
+			target: map[string]interface{}{"a": []interface{}{"b", "c"}},
+			patch:  map[string]interface{}{"a": []interface{}{"d"}},
+			want:   map[string]interface{}{"a": []interface{}{"d"}},
+		},
+		{
+			name:   "nil patch copies the target",
+			target: map[string]interface{}{"a": "b"},
+			want:   map[string]interface{}{"a": "b"},
+		},
+		{
+			name:  "nil target",
+			patch: map[string]interface{}{"a": "b", "c": nil},
+			want:  map[string]interface{}{"a": "b"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := mergePatch(tt.target, tt.patch)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("mergePatch() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergePatchLeavesTargetUnchanged(t *testing.T) {
+	target := map[string]interface{}{"a": "b", "n": map[string]interface{}{"c": "d"}}
+	mergePatch(target, map[string]interface{}{"a": nil, "n": map[string]interface{}{"c": nil}})
+
+	want := map[string]interface{}{"a": "b", "n": map[string]interface{}{"c": "d"}}
+	if !reflect.DeepEqual(target, want) {
+		t.Errorf("target = %v, want %v", target, want)
+	}
+}
+
+func TestRequiredModules(t *testing.T) {
+	tests := []struct {
+		name     string
+		requires map[string][]string
+		root     string
+		want     []string
+		cycle    []string
+		err      error
+	}{
+		{
+			name:     "no dependencies",
+			requires: map[string][]string{"a": nil},
+			root:     "a",
+			want:     []string{},
+		},
+		{
+			name:     "chain lists dependencies first",
+			requires: map[string][]string{"a": {"b"}, "b": {"c"}, "c": nil},
+			root:     "a",
+			want:     []string{"c", "b"},
+		},
+		{
+			name:     "shared dependency is listed once",
+			requires: map[string][]string{"a": {"b", "c"}, "b": {"d"}, "c": {"d"}, "d": nil},
+			root:     "a",
+			want:     []string{"d", "b", "c"},
+		},
+		{
+			name:     "cycle through the root",
+			requires: map[string][]string{"a": {"b"}, "b": {"a"}},
+			root:     "a",
+			cycle:    []string{"a", "b", "a"},
+		},
+		{
+			name:     "cycle below the root",
+			requires: map[string][]string{"a": {"b"}, "b": {"c"}, "c": {"d"}, "d": {"b"}},
+			root:     "a",
+			cycle:    []string{"b", "c", "d", "b"},
+		},
+		{
+			name:     "module requiring itself",
+			requires: map[string][]string{"a": {"a"}},
+			root:     "a",
+			cycle:    []string{"a", "a"},
+		},
+		{
+			name:     "unknown dependency",
+			requires: map[string][]string{"a": {"b"}},
+			root:     "a",
+			err:      module.ErrModuleNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			graph := make(map[string]*module.ModuleMaster, len(tt.requires))
+			for key, requires := range tt.requires {
+				graph[key] = &module.ModuleMaster{ModuleKey: key, Requires: requires}
+			}
+
+			got, err := requiredModules(graph, tt.root)
+
+			if tt.cycle != nil {
+				var depErr *module.DependencyError
+				if !errors.As(err, &depErr) || !errors.Is(err, module.ErrDependencyCycle) {
+					t.Fatalf("requiredModules() error = %v, want a dependency cycle", err)
+				}
+				if depErr.Module != tt.root || !reflect.DeepEqual(depErr.Modules, tt.cycle) {
+					t.Errorf("cycle = %s %v, want %s %v", depErr.Module, depErr.Modules, tt.root, tt.cycle)
+				}
+				return
+			}
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Fatalf("requiredModules() error = %v, want %v", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("requiredModules() error = %v", err)
+			}
+
+			keys := []string{}
+			for _, m := range got {
+				keys = append(keys, m.ModuleKey)
+			}
+			if !reflect.DeepEqual(keys, tt.want) {
+				t.Errorf("requiredModules() = %v, want %v", keys, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckModuleSet(t *testing.T) {
+	graph := map[string]*module.ModuleMaster{
+		"orders":   {ModuleKey: "orders"},
+		"clients":  {ModuleKey: "clients"},
+		"billing":  {ModuleKey: "billing", Requires: module.ModuleKeys{"orders", "clients"}},
+		"routing":  {ModuleKey: "routing", Conflicts: module.ModuleKeys{"ai_route"}},
+		"ai_route": {ModuleKey: "ai_route"},
+		"loop_a":   {ModuleKey: "loop_a", Requires: module.ModuleKeys{"loop_b"}},
+		"loop_b":   {ModuleKey: "loop_b", Requires: module.ModuleKeys{"loop_a"}},
+	}
+
+	tests := []struct {
+		name        string
+		enabled     []string
+		wantErr     error
+		wantModule  string
+		wantModules []string
+	}{
+		{
+			name:    "dependencies enabled",
+			enabled: []string{"orders", "clients", "billing"},
+		},
+		{
+			name:        "missing dependency",
+			enabled:     []string{"orders", "billing"},
+			wantErr:     module.ErrMissingDependencies,
+			wantModule:  "billing",
+			wantModules: []string{"clients"},
+		},
+		{
+			name:        "conflict declared by the other module",
+			enabled:     []string{"ai_route", "routing"},
+			wantErr:     module.ErrModuleConflict,
+			wantModule:  "ai_route",
+			wantModules: []string{"routing"},
+		},
+		{
+			name:    "conflicting module not enabled",
+			enabled: []string{"routing", "orders"},
+		},
+		{
+			name:    "dependency cycle",
+			enabled: []string{"loop_a", "loop_b"},
+			wantErr: module.ErrDependencyCycle,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			enabled := make(map[string]*module.ModuleMaster, len(tt.enabled))
+			for _, key := range tt.enabled {
+				enabled[key] = graph[key]
+			}
+
+			err := checkModuleSet(graph, enabled)
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Fatalf("checkModuleSet() error = %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("checkModuleSet() error = %v, want %v", err, tt.wantErr)
+			}
+			var depErr *module.DependencyError
+			if tt.wantModule != "" && (!errors.As(err, &depErr) || depErr.Module != tt.wantModule || !reflect.DeepEqual(depErr.Modules, tt.wantModules)) {
+				t.Errorf("checkModuleSet() error = %v, want %s %v", err, tt.wantModule, tt.wantModules)
+			}
+		})
+	}
+}
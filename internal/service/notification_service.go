@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	"my-go-driver/internal/domain/module"
+	"my-go-driver/internal/domain/notification"
+	"my-go-driver/internal/tenant"
+
+	"gorm.io/gorm"
+)
+
+type notificationService struct {
+	repo    notification.Repository
+	modules module.Service
+}
+
+// NewNotificationService creates a new notification service
+func NewNotificationService(repo notification.Repository, modules module.Service) notification.Service {
+	return &notificationService{
+		repo:    repo,
+		modules: modules,
+	}
+}
+
+func (s *notificationService) Notify(ctx context.Context, n *notification.Notification) (bool, error) {
+	enabled, err := s.modules.IsEnabled(ctx, n.CompanyID, module.KeyNotifications)
+	if err != nil {
+		return false, err
+	}
+	if !enabled {
+		return false, nil
+	}
+
+	if err := s.repo.Create(ctx, n); err != nil {
+		return false, fmt.Errorf("failed to create notification: %w", err)
+	}
+	return true, nil
+}
+
+func (s *notificationService) ListNotifications(ctx context.Context, query notification.ListNotificationsQuery) (*notification.PaginatedNotificationsResponse, error) {
+	// Company admins only ever see their own company's notifications
+	if companyID, ok := tenant.CompanyID(ctx); ok {
+		query.CompanyID = companyID
+	}
+
+	notifications, total, err := s.repo.List(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set defaults
+	if query.Page <= 0 {
+		query.Page = 1
+	}
+	if query.Limit <= 0 {
+		query.Limit = 10
+	}
+
+	responses := make([]notification.NotificationResponse, len(notifications))
+	for i, n := range notifications {
+		responses[i] = s.toNotificationResponse(&n)
+	}
+
+	totalPages := int(math.Ceil(float64(total) / float64(query.Limit)))
+
+	return &notification.PaginatedNotificationsResponse{
+		Notifications: responses,
+		TotalCount:    total,
+		Page:          query.Page,
+		Limit:         query.Limit,
+		TotalPages:    totalPages,
+	}, nil
+}
+
+func (s *notificationService) MarkRead(ctx context.Context, id, userID uint64) (*notification.NotificationResponse, error) {
+	n, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, notification.ErrNotificationNotFound
+		}
+		return nil, err
+	}
+
+	// Admins cannot see driver notifications or those addressed to another admin
+	if !tenant.Allows(ctx, n.CompanyID) || n.DriverID != nil || (n.UserID != nil && *n.UserID != userID) {
+		return nil, notification.ErrNotificationNotFound
+	}
+
+	if err := s.repo.MarkRead(ctx, id, userID); err != nil {
+		return nil, fmt.Errorf("failed to mark notification as read: %w", err)
+	}
+	n.IsRead = true
+
+	response := s.toNotificationResponse(n)
+	return &response, nil
+}
+
+// Helper methods
+func (s *notificationService) toNotificationResponse(n *notification.Notification) notification.NotificationResponse {
+	return notification.NotificationResponse{
+		ID:        n.ID,
+		CompanyID: n.CompanyID,
+		UserID:    n.UserID,
+		Type:      n.Type,
+		Title:     n.Title,
+		Body:      n.Body,
+		Data:      n.Data,
+		IsRead:    n.IsRead,
+		CreatedAt: n.CreatedAt,
+	}
+}
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"my-go-driver/internal/domain/company"
+	"my-go-driver/internal/domain/module"
+	"my-go-driver/internal/domain/plan"
+
+	"gorm.io/gorm"
+)
+
+type planService struct {
+	companyRepo company.Repository
+	moduleRepo  module.Repository
+}
+
+// NewPlanService creates a new plan service
+func NewPlanService(companyRepo company.Repository, moduleRepo module.Repository) plan.Service {
+	return &planService{
+		companyRepo: companyRepo,
+		moduleRepo:  moduleRepo,
+	}
+}
+
+func (s *planService) ListPlans(ctx context.Context) ([]plan.Plan, error) {
+	return plan.All(), nil
+}
+
+func (s *planService) CheckModule(ctx context.Context, companyID uint64, moduleKey string) error {
+	c, err := s.companyRepo.GetByID(ctx, companyID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return company.ErrCompanyNotFound
+		}
+		return err
+	}
+
+	p, ok := plan.Get(c.Plan)
+	if !ok {
+		return fmt.Errorf("%w: %s", plan.ErrUnknownPlan, c.Plan)
+	}
+	if !p.Includes(moduleKey) {
+		return fmt.Errorf("%w: the %s plan does not include %s", plan.ErrModuleNotInPlan, p.Key, moduleKey)
+	}
+	return nil
+}
+
+func (s *planService) PreviewChange(ctx context.Context, companyID uint64, from, to company.BillingPlan) (*company.PlanChange, error) {
+	fromPlan, ok := plan.Get(from)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", plan.ErrUnknownPlan, from)
+	}
+	toPlan, ok := plan.Get(to)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", plan.ErrUnknownPlan, to)
+	}
+
+	assigned, err := s.moduleRepo.GetCompanyModules(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	change := &company.PlanChange{
+		From:            from,
+		To:              to,
+		ModulesToRemove: []string{},
+		ModulesUnlocked: []string{},
+		QuotaChanges:    []company.QuotaChange{},
+	}
+	for _, cm := range assigned {
+		if cm.Module != nil && !toPlan.Includes(cm.Module.ModuleKey) {
+			change.ModulesToRemove = append(change.ModulesToRemove, cm.Module.ModuleKey)
+		}
+	}
+	for _, key := range toPlan.Modules {
+		if !fromPlan.Includes(key) {
+			change.ModulesUnlocked = append(change.ModulesUnlocked, key)
+		}
+	}
+
+	return change, nil
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+
+	"my-go-driver/internal/domain/company"
+	"my-go-driver/internal/domain/module"
+	"my-go-driver/internal/domain/plan"
+)
+
+// assignedModuleRepository returns a fixed set of company modules. Only
+// GetCompanyModules is implemented.
+type assignedModuleRepository struct {
+	module.Repository
+	keys []string
+}
+
+func (r assignedModuleRepository) GetCompanyModules(ctx context.Context, companyID uint64) ([]module.CompanyModule, error) {
+	modules := make([]module.CompanyModule, len(r.keys))
+	for i, key := range r.keys {
+		modules[i] = module.CompanyModule{CompanyID: companyID, Module: &module.ModuleMaster{ModuleKey: key}}
+	}
+	return modules, nil
+}
+
+func TestPlanServicePreviewChange(t *testing.T) {
+	tests := []struct {
+		name         string
+		from, to     company.BillingPlan
+		assigned     []string
+		wantRemove   []string
+		wantUnlocked []string
+	}{
+		{
+			name:     "upgrade unlocks modules and removes none",
+			from:     company.PlanFree,
+			to:       company.PlanBasic,
+			assigned: []string{"driver_management", "gps_tracking"},
+			wantUnlocked: []string{
+				"reports_analytics", "product_inventory", "cash_handling", "chat_communication",
+				"zone_territory", "incident_reporting", "otp_qr_delivery", "task_checklist",
+				"return_to_depot", "cash_collection", "driver_admin_chat", "issue_reporting",
+			},
+		},
+		{
+			name:       "downgrade removes modules outside the new plan",
+			from:       company.PlanPro,
+			to:         company.PlanFree,
+			assigned:   []string{"driver_management", "api_access", "reports_analytics"},
+			wantRemove: []string{"api_access", "reports_analytics"},
+		},
+		{
+			name:     "same plan changes nothing",
+			from:     company.PlanBasic,
+			to:       company.PlanBasic,
+			assigned: []string{"reports_analytics"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewPlanService(nil, assignedModuleRepository{keys: tt.assigned})
+
+			change, err := s.PreviewChange(context.Background(), 1, tt.from, tt.to)
+			if err != nil {
+				t.Fatalf("PreviewChange: %v", err)
+			}
+
+			wantRemove := tt.wantRemove
+			if wantRemove == nil {
+				wantRemove = []string{}
+			}
+			wantUnlocked := tt.wantUnlocked
+			if wantUnlocked == nil {
+				wantUnlocked = []string{}
+			}
+			sort.Strings(change.ModulesToRemove)
+			sort.Strings(wantRemove)
+			if !reflect.DeepEqual(change.ModulesToRemove, wantRemove) {
+				t.Errorf("ModulesToRemove = %v, want %v", change.ModulesToRemove, wantRemove)
+			}
+			if !reflect.DeepEqual(change.ModulesUnlocked, wantUnlocked) {
+				t.Errorf("ModulesUnlocked = %v, want %v", change.ModulesUnlocked, wantUnlocked)
+			}
+			if change.From != tt.from || change.To != tt.to {
+				t.Errorf("change = %s to %s, want %s to %s", change.From, change.To, tt.from, tt.to)
+			}
+		})
+	}
+}
+
+func TestPlanServicePreviewChangeUnknownPlan(t *testing.T) {
+	s := NewPlanService(nil, assignedModuleRepository{})
+	if _, err := s.PreviewChange(context.Background(), 1, company.PlanFree, "platinum"); !errors.Is(err, plan.ErrUnknownPlan) {
+		t.Fatalf("PreviewChange error = %v, want %v", err, plan.ErrUnknownPlan)
+	}
+}
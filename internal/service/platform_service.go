@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"my-go-driver/internal/domain/loginguard"
+	"my-go-driver/internal/domain/platform"
+	"my-go-driver/internal/domain/session"
+	"my-go-driver/pkg/hash"
+	"my-go-driver/pkg/jwt"
+
+	"gorm.io/gorm"
+)
+
+type platformService struct {
+	repo     platform.Repository
+	sessions session.Service
+	guard    loginguard.Service
+}
+
+// NewPlatformService creates a new platform operator service
+func NewPlatformService(repo platform.Repository, sessions session.Service, guard loginguard.Service) platform.Service {
+	return &platformService{
+		repo:     repo,
+		sessions: sessions,
+		guard:    guard,
+	}
+}
+
+func (s *platformService) CreateAdmin(ctx context.Context, req platform.CreateAdminRequest) (*platform.AdminResponse, error) {
+	// Check if email already exists
+	existing, err := s.repo.GetByEmail(ctx, req.Email)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("error checking email: %w", err)
+	}
+	if existing != nil {
+		return nil, fmt.Errorf("platform admin email already exists")
+	}
+
+	hashedPassword, err := hash.HashPassword(req.Password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	admin := &platform.Admin{
+		Name:         req.Name,
+		Email:        req.Email,
+		PasswordHash: hashedPassword,
+		IsActive:     true,
+	}
+
+	if err := s.repo.Create(ctx, admin); err != nil {
+		return nil, fmt.Errorf("failed to create platform admin: %w", err)
+	}
+
+	response := s.toAdminResponse(admin)
+	return &response, nil
+}
+
+func (s *platformService) Login(ctx context.Context, req platform.LoginRequest) (*platform.LoginResponse, error) {
+	attempt := loginguard.Attempt{
+		SubjectType: jwt.SubjectPlatform,
+		Identifier:  req.Email,
+		IPAddress:   req.IPAddress,
+		UserAgent:   req.UserAgent,
+	}
+	if err := s.guard.Check(ctx, attempt); err != nil {
+		return nil, err
+	}
+
+	admin, err := s.repo.GetByEmail(ctx, req.Email)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			hash.CheckDummy(req.Password)
+			return nil, s.loginFailed(ctx, attempt)
+		}
+		return nil, err
+	}
+	attempt.SubjectID = &admin.ID
+
+	// Verify password
+	if !hash.CheckPasswordHash(req.Password, admin.PasswordHash) {
+		return nil, s.loginFailed(ctx, attempt)
+	}
+
+	// Inactive accounts fail like wrong passwords, so they cannot be told apart
+	if !admin.IsActive {
+		return nil, s.loginFailed(ctx, attempt)
+	}
+
+	if err := s.guard.Succeeded(ctx, attempt); err != nil {
+		return nil, err
+	}
+
+	// Start a platform session; operators are not bound to a company
+	tokens, err := s.sessions.Issue(ctx, jwt.Subject{Type: jwt.SubjectPlatform, ID: admin.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	return &platform.LoginResponse{
+		Admin:        s.toAdminResponse(admin),
+		Token:        tokens.Token,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresAt:    tokens.ExpiresAt,
+	}, nil
+}
+
+func (s *platformService) GetProfile(ctx context.Context, adminID uint64) (*platform.AdminResponse, error) {
+	admin, err := s.repo.GetByID(ctx, adminID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, platform.ErrAdminNotFound
+		}
+		return nil, err
+	}
+
+	response := s.toAdminResponse(admin)
+	return &response, nil
+}
+
+// Helper methods
+
+// loginFailed records a failed login and returns the error shown to the caller
+func (s *platformService) loginFailed(ctx context.Context, attempt loginguard.Attempt) error {
+	if err := s.guard.Failed(ctx, attempt); err != nil {
+		return err
+	}
+	return fmt.Errorf("invalid email or password")
+}
+
+func (s *platformService) toAdminResponse(admin *platform.Admin) platform.AdminResponse {
+	return platform.AdminResponse{
+		ID:        admin.ID,
+		Name:      admin.Name,
+		Email:     admin.Email,
+		IsActive:  admin.IsActive,
+		CreatedAt: admin.CreatedAt,
+		UpdatedAt: admin.UpdatedAt,
+	}
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"my-go-driver/internal/domain/company"
+	"my-go-driver/internal/domain/quota"
+
+	"gorm.io/gorm"
+)
+
+type quotaService struct {
+	repo        quota.Repository
+	companyRepo company.Repository
+}
+
+// NewQuotaService creates a new quota service
+func NewQuotaService(repo quota.Repository, companyRepo company.Repository) quota.Service {
+	return &quotaService{
+		repo:        repo,
+		companyRepo: companyRepo,
+	}
+}
+
+func (s *quotaService) CheckDrivers(ctx context.Context, companyID uint64, adding int) error {
+	c, err := s.findCompany(ctx, companyID)
+	if err != nil {
+		return err
+	}
+	return s.check(ctx, quota.ResourceDrivers, companyID, c.MaxAllowedDrivers, adding)
+}
+
+func (s *quotaService) CheckSeats(ctx context.Context, companyID uint64, adding int) error {
+	c, err := s.findCompany(ctx, companyID)
+	if err != nil {
+		return err
+	}
+	return s.check(ctx, quota.ResourceSeats, companyID, c.SeatsLimit, adding)
+}
+
+func (s *quotaService) CheckLimits(ctx context.Context, companyID uint64, maxDrivers, seats int) error {
+	if _, err := s.findCompany(ctx, companyID); err != nil {
+		return err
+	}
+	if err := s.checkLimit(ctx, quota.ResourceDrivers, companyID, maxDrivers); err != nil {
+		return err
+	}
+	return s.checkLimit(ctx, quota.ResourceSeats, companyID, seats)
+}
+
+// Helper methods
+
+// check returns a quota_exceeded error when adding more of the resource would
+// exceed limit. A limit of zero is unlimited.
+func (s *quotaService) check(ctx context.Context, resource quota.Resource, companyID uint64, limit, adding int) error {
+	if limit <= 0 {
+		return nil
+	}
+	used, err := s.count(ctx, resource, companyID)
+	if err != nil {
+		return err
+	}
+	if used+int64(adding) > int64(limit) {
+		return &quota.Error{Code: quota.CodeQuotaExceeded, Resource: resource, Limit: limit, Used: used}
+	}
+	return nil
+}
+
+// checkLimit returns a limit_below_usage error when limit is lower than the
+// current usage. A limit of zero is unlimited.
+func (s *quotaService) checkLimit(ctx context.Context, resource quota.Resource, companyID uint64, limit int) error {
+	if limit <= 0 {
+		return nil
+	}
+	used, err := s.count(ctx, resource, companyID)
+	if err != nil {
+		return err
+	}
+	if int64(limit) < used {
+		return &quota.Error{Code: quota.CodeLimitBelowUsage, Resource: resource, Limit: limit, Used: used}
+	}
+	return nil
+}
+
+// count returns the company's current usage of the resource
+func (s *quotaService) count(ctx context.Context, resource quota.Resource, companyID uint64) (int64, error) {
+	var (
+		used int64
+		err  error
+	)
+	switch resource {
+	case quota.ResourceDrivers:
+		used, err = s.repo.CountActiveDrivers(ctx, companyID)
+	case quota.ResourceSeats:
+		used, err = s.repo.CountActiveAdmins(ctx, companyID)
+	default:
+		return 0, fmt.Errorf("unknown quota resource %q", resource)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to count %s: %w", resource, err)
+	}
+	return used, nil
+}
+
+// findCompany loads the company whose limits apply, locking it so usage is
+// counted by one check at a time
+func (s *quotaService) findCompany(ctx context.Context, id uint64) (*company.Company, error) {
+	c, err := s.companyRepo.GetByIDForUpdate(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, company.ErrCompanyNotFound
+		}
+		return nil, err
+	}
+	return c, nil
+}
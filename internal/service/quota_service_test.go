@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"my-go-driver/internal/domain/company"
+	"my-go-driver/internal/domain/quota"
+)
+
+// fixedQuotaRepository reports fixed usage counts
+type fixedQuotaRepository struct {
+	drivers int64
+	admins  int64
+}
+
+func (r fixedQuotaRepository) CountActiveDrivers(ctx context.Context, companyID uint64) (int64, error) {
+	return r.drivers, nil
+}
+
+func (r fixedQuotaRepository) CountActiveAdmins(ctx context.Context, companyID uint64) (int64, error) {
+	return r.admins, nil
+}
+
+func TestQuotaServiceCheck(t *testing.T) {
+	tests := []struct {
+		name     string
+		resource quota.Resource
+		limit    int
+		used     int64
+		adding   int
+		wantErr  *quota.Error
+	}{
+		{
+			name:     "drivers below the limit",
+			resource: quota.ResourceDrivers,
+			limit:    10,
+			used:     8,
+			adding:   2,
+		},
+		{
+			name:     "drivers over the limit",
+			resource: quota.ResourceDrivers,
+			limit:    10,
+			used:     9,
+			adding:   2,
+			wantErr:  &quota.Error{Code: quota.CodeQuotaExceeded, Resource: quota.ResourceDrivers, Limit: 10, Used: 9},
+		},
+		{
+			name:     "drivers at the limit",
+			resource: quota.ResourceDrivers,
+			limit:    10,
+			used:     10,
+			adding:   1,
+			wantErr:  &quota.Error{Code: quota.CodeQuotaExceeded, Resource: quota.ResourceDrivers, Limit: 10, Used: 10},
+		},
+		{
+			name:     "unlimited drivers",
+			resource: quota.ResourceDrivers,
+			used:     500,
+			adding:   1,
+		},
+		{
+			name:     "seats up to the limit exactly",
+			resource: quota.ResourceSeats,
+			limit:    5,
+			used:     4,
+			adding:   1,
+		},
+		{
+			name:     "seats at the limit",
+			resource: quota.ResourceSeats,
+			limit:    5,
+			used:     5,
+			adding:   1,
+			wantErr:  &quota.Error{Code: quota.CodeQuotaExceeded, Resource: quota.ResourceSeats, Limit: 5, Used: 5},
+		},
+		{
+			name:     "unlimited seats",
+			resource: quota.ResourceSeats,
+			used:     500,
+			adding:   1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := company.Company{ID: 1}
+			repo := fixedQuotaRepository{}
+			if tt.resource == quota.ResourceDrivers {
+				c.MaxAllowedDrivers = tt.limit
+				repo.drivers = tt.used
+			} else {
+				c.SeatsLimit = tt.limit
+				repo.admins = tt.used
+			}
+			s := NewQuotaService(repo, newMemoryCompanyRepository(c))
+			check := s.CheckDrivers
+			if tt.resource == quota.ResourceSeats {
+				check = s.CheckSeats
+			}
+
+			err := check(context.Background(), 1, tt.adding)
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Fatalf("check error = %v, want none", err)
+				}
+				return
+			}
+
+			var quotaErr *quota.Error
+			if !errors.As(err, &quotaErr) {
+				t.Fatalf("check error = %v, want a *quota.Error", err)
+			}
+			if *quotaErr != *tt.wantErr {
+				t.Fatalf("check error = %+v, want %+v", *quotaErr, *tt.wantErr)
+			}
+			if !errors.Is(err, quota.ErrQuotaExceeded) {
+				t.Fatalf("check error = %v, want it to match %v", err, quota.ErrQuotaExceeded)
+			}
+		})
+	}
+}
+
+func TestQuotaServiceCheckLimits(t *testing.T) {
+	tests := []struct {
+		name       string
+		maxDrivers int
+		seats      int
+		wantErr    *quota.Error
+	}{
+		{
+			name:       "limits at usage",
+			maxDrivers: 4,
+			seats:      2,
+		},
+		{
+			name:       "unlimited",
+			maxDrivers: 0,
+			seats:      0,
+		},
+		{
+			name:       "driver limit below usage",
+			maxDrivers: 3,
+			seats:      2,
+			wantErr:    &quota.Error{Code: quota.CodeLimitBelowUsage, Resource: quota.ResourceDrivers, Limit: 3, Used: 4},
+		},
+		{
+			name:       "seat limit below usage",
+			maxDrivers: 4,
+			seats:      1,
+			wantErr:    &quota.Error{Code: quota.CodeLimitBelowUsage, Resource: quota.ResourceSeats, Limit: 1, Used: 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewQuotaService(fixedQuotaRepository{drivers: 4, admins: 2}, newMemoryCompanyRepository(company.Company{ID: 1}))
+
+			err := s.CheckLimits(context.Background(), 1, tt.maxDrivers, tt.seats)
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Fatalf("CheckLimits error = %v, want none", err)
+				}
+				return
+			}
+
+			var quotaErr *quota.Error
+			if !errors.As(err, &quotaErr) || *quotaErr != *tt.wantErr {
+				t.Fatalf("CheckLimits error = %v, want %+v", err, *tt.wantErr)
+			}
+			if !errors.Is(err, quota.ErrLimitBelowUsage) {
+				t.Fatalf("CheckLimits error = %v, want it to match %v", err, quota.ErrLimitBelowUsage)
+			}
+		})
+	}
+}
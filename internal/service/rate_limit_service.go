@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"my-go-driver/internal/domain/company"
+	"my-go-driver/internal/domain/ratelimit"
+	"my-go-driver/pkg/tokenbucket"
+
+	"gorm.io/gorm"
+)
+
+type rateLimitService struct {
+	store       tokenbucket.Store
+	companyRepo company.Repository
+	window      time.Duration
+	apiKeyLimit int
+	driverLimit int
+}
+
+// NewRateLimitService creates a new rate limit service. A company's
+// APIRateLimit is the number of requests it may make per window; a single API
+// key or driver is further held to apiKeyLimit or driverLimit per window when
+// those are lower. Limits are read on every request, so changes to a company
+// apply immediately.
+func NewRateLimitService(store tokenbucket.Store, companyRepo company.Repository, window time.Duration, apiKeyLimit, driverLimit int) ratelimit.Service {
+	return &rateLimitService{
+		store:       store,
+		companyRepo: companyRepo,
+		window:      window,
+		apiKeyLimit: apiKeyLimit,
+		driverLimit: driverLimit,
+	}
+}
+
+func (s *rateLimitService) Allow(ctx context.Context, req ratelimit.Request) (*tokenbucket.Result, error) {
+	c, err := s.companyRepo.GetByID(ctx, req.CompanyID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, company.ErrCompanyNotFound
+		}
+		return nil, err
+	}
+
+	// A limit of zero disables rate limiting for the company
+	if c.APIRateLimit <= 0 {
+		return nil, nil
+	}
+
+	now := time.Now()
+
+	// The caller's own bucket is checked first so a rejected request does
+	// not use up the company's allowance
+	var principal *tokenbucket.Result
+	switch {
+	case req.APIKeyID != nil:
+		principal, err = s.take(ctx, fmt.Sprintf("api_key:%d", *req.APIKeyID), principalLimit(s.apiKeyLimit, c.APIRateLimit), now)
+	case req.DriverID != nil:
+		principal, err = s.take(ctx, fmt.Sprintf("driver:%d", *req.DriverID), principalLimit(s.driverLimit, c.APIRateLimit), now)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if principal != nil && !principal.Allowed {
+		return principal, nil
+	}
+
+	result, err := s.take(ctx, fmt.Sprintf("company:%d", c.ID), c.APIRateLimit, now)
+	if err != nil {
+		return nil, err
+	}
+	if result.Allowed && principal != nil && principal.Remaining < result.Remaining {
+		return principal, nil
+	}
+	return result, nil
+}
+
+// Helper methods
+
+// take takes a token from the bucket for key
+func (s *rateLimitService) take(ctx context.Context, key string, limit int, now time.Time) (*tokenbucket.Result, error) {
+	result, err := s.store.Take(ctx, key, tokenbucket.Rate{Limit: limit, Window: s.window}, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply rate limit: %w", err)
+	}
+	return &result, nil
+}
+
+// principalLimit returns the limit of a single API key or driver, which never
+// exceeds the company's limit
+func principalLimit(limit, companyLimit int) int {
+	if limit <= 0 || limit > companyLimit {
+		return companyLimit
+	}
+	return limit
+}
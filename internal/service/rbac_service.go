@@ -0,0 +1,279 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"my-go-driver/internal/domain/company"
+	"my-go-driver/internal/domain/module"
+	"my-go-driver/internal/domain/rbac"
+	"my-go-driver/internal/tenant"
+
+	"gorm.io/gorm"
+)
+
+type rbacService struct {
+	repo        rbac.Repository
+	companyRepo company.Repository
+	modules     module.Service
+}
+
+// NewRBACService creates a new role and permission service
+func NewRBACService(repo rbac.Repository, companyRepo company.Repository, modules module.Service) rbac.Service {
+	return &rbacService{
+		repo:        repo,
+		companyRepo: companyRepo,
+		modules:     modules,
+	}
+}
+
+func (s *rbacService) ListPermissions(ctx context.Context) []rbac.Permission {
+	return rbac.AllPermissions
+}
+
+func (s *rbacService) ListRoles(ctx context.Context, companyID uint64) ([]rbac.RoleResponse, error) {
+	if !tenant.Allows(ctx, companyID) {
+		return nil, company.ErrCompanyNotFound
+	}
+
+	responses := []rbac.RoleResponse{
+		s.toBuiltinRoleResponse(company.AdminRoleOwner),
+		s.toBuiltinRoleResponse(company.AdminRoleManager),
+	}
+
+	roles, err := s.repo.ListByCompany(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range roles {
+		responses = append(responses, s.toRoleResponse(&roles[i]))
+	}
+
+	return responses, nil
+}
+
+func (s *rbacService) CreateRole(ctx context.Context, companyID uint64, req rbac.CreateRoleRequest) (*rbac.RoleResponse, error) {
+	if err := s.requireCustomRoles(ctx, companyID); err != nil {
+		return nil, err
+	}
+	if err := s.validatePermissions(ctx, req.Permissions); err != nil {
+		return nil, err
+	}
+	if err := s.checkRoleName(ctx, companyID, req.Name, 0); err != nil {
+		return nil, err
+	}
+
+	role := &rbac.Role{
+		CompanyID:   companyID,
+		Name:        req.Name,
+		Description: req.Description,
+		Permissions: req.Permissions,
+	}
+
+	if err := s.repo.Create(ctx, role); err != nil {
+		return nil, fmt.Errorf("failed to create role: %w", err)
+	}
+
+	response := s.toRoleResponse(role)
+	return &response, nil
+}
+
+func (s *rbacService) UpdateRole(ctx context.Context, companyID, roleID uint64, req rbac.UpdateRoleRequest) (*rbac.RoleResponse, error) {
+	if err := s.requireCustomRoles(ctx, companyID); err != nil {
+		return nil, err
+	}
+
+	role, err := s.findRole(ctx, companyID, roleID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != "" {
+		if err := s.checkRoleName(ctx, companyID, req.Name, role.ID); err != nil {
+			return nil, err
+		}
+		role.Name = req.Name
+	}
+	if req.Description != nil {
+		role.Description = *req.Description
+	}
+	if req.Permissions != nil {
+		if err := s.validatePermissions(ctx, req.Permissions); err != nil {
+			return nil, err
+		}
+		role.Permissions = req.Permissions
+	}
+
+	if err := s.repo.Update(ctx, role); err != nil {
+		return nil, fmt.Errorf("failed to update role: %w", err)
+	}
+
+	response := s.toRoleResponse(role)
+	return &response, nil
+}
+
+func (s *rbacService) DeleteRole(ctx context.Context, companyID, roleID uint64) error {
+	if _, err := s.findRole(ctx, companyID, roleID); err != nil {
+		return err
+	}
+
+	// Admins holding the role fall back to their built-in role (ON DELETE SET NULL)
+	return s.repo.Delete(ctx, roleID)
+}
+
+func (s *rbacService) AssignAdminRole(ctx context.Context, adminID uint64, req rbac.AssignRoleRequest) error {
+	admin, err := s.companyRepo.GetAdminByID(ctx, adminID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return company.ErrAdminNotFound
+		}
+		return err
+	}
+	if !tenant.Allows(ctx, admin.CompanyID) {
+		return company.ErrAdminNotFound
+	}
+
+	if req.RoleID != nil {
+		if admin.Role == company.AdminRoleOwner {
+			return fmt.Errorf("owners always hold every permission and cannot be given a custom role")
+		}
+		if err := s.requireCustomRoles(ctx, admin.CompanyID); err != nil {
+			return err
+		}
+		role, err := s.findRole(ctx, admin.CompanyID, *req.RoleID)
+		if err != nil {
+			return err
+		}
+		if err := s.validatePermissions(ctx, role.Permissions); err != nil {
+			return err
+		}
+	}
+
+	admin.CustomRoleID = req.RoleID
+	if err := s.companyRepo.UpdateAdmin(ctx, admin); err != nil {
+		return fmt.Errorf("failed to assign role: %w", err)
+	}
+
+	return nil
+}
+
+func (s *rbacService) ResolvePermissions(ctx context.Context, adminID uint64) (rbac.PermissionSet, error) {
+	admin, err := s.companyRepo.GetAdminByID(ctx, adminID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, company.ErrAdminNotFound
+		}
+		return nil, err
+	}
+
+	// Custom roles only apply while the company has roles_permissions enabled;
+	// otherwise the admin falls back to their built-in role
+	if admin.Role != company.AdminRoleOwner && admin.CustomRoleID != nil {
+		enabled, err := s.customRolesEnabled(ctx, admin.CompanyID)
+		if err != nil {
+			return nil, err
+		}
+		if enabled {
+			role, err := s.repo.GetByID(ctx, *admin.CustomRoleID)
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, err
+			}
+			if role != nil && role.CompanyID == admin.CompanyID {
+				return rbac.NewPermissionSet(role.Permissions), nil
+			}
+		}
+	}
+
+	return rbac.NewPermissionSet(rbac.BuiltinPermissions(admin.Role)), nil
+}
+
+// Helper methods
+
+// findRole loads a custom role belonging to the given company
+func (s *rbacService) findRole(ctx context.Context, companyID, roleID uint64) (*rbac.Role, error) {
+	if !tenant.Allows(ctx, companyID) {
+		return nil, rbac.ErrRoleNotFound
+	}
+
+	role, err := s.repo.GetByID(ctx, roleID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, rbac.ErrRoleNotFound
+		}
+		return nil, err
+	}
+	if role.CompanyID != companyID {
+		return nil, rbac.ErrRoleNotFound
+	}
+	return role, nil
+}
+
+func (s *rbacService) customRolesEnabled(ctx context.Context, companyID uint64) (bool, error) {
+	return s.modules.IsEnabled(ctx, companyID, module.KeyRolesPermissions)
+}
+
+func (s *rbacService) requireCustomRoles(ctx context.Context, companyID uint64) error {
+	if !tenant.Allows(ctx, companyID) {
+		return company.ErrCompanyNotFound
+	}
+
+	enabled, err := s.customRolesEnabled(ctx, companyID)
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		return rbac.ErrCustomRolesDisabled
+	}
+	return nil
+}
+
+// validatePermissions checks that perms are known and that the caller holds
+// each of them, so a role can never grant more than the admin who grants it
+func (s *rbacService) validatePermissions(ctx context.Context, perms []rbac.Permission) error {
+	for _, p := range perms {
+		if !rbac.IsKnown(p) {
+			return fmt.Errorf("%w: %s", rbac.ErrUnknownPermission, p)
+		}
+		if err := rbac.Check(ctx, p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *rbacService) checkRoleName(ctx context.Context, companyID uint64, name string, roleID uint64) error {
+	if company.AdminRole(name) == company.AdminRoleOwner || company.AdminRole(name) == company.AdminRoleManager {
+		return fmt.Errorf("role name %q is reserved for a built-in role", name)
+	}
+
+	existing, err := s.repo.GetByName(ctx, companyID, name)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("error checking role name: %w", err)
+	}
+	if existing != nil && existing.ID != roleID {
+		return fmt.Errorf("role with this name already exists")
+	}
+	return nil
+}
+
+func (s *rbacService) toRoleResponse(role *rbac.Role) rbac.RoleResponse {
+	return rbac.RoleResponse{
+		ID:          role.ID,
+		CompanyID:   role.CompanyID,
+		Name:        role.Name,
+		Description: role.Description,
+		Permissions: role.Permissions,
+		IsBuiltin:   false,
+		CreatedAt:   &role.CreatedAt,
+		UpdatedAt:   &role.UpdatedAt,
+	}
+}
+
+func (s *rbacService) toBuiltinRoleResponse(role company.AdminRole) rbac.RoleResponse {
+	return rbac.RoleResponse{
+		Name:        string(role),
+		Permissions: rbac.BuiltinPermissions(role),
+		IsBuiltin:   true,
+	}
+}
@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"my-go-driver/internal/domain/rbac"
+)
+
+func TestRBACServiceValidatePermissions(t *testing.T) {
+	held := []rbac.Permission{rbac.PermRolesManage, rbac.PermCompaniesWrite}
+
+	tests := []struct {
+		name    string
+		perms   []rbac.Permission
+		wantErr error
+	}{
+		{
+			name:  "permissions the caller holds",
+			perms: []rbac.Permission{rbac.PermCompaniesWrite},
+		},
+		{
+			name:    "permission the caller lacks",
+			perms:   []rbac.Permission{rbac.PermCompaniesWrite, rbac.PermCompaniesBilling},
+			wantErr: rbac.ErrPermissionDenied,
+		},
+		{
+			name:    "unknown permission",
+			perms:   []rbac.Permission{"companies.everything"},
+			wantErr: rbac.ErrUnknownPermission,
+		},
+	}
+
+	s := &rbacService{}
+	ctx := rbac.WithPermissions(context.Background(), rbac.NewPermissionSet(held))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := s.validatePermissions(ctx, tt.perms); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("validatePermissions error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"my-go-driver/internal/domain/company"
+	"my-go-driver/internal/domain/session"
+	"my-go-driver/pkg/hash"
+	"my-go-driver/pkg/jwt"
+
+	"gorm.io/gorm"
+)
+
+type sessionService struct {
+	repo       session.Repository
+	companies  company.Repository
+	tokens     *jwt.Service
+	refreshTTL time.Duration
+}
+
+// NewSessionService creates a new session service. The session and its
+// rotating refresh tokens live for refreshTTL. Sessions of a company's
+// admins and drivers cannot be refreshed while the company is suspended.
+func NewSessionService(repo session.Repository, companies company.Repository, tokens *jwt.Service, refreshTTL time.Duration) session.Service {
+	return &sessionService{
+		repo:       repo,
+		companies:  companies,
+		tokens:     tokens,
+		refreshTTL: refreshTTL,
+	}
+}
+
+func (s *sessionService) Issue(ctx context.Context, subject jwt.Subject) (*session.TokenPair, error) {
+	sess := &session.Session{
+		SubjectType: subject.Type,
+		SubjectID:   subject.ID,
+		Role:        subject.Role,
+		ExpiresAt:   time.Now().Add(s.refreshTTL),
+	}
+	if subject.CompanyID != 0 {
+		companyID := subject.CompanyID
+		sess.CompanyID = &companyID
+	}
+
+	if err := s.repo.CreateSession(ctx, sess); err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+
+	return s.issueTokens(ctx, sess)
+}
+
+func (s *sessionService) Refresh(ctx context.Context, subjectType jwt.SubjectType, refreshToken string) (*session.TokenPair, error) {
+	token, err := s.repo.GetRefreshTokenByHash(ctx, hash.HashToken(refreshToken))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, session.ErrInvalidRefreshToken
+		}
+		return nil, err
+	}
+
+	sess, err := s.repo.GetSessionByID(ctx, token.SessionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, session.ErrInvalidRefreshToken
+		}
+		return nil, err
+	}
+	if sess.SubjectType != subjectType {
+		return nil, session.ErrInvalidRefreshToken
+	}
+
+	// A refresh token presented twice means it has leaked; end the session
+	// so neither the attacker nor the legitimate client can keep using it.
+	if token.UsedAt != nil {
+		return nil, s.revokeForReuse(ctx, sess.ID)
+	}
+
+	now := time.Now()
+	if !sess.IsActive(now) || now.After(token.ExpiresAt) {
+		return nil, session.ErrInvalidRefreshToken
+	}
+	if sess.CompanyID != nil {
+		if err := checkCompanyActive(ctx, s.companies, *sess.CompanyID); err != nil {
+			return nil, err
+		}
+	}
+
+	marked, err := s.repo.MarkRefreshTokenUsed(ctx, token.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to rotate refresh token: %w", err)
+	}
+	if !marked {
+		return nil, s.revokeForReuse(ctx, sess.ID)
+	}
+
+	return s.issueTokens(ctx, sess)
+}
+
+func (s *sessionService) ValidateSession(ctx context.Context, sessionID uint64) error {
+	sess, err := s.repo.GetSessionByID(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return session.ErrSessionRevoked
+		}
+		return err
+	}
+
+	if !sess.IsActive(time.Now()) {
+		return session.ErrSessionRevoked
+	}
+	return nil
+}
+
+func (s *sessionService) Revoke(ctx context.Context, sessionID uint64) error {
+	return s.repo.RevokeSession(ctx, sessionID, session.RevokeReasonLogout)
+}
+
+func (s *sessionService) RevokeAll(ctx context.Context, subjectType jwt.SubjectType, subjectID uint64, reason string) error {
+	if err := s.repo.RevokeSubjectSessions(ctx, subjectType, subjectID, reason); err != nil {
+		return fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+	return nil
+}
+
+func (s *sessionService) RevokeCompany(ctx context.Context, companyID uint64, reason string) error {
+	if err := s.repo.RevokeCompanySessions(ctx, companyID, reason); err != nil {
+		return fmt.Errorf("failed to revoke company sessions: %w", err)
+	}
+	return nil
+}
+
+// Helper methods
+
+// issueTokens signs a new access token for the session and stores a fresh refresh token
+func (s *sessionService) issueTokens(ctx context.Context, sess *session.Session) (*session.TokenPair, error) {
+	refreshToken, err := hash.RandomToken(32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+
+	if err := s.repo.CreateRefreshToken(ctx, &session.RefreshToken{
+		SessionID: sess.ID,
+		TokenHash: hash.HashToken(refreshToken),
+		ExpiresAt: sess.ExpiresAt,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to store refresh token: %w", err)
+	}
+
+	accessToken, claims, err := s.tokens.Issue(sess.Subject())
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate token: %w", err)
+	}
+
+	return &session.TokenPair{
+		Token:        accessToken,
+		RefreshToken: refreshToken,
+		ExpiresAt:    claims.ExpiresAt.Time,
+	}, nil
+}
+
+func (s *sessionService) revokeForReuse(ctx context.Context, sessionID uint64) error {
+	if err := s.repo.RevokeSession(ctx, sessionID, session.RevokeReasonTokenReuse); err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+	return session.ErrRefreshTokenReused
+}
@@ -0,0 +1,271 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"my-go-driver/internal/domain/company"
+	"my-go-driver/internal/domain/session"
+	"my-go-driver/pkg/jwt"
+
+	"gorm.io/gorm"
+)
+
+// memorySessionRepository keeps sessions and refresh tokens in memory.
+// When loseMarkRace is set, MarkRefreshTokenUsed behaves as if a concurrent
+// request had used the token first.
+type memorySessionRepository struct {
+	sessions     map[uint64]session.Session
+	tokens       map[uint64]session.RefreshToken
+	loseMarkRace bool
+}
+
+func newMemorySessionRepository() *memorySessionRepository {
+	return &memorySessionRepository{
+		sessions: make(map[uint64]session.Session),
+		tokens:   make(map[uint64]session.RefreshToken),
+	}
+}
+
+func (r *memorySessionRepository) CreateSession(ctx context.Context, s *session.Session) error {
+	s.ID = uint64(len(r.sessions) + 1)
+	r.sessions[s.ID] = *s
+	return nil
+}
+
+func (r *memorySessionRepository) GetSessionByID(ctx context.Context, id uint64) (*session.Session, error) {
+	s, ok := r.sessions[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &s, nil
+}
+
+func (r *memorySessionRepository) RevokeSession(ctx context.Context, id uint64, reason string) error {
+	s, ok := r.sessions[id]
+	if !ok || s.RevokedAt != nil {
+		return nil
+	}
+	now := time.Now()
+	s.RevokedAt = &now
+	s.RevokedReason = reason
+	r.sessions[id] = s
+	return nil
+}
+
+func (r *memorySessionRepository) RevokeSubjectSessions(ctx context.Context, subjectType jwt.SubjectType, subjectID uint64, reason string) error {
+	for id, s := range r.sessions {
+		if s.SubjectType == subjectType && s.SubjectID == subjectID {
+			if err := r.RevokeSession(ctx, id, reason); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (r *memorySessionRepository) RevokeCompanySessions(ctx context.Context, companyID uint64, reason string) error {
+	for id, s := range r.sessions {
+		if s.CompanyID != nil && *s.CompanyID == companyID {
+			if err := r.RevokeSession(ctx, id, reason); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (r *memorySessionRepository) CreateRefreshToken(ctx context.Context, t *session.RefreshToken) error {
+	t.ID = uint64(len(r.tokens) + 1)
+	r.tokens[t.ID] = *t
+	return nil
+}
+
+func (r *memorySessionRepository) GetRefreshTokenByHash(ctx context.Context, tokenHash string) (*session.RefreshToken, error) {
+	for _, t := range r.tokens {
+		if t.TokenHash == tokenHash {
+			return &t, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *memorySessionRepository) MarkRefreshTokenUsed(ctx context.Context, id uint64) (bool, error) {
+	t, ok := r.tokens[id]
+	if !ok || t.UsedAt != nil || r.loseMarkRace {
+		return false, nil
+	}
+	now := time.Now()
+	t.UsedAt = &now
+	r.tokens[id] = t
+	return true, nil
+}
+
+func newTestSessionService(t *testing.T, companies ...company.Company) (session.Service, *memorySessionRepository, *memoryCompanyRepository) {
+	tokens, err := jwt.NewService(jwt.Config{Secret: "test-secret", Issuer: "test", Expiration: time.Minute})
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	repo := newMemorySessionRepository()
+	companyRepo := newMemoryCompanyRepository(companies...)
+	return NewSessionService(repo, companyRepo, tokens, time.Hour), repo, companyRepo
+}
+
+var testAdminSubject = jwt.Subject{Type: jwt.SubjectAdmin, ID: 7, CompanyID: 1, Role: "manager"}
+
+func TestSessionServiceRefreshRotates(t *testing.T) {
+	s, repo, _ := newTestSessionService(t, company.Company{ID: 1, Status: company.CompanyStatusActive})
+	ctx := context.Background()
+
+	issued, err := s.Issue(ctx, testAdminSubject)
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+
+	refreshed, err := s.Refresh(ctx, jwt.SubjectAdmin, issued.RefreshToken)
+	if err != nil {
+		t.Fatalf("Refresh: %v", err)
+	}
+	if refreshed.RefreshToken == "" || refreshed.RefreshToken == issued.RefreshToken {
+		t.Fatalf("Refresh returned refresh token %q, want a new one", refreshed.RefreshToken)
+	}
+	if refreshed.Token == "" {
+		t.Fatal("Refresh returned no access token")
+	}
+
+	// The new token rotates in turn
+	if _, err := s.Refresh(ctx, jwt.SubjectAdmin, refreshed.RefreshToken); err != nil {
+		t.Fatalf("Refresh with the rotated token: %v", err)
+	}
+	if sess := repo.sessions[1]; sess.RevokedAt != nil {
+		t.Fatalf("session revoked with reason %q, want active", sess.RevokedReason)
+	}
+}
+
+func TestSessionServiceRefreshReuse(t *testing.T) {
+	tests := []struct {
+		name string
+		// reuse refreshes twice with the same token; otherwise the first
+		// refresh loses the race to mark the token used
+		reuse bool
+	}{
+		{name: "token presented twice", reuse: true},
+		{name: "concurrent refresh marked the token first"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, repo, _ := newTestSessionService(t, company.Company{ID: 1, Status: company.CompanyStatusActive})
+			ctx := context.Background()
+
+			issued, err := s.Issue(ctx, testAdminSubject)
+			if err != nil {
+				t.Fatalf("Issue: %v", err)
+			}
+
+			var rotated *session.TokenPair
+			if tt.reuse {
+				if rotated, err = s.Refresh(ctx, jwt.SubjectAdmin, issued.RefreshToken); err != nil {
+					t.Fatalf("first Refresh: %v", err)
+				}
+			} else {
+				repo.loseMarkRace = true
+			}
+
+			if _, err := s.Refresh(ctx, jwt.SubjectAdmin, issued.RefreshToken); !errors.Is(err, session.ErrRefreshTokenReused) {
+				t.Fatalf("Refresh error = %v, want %v", err, session.ErrRefreshTokenReused)
+			}
+			sess := repo.sessions[1]
+			if sess.RevokedAt == nil || sess.RevokedReason != session.RevokeReasonTokenReuse {
+				t.Fatalf("session revoked at %v with reason %q, want revoked for reuse", sess.RevokedAt, sess.RevokedReason)
+			}
+
+			// Tokens rotated before the reuse die with the session
+			if rotated != nil {
+				if _, err := s.Refresh(ctx, jwt.SubjectAdmin, rotated.RefreshToken); !errors.Is(err, session.ErrInvalidRefreshToken) {
+					t.Fatalf("Refresh with the rotated token error = %v, want %v", err, session.ErrInvalidRefreshToken)
+				}
+			}
+		})
+	}
+}
+
+func TestSessionServiceRefreshRejected(t *testing.T) {
+	past := time.Now().Add(-time.Minute)
+
+	tests := []struct {
+		name        string
+		status      company.CompanyStatus
+		subjectType jwt.SubjectType
+		prepare     func(repo *memorySessionRepository)
+		wantErr     error
+	}{
+		{
+			name: "expired session",
+			prepare: func(repo *memorySessionRepository) {
+				sess := repo.sessions[1]
+				sess.ExpiresAt = past
+				repo.sessions[1] = sess
+			},
+			wantErr: session.ErrInvalidRefreshToken,
+		},
+		{
+			name: "revoked session",
+			prepare: func(repo *memorySessionRepository) {
+				_ = repo.RevokeSession(context.Background(), 1, session.RevokeReasonLogout)
+			},
+			wantErr: session.ErrInvalidRefreshToken,
+		},
+		{
+			name: "expired refresh token",
+			prepare: func(repo *memorySessionRepository) {
+				token := repo.tokens[1]
+				token.ExpiresAt = past
+				repo.tokens[1] = token
+			},
+			wantErr: session.ErrInvalidRefreshToken,
+		},
+		{
+			name:        "token of another subject type",
+			subjectType: jwt.SubjectDriver,
+			wantErr:     session.ErrInvalidRefreshToken,
+		},
+		{
+			name:    "suspended company",
+			status:  company.CompanyStatusSuspended,
+			wantErr: company.ErrCompanySuspended,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status := tt.status
+			if status == "" {
+				status = company.CompanyStatusActive
+			}
+			s, repo, _ := newTestSessionService(t, company.Company{ID: 1, Status: status})
+			ctx := context.Background()
+
+			issued, err := s.Issue(ctx, testAdminSubject)
+			if err != nil {
+				t.Fatalf("Issue: %v", err)
+			}
+			if tt.prepare != nil {
+				tt.prepare(repo)
+			}
+			subjectType := tt.subjectType
+			if subjectType == "" {
+				subjectType = jwt.SubjectAdmin
+			}
+
+			if _, err := s.Refresh(ctx, subjectType, issued.RefreshToken); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Refresh error = %v, want %v", err, tt.wantErr)
+			}
+			if token := repo.tokens[1]; token.UsedAt != nil {
+				t.Fatal("rejected refresh token was marked used")
+			}
+		})
+	}
+}
@@ -2,22 +2,53 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
+	"time"
 
+	"my-go-driver/internal/domain/company"
+	"my-go-driver/internal/domain/driver"
+	"my-go-driver/internal/domain/module"
 	"my-go-driver/internal/domain/shift"
+	"my-go-driver/internal/domain/vehicle"
+	"my-go-driver/internal/tenant"
+	"my-go-driver/internal/transaction"
+
+	"gorm.io/gorm"
 )
 
 type shiftService struct {
-	repo shift.Repository
+	repo        shift.Repository
+	driverRepo  driver.Repository
+	companyRepo company.Repository
+	modules     module.Service
+	vehicles    vehicle.Service
+	tx          transaction.Transactor
 }
 
 // NewShiftService creates a new shift service
-func NewShiftService(repo shift.Repository) shift.Service {
-	return &shiftService{repo: repo}
+func NewShiftService(repo shift.Repository, driverRepo driver.Repository, companyRepo company.Repository, modules module.Service, vehicles vehicle.Service, tx transaction.Transactor) shift.Service {
+	return &shiftService{
+		repo:        repo,
+		driverRepo:  driverRepo,
+		companyRepo: companyRepo,
+		modules:     modules,
+		vehicles:    vehicles,
+		tx:          tx,
+	}
 }
 
 func (s *shiftService) GetDriverShifts(ctx context.Context, driverID uint64, query shift.ListShiftsQuery) (*shift.PaginatedShiftsResponse, error) {
+	// Check if driver exists within the caller's tenant
+	if _, err := s.findDriver(ctx, driverID); err != nil {
+		return nil, err
+	}
+
+	if companyID, ok := tenant.CompanyID(ctx); ok {
+		query.CompanyID = companyID
+	}
+
 	shifts, total, err := s.repo.GetByDriverID(ctx, driverID, query)
 	if err != nil {
 		return nil, err
@@ -47,12 +78,152 @@ func (s *shiftService) GetDriverShifts(ctx context.Context, driverID uint64, que
 	}, nil
 }
 
+func (s *shiftService) StartShift(ctx context.Context, driverID uint64) (*shift.ShiftResponse, error) {
+	d, err := s.findDriver(ctx, driverID)
+	if err != nil {
+		return nil, err
+	}
+	if d.Status == driver.DriverStatusSuspended {
+		return nil, driver.ErrDriverSuspended
+	}
+
+	autoAssign, err := s.autoAssignsVehicles(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	var response shift.ShiftResponse
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		// Lock the driver so concurrent starts cannot both find no shift
+		if _, err := s.driverRepo.GetByIDForUpdate(ctx, d.ID); err != nil {
+			return err
+		}
+		if _, err := s.repo.GetOngoing(ctx, d.ID); err == nil {
+			return shift.ErrShiftInProgress
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		// The shift only starts if the driver gets a vehicle
+		var vehicleID *uint64
+		if autoAssign {
+			assignment, err := s.vehicles.AutoAssign(ctx, d.ID)
+			if err != nil {
+				return err
+			}
+			vehicleID = &assignment.VehicleID
+		} else {
+			assignment, err := s.vehicles.GetDriverAssignment(ctx, d.ID)
+			if err != nil && !errors.Is(err, vehicle.ErrNotAssigned) {
+				return err
+			}
+			if assignment != nil {
+				vehicleID = &assignment.VehicleID
+			}
+		}
+
+		now := time.Now()
+		sh := &shift.DriverShift{
+			DriverID:  d.ID,
+			CompanyID: d.CompanyID,
+			VehicleID: vehicleID,
+			ShiftDate: time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()),
+			StartTime: &now,
+			Status:    shift.ShiftStatusOngoing,
+		}
+		if err := s.repo.Create(ctx, sh); err != nil {
+			return fmt.Errorf("failed to start shift: %w", err)
+		}
+		response = s.toShiftResponse(sh)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+func (s *shiftService) EndShift(ctx context.Context, driverID uint64, req shift.EndShiftRequest) (*shift.ShiftResponse, error) {
+	d, err := s.findDriver(ctx, driverID)
+	if err != nil {
+		return nil, err
+	}
+
+	var response shift.ShiftResponse
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		sh, err := s.repo.GetOngoing(ctx, d.ID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return shift.ErrNoShiftStarted
+			}
+			return err
+		}
+
+		now := time.Now()
+		sh.EndTime = &now
+		sh.Status = shift.ShiftStatusCompleted
+		sh.TotalDistance = *req.TotalDistance
+		if err := s.repo.Update(ctx, sh); err != nil {
+			return fmt.Errorf("failed to end shift: %w", err)
+		}
+		response = s.toShiftResponse(sh)
+
+		// Vehicles handed out at shift start are only kept for the shift;
+		// vehicles assigned by an admin stay with the driver
+		return s.vehicles.ReleaseAutoAssignment(ctx, d.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
 // Helper methods
+
+// findDriver loads a driver, hiding drivers outside the caller's tenant
+func (s *shiftService) findDriver(ctx context.Context, id uint64) (*driver.Driver, error) {
+	d, err := s.driverRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, driver.ErrDriverNotFound
+		}
+		return nil, err
+	}
+	if !tenant.Allows(ctx, d.CompanyID) {
+		return nil, driver.ErrDriverNotFound
+	}
+	return d, nil
+}
+
+// autoAssignsVehicles reports whether the driver's shifts come with a vehicle:
+// the company assigns vehicles automatically and vehicle management is
+// enabled for the driver's store
+func (s *shiftService) autoAssignsVehicles(ctx context.Context, d *driver.Driver) (bool, error) {
+	c, err := s.companyRepo.GetByID(ctx, d.CompanyID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, company.ErrCompanyNotFound
+		}
+		return false, err
+	}
+	if c.VehicleAssignmentMode != company.VehicleAssignmentAuto {
+		return false, nil
+	}
+
+	if d.StoreID != nil {
+		return s.modules.IsEnabledForStore(ctx, d.CompanyID, *d.StoreID, module.KeyVehicleManagement)
+	}
+	return s.modules.IsEnabled(ctx, d.CompanyID, module.KeyVehicleManagement)
+}
+
 func (s *shiftService) toShiftResponse(sh *shift.DriverShift) shift.ShiftResponse {
 	response := shift.ShiftResponse{
 		ID:              sh.ID,
 		DriverID:        sh.DriverID,
 		CompanyID:       sh.CompanyID,
+		VehicleID:       sh.VehicleID,
 		ShiftDate:       sh.ShiftDate,
 		StartTime:       sh.StartTime,
 		EndTime:         sh.EndTime,
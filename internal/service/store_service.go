@@ -0,0 +1,225 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	"my-go-driver/internal/domain/company"
+	"my-go-driver/internal/domain/store"
+	"my-go-driver/internal/tenant"
+
+	"gorm.io/gorm"
+)
+
+type storeService struct {
+	repo store.Repository
+}
+
+// NewStoreService creates a new store service
+func NewStoreService(repo store.Repository) store.Service {
+	return &storeService{repo: repo}
+}
+
+func (s *storeService) CreateStore(ctx context.Context, req store.CreateStoreRequest) (*store.StoreResponse, error) {
+	if !tenant.Allows(ctx, req.CompanyID) {
+		return nil, company.ErrCompanyNotFound
+	}
+
+	if err := validateStoreDetails(req.Latitude, req.Longitude, req.OpeningHours); err != nil {
+		return nil, err
+	}
+
+	newStore := &store.Store{
+		CompanyID:    req.CompanyID,
+		Name:         req.Name,
+		Phone:        req.Phone,
+		Address:      req.Address,
+		Latitude:     req.Latitude,
+		Longitude:    req.Longitude,
+		OpeningHours: req.OpeningHours,
+		Status:       store.StoreStatusActive,
+	}
+
+	if err := s.repo.Create(ctx, newStore); err != nil {
+		return nil, fmt.Errorf("failed to create store: %w", err)
+	}
+
+	response := s.toStoreResponse(newStore)
+	return &response, nil
+}
+
+func (s *storeService) GetStore(ctx context.Context, id uint64) (*store.StoreResponse, error) {
+	st, err := s.findStore(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	response := s.toStoreResponse(st)
+	return &response, nil
+}
+
+func (s *storeService) UpdateStore(ctx context.Context, id uint64, req store.UpdateStoreRequest) (*store.StoreResponse, error) {
+	st, err := s.findStore(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Update fields
+	if req.Name != "" {
+		st.Name = req.Name
+	}
+	if req.Phone != "" {
+		st.Phone = req.Phone
+	}
+	if req.Address != "" {
+		st.Address = req.Address
+	}
+	if req.Latitude != nil {
+		st.Latitude = req.Latitude
+	}
+	if req.Longitude != nil {
+		st.Longitude = req.Longitude
+	}
+	if req.OpeningHours != nil {
+		st.OpeningHours = req.OpeningHours
+	}
+
+	if err := validateStoreDetails(st.Latitude, st.Longitude, st.OpeningHours); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Update(ctx, st); err != nil {
+		return nil, fmt.Errorf("failed to update store: %w", err)
+	}
+
+	response := s.toStoreResponse(st)
+	return &response, nil
+}
+
+func (s *storeService) DeleteStore(ctx context.Context, id uint64) error {
+	if _, err := s.findStore(ctx, id); err != nil {
+		return err
+	}
+
+	// Orders would be deleted with the store by the foreign key
+	hasOrders, err := s.repo.HasOrders(ctx, id)
+	if err != nil {
+		return fmt.Errorf("error checking store orders: %w", err)
+	}
+	if hasOrders {
+		return store.ErrStoreHasOrders
+	}
+
+	// Drivers of the store are left without a store by the foreign key
+	return s.repo.Delete(ctx, id)
+}
+
+func (s *storeService) ListStores(ctx context.Context, query store.ListStoresQuery) (*store.PaginatedStoresResponse, error) {
+	// Company admins only ever see their own stores
+	if companyID, ok := tenant.CompanyID(ctx); ok {
+		query.CompanyID = companyID
+	}
+
+	stores, total, err := s.repo.List(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set defaults
+	if query.Page <= 0 {
+		query.Page = 1
+	}
+	if query.Limit <= 0 {
+		query.Limit = 10
+	}
+
+	responses := make([]store.StoreResponse, len(stores))
+	for i, st := range stores {
+		responses[i] = s.toStoreResponse(&st)
+	}
+
+	totalPages := int(math.Ceil(float64(total) / float64(query.Limit)))
+
+	return &store.PaginatedStoresResponse{
+		Stores:     responses,
+		TotalCount: total,
+		Page:       query.Page,
+		Limit:      query.Limit,
+		TotalPages: totalPages,
+	}, nil
+}
+
+func (s *storeService) ActivateStore(ctx context.Context, id uint64) error {
+	if _, err := s.findStore(ctx, id); err != nil {
+		return err
+	}
+
+	return s.repo.UpdateStatus(ctx, id, store.StoreStatusActive)
+}
+
+func (s *storeService) DeactivateStore(ctx context.Context, id uint64) error {
+	if _, err := s.findStore(ctx, id); err != nil {
+		return err
+	}
+
+	// Drivers already in the store keep their assignment
+	return s.repo.UpdateStatus(ctx, id, store.StoreStatusInactive)
+}
+
+func (s *storeService) CheckAssignable(ctx context.Context, companyID, storeID uint64) error {
+	st, err := s.findStore(ctx, storeID)
+	if err != nil {
+		return err
+	}
+	if st.CompanyID != companyID {
+		return store.ErrStoreNotFound
+	}
+	if st.Status != store.StoreStatusActive {
+		return store.ErrStoreInactive
+	}
+	return nil
+}
+
+// Helper methods
+
+// findStore loads a store, hiding stores outside the caller's tenant
+func (s *storeService) findStore(ctx context.Context, id uint64) (*store.Store, error) {
+	st, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, store.ErrStoreNotFound
+		}
+		return nil, err
+	}
+	if !tenant.Allows(ctx, st.CompanyID) {
+		return nil, store.ErrStoreNotFound
+	}
+	return st, nil
+}
+
+// validateStoreDetails checks that coordinates come in pairs and that the
+// opening hours are valid
+func validateStoreDetails(latitude, longitude *float64, hours store.OpeningHours) error {
+	if (latitude == nil) != (longitude == nil) {
+		return store.ErrIncompleteCoordinates
+	}
+	return hours.Validate()
+}
+
+func (s *storeService) toStoreResponse(st *store.Store) store.StoreResponse {
+	return store.StoreResponse{
+		ID:           st.ID,
+		CompanyID:    st.CompanyID,
+		Name:         st.Name,
+		Phone:        st.Phone,
+		Address:      st.Address,
+		Latitude:     st.Latitude,
+		Longitude:    st.Longitude,
+		OpeningHours: st.OpeningHours,
+		Status:       st.Status,
+		CreatedAt:    st.CreatedAt,
+		UpdatedAt:    st.UpdatedAt,
+	}
+}
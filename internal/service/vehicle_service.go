@@ -0,0 +1,571 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"my-go-driver/internal/domain/company"
+	"my-go-driver/internal/domain/driver"
+	"my-go-driver/internal/domain/store"
+	"my-go-driver/internal/domain/vehicle"
+	"my-go-driver/internal/tenant"
+	"my-go-driver/internal/transaction"
+
+	"gorm.io/gorm"
+)
+
+type vehicleService struct {
+	repo       vehicle.Repository
+	driverRepo driver.Repository
+	stores     store.Service
+	tx         transaction.Transactor
+}
+
+// NewVehicleService creates a new vehicle service
+func NewVehicleService(repo vehicle.Repository, driverRepo driver.Repository, stores store.Service, tx transaction.Transactor) vehicle.Service {
+	return &vehicleService{
+		repo:       repo,
+		driverRepo: driverRepo,
+		stores:     stores,
+		tx:         tx,
+	}
+}
+
+func (s *vehicleService) CreateVehicle(ctx context.Context, req vehicle.CreateVehicleRequest) (*vehicle.VehicleResponse, error) {
+	if !tenant.Allows(ctx, req.CompanyID) {
+		return nil, company.ErrCompanyNotFound
+	}
+
+	plate := vehicle.NormalizePlate(req.PlateNumber)
+	if err := s.checkPlate(ctx, plate, req.CompanyID, 0); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkStore(ctx, req.CompanyID, req.StoreID); err != nil {
+		return nil, err
+	}
+
+	lastOilChange, err := parseVehicleDate(req.LastOilChange)
+	if err != nil {
+		return nil, err
+	}
+
+	newVehicle := &vehicle.Vehicle{
+		CompanyID:     req.CompanyID,
+		StoreID:       req.StoreID,
+		PlateNumber:   plate,
+		Type:          req.Type,
+		Capacity:      req.Capacity,
+		Status:        vehicle.VehicleStatusActive,
+		FuelType:      req.FuelType,
+		LastOilChange: lastOilChange,
+	}
+
+	if err := s.repo.Create(ctx, newVehicle); err != nil {
+		return nil, fmt.Errorf("failed to create vehicle: %w", err)
+	}
+
+	response := s.toVehicleResponse(newVehicle)
+	return &response, nil
+}
+
+func (s *vehicleService) GetVehicle(ctx context.Context, id uint64) (*vehicle.VehicleResponse, error) {
+	v, err := s.findVehicle(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	response := s.toVehicleResponse(v)
+	return &response, nil
+}
+
+func (s *vehicleService) UpdateVehicle(ctx context.Context, id uint64, req vehicle.UpdateVehicleRequest) (*vehicle.VehicleResponse, error) {
+	v, err := s.findVehicle(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Update fields
+	if req.PlateNumber != "" {
+		plate := vehicle.NormalizePlate(req.PlateNumber)
+		if err := s.checkPlate(ctx, plate, v.CompanyID, v.ID); err != nil {
+			return nil, err
+		}
+		v.PlateNumber = plate
+	}
+	if req.StoreID != nil && (v.StoreID == nil || *v.StoreID != *req.StoreID) {
+		if err := s.checkStore(ctx, v.CompanyID, req.StoreID); err != nil {
+			return nil, err
+		}
+		v.StoreID = req.StoreID
+	}
+	if req.Type != "" {
+		v.Type = req.Type
+	}
+	if req.Capacity != nil {
+		v.Capacity = req.Capacity
+	}
+	if req.FuelType != "" {
+		v.FuelType = req.FuelType
+	}
+	if req.LastOilChange != "" {
+		lastOilChange, err := parseVehicleDate(req.LastOilChange)
+		if err != nil {
+			return nil, err
+		}
+		v.LastOilChange = lastOilChange
+	}
+
+	if err := s.repo.Update(ctx, v); err != nil {
+		return nil, fmt.Errorf("failed to update vehicle: %w", err)
+	}
+
+	response := s.toVehicleResponse(v)
+	return &response, nil
+}
+
+func (s *vehicleService) DeleteVehicle(ctx context.Context, id uint64) error {
+	return s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		// Lock the vehicle so it cannot be assigned while being deleted
+		v, err := s.lockVehicle(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		// Assignments and maintenance records would be deleted with the
+		// vehicle by the foreign keys
+		hasHistory, err := s.repo.HasHistory(ctx, v.ID)
+		if err != nil {
+			return fmt.Errorf("error checking vehicle history: %w", err)
+		}
+		if hasHistory {
+			return vehicle.ErrVehicleHasHistory
+		}
+
+		return s.repo.Delete(ctx, v.ID)
+	})
+}
+
+func (s *vehicleService) ListVehicles(ctx context.Context, query vehicle.ListVehiclesQuery) (*vehicle.PaginatedVehiclesResponse, error) {
+	// Company admins only ever see their own fleet
+	if companyID, ok := tenant.CompanyID(ctx); ok {
+		query.CompanyID = companyID
+	}
+
+	vehicles, total, err := s.repo.List(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set defaults
+	if query.Page <= 0 {
+		query.Page = 1
+	}
+	if query.Limit <= 0 {
+		query.Limit = 10
+	}
+
+	responses := make([]vehicle.VehicleResponse, len(vehicles))
+	for i, v := range vehicles {
+		responses[i] = s.toVehicleResponse(&v)
+	}
+
+	totalPages := int(math.Ceil(float64(total) / float64(query.Limit)))
+
+	return &vehicle.PaginatedVehiclesResponse{
+		Vehicles:   responses,
+		TotalCount: total,
+		Page:       query.Page,
+		Limit:      query.Limit,
+		TotalPages: totalPages,
+	}, nil
+}
+
+func (s *vehicleService) ChangeStatus(ctx context.Context, id uint64, req vehicle.ChangeStatusRequest) (*vehicle.VehicleResponse, error) {
+	var v *vehicle.Vehicle
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		v, err = s.lockVehicle(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if !vehicle.CanTransition(v.Status, req.Status) {
+			return fmt.Errorf("%w: %s to %s", vehicle.ErrInvalidStatusTransition, v.Status, req.Status)
+		}
+
+		if err := s.repo.UpdateStatus(ctx, id, req.Status); err != nil {
+			return fmt.Errorf("failed to update vehicle status: %w", err)
+		}
+		v.Status = req.Status
+
+		// A vehicle out of active service cannot stay with its driver
+		if req.Status != vehicle.VehicleStatusActive {
+			return s.endActiveAssignment(ctx, s.repo.GetActiveAssignmentByVehicle, id)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := s.toVehicleResponse(v)
+	return &response, nil
+}
+
+func (s *vehicleService) AssignDriver(ctx context.Context, vehicleID uint64, req vehicle.AssignDriverRequest) (*vehicle.AssignmentResponse, error) {
+	var assignment *vehicle.Assignment
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		v, err := s.lockVehicle(ctx, vehicleID)
+		if err != nil {
+			return err
+		}
+
+		d, err := s.findDriver(ctx, req.DriverID)
+		if err != nil {
+			return err
+		}
+		if d.CompanyID != v.CompanyID {
+			return driver.ErrDriverNotFound
+		}
+
+		assignment, err = s.assign(ctx, v, d, false)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := s.toAssignmentResponse(assignment)
+	return &response, nil
+}
+
+func (s *vehicleService) UnassignDriver(ctx context.Context, vehicleID uint64) (*vehicle.AssignmentResponse, error) {
+	var assignment *vehicle.Assignment
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if _, err := s.lockVehicle(ctx, vehicleID); err != nil {
+			return err
+		}
+
+		var err error
+		assignment, err = s.repo.GetActiveAssignmentByVehicle(ctx, vehicleID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return vehicle.ErrNotAssigned
+			}
+			return err
+		}
+		return s.endAssignment(ctx, assignment)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := s.toAssignmentResponse(assignment)
+	return &response, nil
+}
+
+func (s *vehicleService) GetDriverAssignment(ctx context.Context, driverID uint64) (*vehicle.AssignmentResponse, error) {
+	d, err := s.findDriver(ctx, driverID)
+	if err != nil {
+		return nil, err
+	}
+
+	assignment, err := s.repo.GetActiveAssignmentByDriver(ctx, d.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, vehicle.ErrNotAssigned
+		}
+		return nil, err
+	}
+
+	response := s.toAssignmentResponse(assignment)
+	return &response, nil
+}
+
+func (s *vehicleService) ListAssignments(ctx context.Context, query vehicle.ListAssignmentsQuery) (*vehicle.PaginatedAssignmentsResponse, error) {
+	// The history is only listed for a vehicle or driver the caller can see
+	if query.VehicleID > 0 {
+		if _, err := s.findVehicle(ctx, query.VehicleID); err != nil {
+			return nil, err
+		}
+	}
+	if query.DriverID > 0 {
+		if _, err := s.findDriver(ctx, query.DriverID); err != nil {
+			return nil, err
+		}
+	}
+	if query.VehicleID == 0 && query.DriverID == 0 {
+		return nil, vehicle.ErrVehicleNotFound
+	}
+
+	assignments, total, err := s.repo.ListAssignments(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set defaults
+	if query.Page <= 0 {
+		query.Page = 1
+	}
+	if query.Limit <= 0 {
+		query.Limit = 10
+	}
+
+	responses := make([]vehicle.AssignmentResponse, len(assignments))
+	for i, a := range assignments {
+		responses[i] = s.toAssignmentResponse(&a)
+	}
+
+	totalPages := int(math.Ceil(float64(total) / float64(query.Limit)))
+
+	return &vehicle.PaginatedAssignmentsResponse{
+		Assignments: responses,
+		TotalCount:  total,
+		Page:        query.Page,
+		Limit:       query.Limit,
+		TotalPages:  totalPages,
+	}, nil
+}
+
+func (s *vehicleService) AutoAssign(ctx context.Context, driverID uint64) (*vehicle.AssignmentResponse, error) {
+	var assignment *vehicle.Assignment
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		d, err := s.findDriver(ctx, driverID)
+		if err != nil {
+			return err
+		}
+
+		assignment, err = s.repo.GetActiveAssignmentByDriver(ctx, d.ID)
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		v, err := s.repo.FindAvailable(ctx, d.CompanyID, d.StoreID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return vehicle.ErrNoVehicleAvailable
+			}
+			return err
+		}
+
+		assignment, err = s.assign(ctx, v, d, true)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := s.toAssignmentResponse(assignment)
+	return &response, nil
+}
+
+func (s *vehicleService) ReleaseDriver(ctx context.Context, driverID uint64) error {
+	return s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		d, err := s.findDriver(ctx, driverID)
+		if err != nil {
+			return err
+		}
+		return s.endActiveAssignment(ctx, s.repo.GetActiveAssignmentByDriver, d.ID)
+	})
+}
+
+func (s *vehicleService) ReleaseAutoAssignment(ctx context.Context, driverID uint64) error {
+	return s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		d, err := s.findDriver(ctx, driverID)
+		if err != nil {
+			return err
+		}
+
+		assignment, err := s.repo.GetActiveAssignmentByDriver(ctx, d.ID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+			return err
+		}
+		if !assignment.AutoAssigned {
+			return nil
+		}
+		return s.endAssignment(ctx, assignment)
+	})
+}
+
+// Helper methods
+
+// findVehicle loads a vehicle, hiding vehicles outside the caller's tenant
+func (s *vehicleService) findVehicle(ctx context.Context, id uint64) (*vehicle.Vehicle, error) {
+	return s.loadVehicle(ctx, s.repo.GetByID, id)
+}
+
+// lockVehicle is findVehicle for a transaction that changes the vehicle's
+// status or assignment
+func (s *vehicleService) lockVehicle(ctx context.Context, id uint64) (*vehicle.Vehicle, error) {
+	return s.loadVehicle(ctx, s.repo.GetByIDForUpdate, id)
+}
+
+func (s *vehicleService) loadVehicle(ctx context.Context, get func(context.Context, uint64) (*vehicle.Vehicle, error), id uint64) (*vehicle.Vehicle, error) {
+	v, err := get(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, vehicle.ErrVehicleNotFound
+		}
+		return nil, err
+	}
+	if !tenant.Allows(ctx, v.CompanyID) {
+		return nil, vehicle.ErrVehicleNotFound
+	}
+	return v, nil
+}
+
+// findDriver loads a driver, hiding drivers outside the caller's tenant
+func (s *vehicleService) findDriver(ctx context.Context, id uint64) (*driver.Driver, error) {
+	d, err := s.driverRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, driver.ErrDriverNotFound
+		}
+		return nil, err
+	}
+	if !tenant.Allows(ctx, d.CompanyID) {
+		return nil, driver.ErrDriverNotFound
+	}
+	return d, nil
+}
+
+// assign starts an assignment of a locked vehicle to a driver of its company,
+// keeping to one active assignment per vehicle and per driver. auto marks
+// assignments made at shift start, which end with the shift.
+func (s *vehicleService) assign(ctx context.Context, v *vehicle.Vehicle, d *driver.Driver, auto bool) (*vehicle.Assignment, error) {
+	if v.Status != vehicle.VehicleStatusActive {
+		return nil, vehicle.ErrVehicleUnavailable
+	}
+	if d.Status == driver.DriverStatusSuspended {
+		return nil, driver.ErrDriverSuspended
+	}
+
+	if _, err := s.repo.GetActiveAssignmentByVehicle(ctx, v.ID); err == nil {
+		return nil, vehicle.ErrVehicleAssigned
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	if _, err := s.repo.GetActiveAssignmentByDriver(ctx, d.ID); err == nil {
+		return nil, vehicle.ErrDriverHasVehicle
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	assignment := &vehicle.Assignment{
+		DriverID:     d.ID,
+		VehicleID:    v.ID,
+		IsActive:     true,
+		AutoAssigned: auto,
+		AssignedAt:   time.Now(),
+	}
+	if err := s.repo.CreateAssignment(ctx, assignment); err != nil {
+		return nil, fmt.Errorf("failed to assign vehicle: %w", err)
+	}
+	return assignment, nil
+}
+
+// endActiveAssignment ends the active assignment of a vehicle or driver,
+// whichever lookup is given, if there is one
+func (s *vehicleService) endActiveAssignment(ctx context.Context, lookup func(context.Context, uint64) (*vehicle.Assignment, error), id uint64) error {
+	assignment, err := lookup(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	return s.endAssignment(ctx, assignment)
+}
+
+func (s *vehicleService) endAssignment(ctx context.Context, assignment *vehicle.Assignment) error {
+	now := time.Now()
+	if err := s.repo.EndAssignment(ctx, assignment.ID, now); err != nil {
+		return fmt.Errorf("failed to unassign vehicle: %w", err)
+	}
+	assignment.IsActive = false
+	assignment.UnassignedAt = &now
+	return nil
+}
+
+// checkPlate verifies that no other vehicle of the company uses the plate
+func (s *vehicleService) checkPlate(ctx context.Context, plate string, companyID, vehicleID uint64) error {
+	existing, err := s.repo.GetByPlate(ctx, plate, companyID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("error checking plate number: %w", err)
+	}
+	if existing != nil && existing.ID != vehicleID {
+		return vehicle.ErrPlateTaken
+	}
+	return nil
+}
+
+// checkStore verifies that a vehicle of the company can be assigned to the
+// store, if one is given
+func (s *vehicleService) checkStore(ctx context.Context, companyID uint64, storeID *uint64) error {
+	if storeID == nil {
+		return nil
+	}
+	return s.stores.CheckAssignable(ctx, companyID, *storeID)
+}
+
+// parseVehicleDate parses an optional date given in vehicle.DateFormat
+func parseVehicleDate(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	date, err := time.Parse(vehicle.DateFormat, value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date %q: %w", value, err)
+	}
+	return &date, nil
+}
+
+func (s *vehicleService) toVehicleResponse(v *vehicle.Vehicle) vehicle.VehicleResponse {
+	var lastOilChange *string
+	if v.LastOilChange != nil {
+		formatted := v.LastOilChange.Format(vehicle.DateFormat)
+		lastOilChange = &formatted
+	}
+
+	maintenanceDue := []string(v.MaintenanceDue)
+	if maintenanceDue == nil {
+		maintenanceDue = []string{}
+	}
+
+	return vehicle.VehicleResponse{
+		ID:             v.ID,
+		CompanyID:      v.CompanyID,
+		StoreID:        v.StoreID,
+		PlateNumber:    v.PlateNumber,
+		Type:           v.Type,
+		Capacity:       v.Capacity,
+		Status:         v.Status,
+		FuelType:       v.FuelType,
+		LastOilChange:  lastOilChange,
+		MaintenanceDue: maintenanceDue,
+		CreatedAt:      v.CreatedAt,
+		UpdatedAt:      v.UpdatedAt,
+	}
+}
+
+func (s *vehicleService) toAssignmentResponse(a *vehicle.Assignment) vehicle.AssignmentResponse {
+	return vehicle.AssignmentResponse{
+		ID:           a.ID,
+		DriverID:     a.DriverID,
+		VehicleID:    a.VehicleID,
+		IsActive:     a.IsActive,
+		AutoAssigned: a.AutoAssigned,
+		AssignedAt:   a.AssignedAt,
+		UnassignedAt: a.UnassignedAt,
+	}
+}
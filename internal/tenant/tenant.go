@@ -0,0 +1,58 @@
+package tenant
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrPlatformOnly is returned when a tenant-scoped caller attempts a platform operation
+var ErrPlatformOnly = errors.New("operation is restricted to platform operators")
+
+type contextKey struct{}
+
+type platformKey struct{}
+
+// WithCompany returns a copy of ctx restricted to the given company
+func WithCompany(ctx context.Context, companyID uint64) context.Context {
+	return context.WithValue(ctx, contextKey{}, companyID)
+}
+
+// CompanyID returns the company the context is restricted to, if any
+func CompanyID(ctx context.Context) (uint64, bool) {
+	companyID, ok := ctx.Value(contextKey{}).(uint64)
+	return companyID, ok
+}
+
+// WithPlatform returns a copy of ctx acting for the platform, which may access
+// every company. It is set for platform operators and operational commands.
+func WithPlatform(ctx context.Context) context.Context {
+	return context.WithValue(ctx, platformKey{}, true)
+}
+
+// IsPlatform reports whether the context acts for the platform and is not
+// restricted to a tenant
+func IsPlatform(ctx context.Context) bool {
+	if _, ok := CompanyID(ctx); ok {
+		return false
+	}
+	platform, _ := ctx.Value(platformKey{}).(bool)
+	return platform
+}
+
+// Allows reports whether the context may access data owned by companyID.
+// Tenant contexts may only access their own company and platform contexts
+// every company. Contexts that are neither are allowed nothing.
+func Allows(ctx context.Context, companyID uint64) bool {
+	if scoped, ok := CompanyID(ctx); ok {
+		return scoped == companyID
+	}
+	return IsPlatform(ctx)
+}
+
+// RequirePlatform returns ErrPlatformOnly unless the context acts for the platform
+func RequirePlatform(ctx context.Context) error {
+	if !IsPlatform(ctx) {
+		return ErrPlatformOnly
+	}
+	return nil
+}
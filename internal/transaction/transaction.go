@@ -0,0 +1,11 @@
+package transaction
+
+import "context"
+
+// Transactor runs a unit of work in a single database transaction. The
+// transaction travels in the context passed to fn, so every repository call
+// made with that context joins it. Calls nested in an existing transaction
+// join the outer one.
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -0,0 +1,3 @@
+-- The users table holds platform operators (super-admins) who manage tenants,
+-- plans and module entitlements. Company admins live in company_admins.
+ALTER TABLE users ADD COLUMN is_active BOOLEAN DEFAULT TRUE AFTER password;
@@ -0,0 +1,3 @@
+ALTER TABLE company_admins DROP FOREIGN KEY fk_company_admins_custom_role;
+ALTER TABLE company_admins DROP COLUMN custom_role_id;
+DROP TABLE IF EXISTS roles;
@@ -0,0 +1,18 @@
+-- Company-defined custom roles (requires the roles_permissions module).
+-- The built-in owner and manager roles are defined in code.
+CREATE TABLE IF NOT EXISTS roles (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    company_id BIGINT UNSIGNED NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    permissions JSON NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (company_id) REFERENCES companies(id) ON DELETE CASCADE,
+    UNIQUE KEY unique_role_name_company (company_id, name),
+    INDEX idx_roles_company (company_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+ALTER TABLE company_admins ADD COLUMN custom_role_id BIGINT UNSIGNED NULL AFTER role;
+ALTER TABLE company_admins ADD CONSTRAINT fk_company_admins_custom_role FOREIGN KEY (custom_role_id) REFERENCES roles(id) ON DELETE SET NULL;
@@ -0,0 +1,32 @@
+-- Login sessions for admins, platform operators and drivers.
+-- Access tokens carry the session id (sid) and stop working once the
+-- session is revoked; refresh tokens rotate on every use.
+CREATE TABLE IF NOT EXISTS auth_sessions (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    subject_type VARCHAR(20) NOT NULL,
+    subject_id BIGINT UNSIGNED NOT NULL,
+    company_id BIGINT UNSIGNED NULL,
+    expires_at TIMESTAMP NOT NULL,
+    revoked_at TIMESTAMP NULL,
+    revoked_reason VARCHAR(50),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (company_id) REFERENCES companies(id) ON DELETE CASCADE,
+    INDEX idx_auth_sessions_subject (subject_type, subject_id),
+    INDEX idx_auth_sessions_company (company_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Only the SHA-256 hash of each refresh token is stored
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    session_id BIGINT UNSIGNED NOT NULL,
+    token_hash CHAR(64) NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (session_id) REFERENCES auth_sessions(id) ON DELETE CASCADE,
+    UNIQUE KEY unique_refresh_token_hash (token_hash),
+    INDEX idx_refresh_tokens_session (session_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,2 @@
+-- Role of the subject at login, copied into every access token of the session
+ALTER TABLE auth_sessions ADD COLUMN role VARCHAR(50) NULL AFTER company_id;
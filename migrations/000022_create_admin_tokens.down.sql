@@ -0,0 +1,2 @@
+ALTER TABLE company_admins DROP COLUMN email_verified_at;
+DROP TABLE IF EXISTS admin_tokens;
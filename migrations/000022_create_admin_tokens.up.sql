@@ -0,0 +1,17 @@
+-- Single-use tokens emailed to company admins (invites, password resets,
+-- email verification). Only the SHA-256 hash of each token is stored.
+CREATE TABLE IF NOT EXISTS admin_tokens (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    admin_id BIGINT UNSIGNED NOT NULL,
+    purpose VARCHAR(30) NOT NULL,
+    token_hash CHAR(64) NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (admin_id) REFERENCES company_admins(id) ON DELETE CASCADE,
+    UNIQUE KEY unique_admin_token_hash (token_hash),
+    INDEX idx_admin_tokens_admin (admin_id, purpose)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+ALTER TABLE company_admins ADD COLUMN email_verified_at TIMESTAMP NULL AFTER is_active;
@@ -0,0 +1,4 @@
+ALTER TABLE companies DROP COLUMN require_owner_mfa;
+DROP TABLE IF EXISTS mfa_challenges;
+DROP TABLE IF EXISTS admin_recovery_codes;
+DROP TABLE IF EXISTS admin_mfa;
@@ -0,0 +1,3 @@
+DELETE FROM activity_logs WHERE company_id IS NULL;
+ALTER TABLE activity_logs MODIFY company_id BIGINT UNSIGNED NOT NULL;
+DROP TABLE IF EXISTS login_throttle;
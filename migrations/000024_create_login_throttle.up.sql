@@ -0,0 +1,14 @@
+-- Failure counters for login brute-force protection, used when
+-- LOGIN_THROTTLE_STORE=sql so every API instance shares them
+CREATE TABLE IF NOT EXISTS login_throttle (
+    throttle_key VARCHAR(255) NOT NULL PRIMARY KEY,
+    failures INT NOT NULL DEFAULT 0,
+    last_failure_at TIMESTAMP NOT NULL,
+    locked_until TIMESTAMP NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+    INDEX idx_login_throttle_last_failure (last_failure_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Lockouts of IP addresses and unknown accounts belong to no company
+ALTER TABLE activity_logs MODIFY company_id BIGINT UNSIGNED NULL;
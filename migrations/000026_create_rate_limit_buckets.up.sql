@@ -0,0 +1,7 @@
+-- Token buckets for API rate limiting when RATE_LIMIT_STORE=sql, shared by
+-- every API instance. Keys are "company:<id>", "api_key:<id>" or "driver:<id>".
+CREATE TABLE IF NOT EXISTS rate_limit_buckets (
+    bucket_key VARCHAR(255) NOT NULL PRIMARY KEY,
+    tokens DOUBLE NOT NULL,
+    refilled_at DATETIME(6) NOT NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
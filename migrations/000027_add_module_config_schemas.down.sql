@@ -0,0 +1,2 @@
+ALTER TABLE modules_master DROP COLUMN default_config;
+ALTER TABLE modules_master DROP COLUMN config_schema;
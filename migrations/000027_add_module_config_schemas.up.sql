@@ -0,0 +1,39 @@
+-- Each module declares a JSON Schema for its company config plus the default
+-- config new assignments start from. Modules without a schema accept any config.
+ALTER TABLE modules_master ADD COLUMN config_schema JSON NULL AFTER default_enabled;
+ALTER TABLE modules_master ADD COLUMN default_config JSON NULL AFTER config_schema;
+
+UPDATE modules_master SET
+    config_schema = '{"type":"object","additionalProperties":false,"properties":{"max_shift_hours":{"type":"integer","minimum":1,"maximum":24,"description":"Longest shift a driver may work"},"min_break_minutes":{"type":"integer","minimum":0,"maximum":240,"description":"Minimum break between shifts"},"allow_overtime":{"type":"boolean"}}}',
+    default_config = '{"max_shift_hours":12,"min_break_minutes":30,"allow_overtime":false}'
+WHERE module_key = 'shift_management';
+
+UPDATE modules_master SET
+    config_schema = '{"type":"object","additionalProperties":false,"properties":{"update_interval_seconds":{"type":"integer","minimum":5,"maximum":3600,"description":"How often drivers report their location"},"track_offline":{"type":"boolean","description":"Keep tracking drivers outside their shifts"},"retention_days":{"type":"integer","minimum":1,"maximum":365}}}',
+    default_config = '{"update_interval_seconds":30,"track_offline":false,"retention_days":90}'
+WHERE module_key = 'gps_tracking';
+
+UPDATE modules_master SET
+    config_schema = '{"type":"object","additionalProperties":false,"properties":{"require_photo":{"type":"boolean"},"require_signature":{"type":"boolean"},"max_photos":{"type":"integer","minimum":1,"maximum":10}}}',
+    default_config = '{"require_photo":true,"require_signature":false,"max_photos":3}'
+WHERE module_key = 'proof_of_delivery';
+
+UPDATE modules_master SET
+    config_schema = '{"type":"object","additionalProperties":false,"properties":{"max_cash_per_driver":{"type":"number","minimum":0,"description":"Cash a driver may hold before depositing"},"require_daily_deposit":{"type":"boolean"}}}',
+    default_config = '{"max_cash_per_driver":1000,"require_daily_deposit":true}'
+WHERE module_key = 'cash_handling';
+
+UPDATE modules_master SET
+    config_schema = '{"type":"object","additionalProperties":false,"properties":{"channels":{"type":"array","minItems":1,"items":{"type":"string","enum":["push","sms","email","whatsapp"]}},"quiet_hours_start":{"type":"string","pattern":"^([01][0-9]|2[0-3]):[0-5][0-9]$"},"quiet_hours_end":{"type":"string","pattern":"^([01][0-9]|2[0-3]):[0-5][0-9]$"}}}',
+    default_config = '{"channels":["push"]}'
+WHERE module_key = 'notifications';
+
+UPDATE modules_master SET
+    config_schema = '{"type":"object","additionalProperties":false,"properties":{"objective":{"type":"string","enum":["shortest_distance","shortest_time","balanced"]},"max_stops_per_route":{"type":"integer","minimum":1,"maximum":500},"avoid_tolls":{"type":"boolean"}}}',
+    default_config = '{"objective":"balanced","max_stops_per_route":50,"avoid_tolls":false}'
+WHERE module_key = 'route_optimization';
+
+UPDATE modules_master SET
+    config_schema = '{"type":"object","additionalProperties":false,"properties":{"allowed_origins":{"type":"array","maxItems":20,"items":{"type":"string","minLength":1,"maxLength":255}}}}',
+    default_config = '{"allowed_origins":[]}'
+WHERE module_key = 'api_access';
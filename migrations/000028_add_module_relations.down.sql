@@ -0,0 +1,2 @@
+ALTER TABLE modules_master DROP COLUMN conflicts;
+ALTER TABLE modules_master DROP COLUMN requires;
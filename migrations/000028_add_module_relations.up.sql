@@ -0,0 +1,29 @@
+-- Modules declare the modules they require and the ones they cannot be
+-- enabled alongside, as JSON arrays of module keys. Conflicts are symmetric,
+-- so each pair only needs to be declared on one side.
+ALTER TABLE modules_master ADD COLUMN requires JSON NULL AFTER default_config;
+ALTER TABLE modules_master ADD COLUMN conflicts JSON NULL AFTER requires;
+
+UPDATE modules_master SET requires = '["driver_management"]'
+WHERE module_key IN ('shift_management', 'gps_tracking', 'auto_assignment', 'driver_efficiency');
+
+UPDATE modules_master SET requires = '["proof_of_delivery"]'
+WHERE module_key IN ('signature_pod', 'photo_pod', 'otp_qr_delivery', 'document_scan_pod');
+
+UPDATE modules_master SET requires = '["cash_collection"]'
+WHERE module_key IN ('driver_reconciliation', 'cash_discrepancy');
+
+UPDATE modules_master SET requires = '["product_inventory"]'
+WHERE module_key IN ('warehouse_stock', 'stock_movement', 'auto_reorder');
+
+UPDATE modules_master SET requires = '["vehicle_stock"]'
+WHERE module_key IN ('realtime_driver_inventory', 'eod_stock_return');
+
+UPDATE modules_master SET requires = '["vehicle_management", "product_inventory"]' WHERE module_key = 'vehicle_stock';
+UPDATE modules_master SET requires = '["vehicle_management"]' WHERE module_key = 'vehicle_load_planning';
+UPDATE modules_master SET requires = '["auto_assignment"]' WHERE module_key = 'ai_driver_assignment';
+UPDATE modules_master SET requires = '["client_management"]' WHERE module_key = 'client_billing';
+UPDATE modules_master SET requires = '["client_billing"]' WHERE module_key = 'invoice_generation';
+UPDATE modules_master SET requires = '["store_management"]' WHERE module_key = 'multi_depot';
+
+UPDATE modules_master SET conflicts = '["route_optimization"]' WHERE module_key = 'ai_route_planning';
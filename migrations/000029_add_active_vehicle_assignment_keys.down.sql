@@ -0,0 +1,5 @@
+ALTER TABLE driver_vehicle_assignments
+    DROP INDEX unique_active_vehicle,
+    DROP INDEX unique_active_driver,
+    DROP COLUMN active_vehicle_id,
+    DROP COLUMN active_driver_id;
@@ -0,0 +1,7 @@
+-- Allow at most one active assignment per driver and per vehicle. The
+-- generated columns are NULL for ended assignments, which the unique keys ignore.
+ALTER TABLE driver_vehicle_assignments
+    ADD COLUMN active_driver_id BIGINT UNSIGNED AS (IF(is_active, driver_id, NULL)) STORED,
+    ADD COLUMN active_vehicle_id BIGINT UNSIGNED AS (IF(is_active, vehicle_id, NULL)) STORED,
+    ADD UNIQUE KEY unique_active_driver (active_driver_id),
+    ADD UNIQUE KEY unique_active_vehicle (active_vehicle_id);
@@ -0,0 +1 @@
+ALTER TABLE driver_vehicle_assignments DROP COLUMN auto_assigned;
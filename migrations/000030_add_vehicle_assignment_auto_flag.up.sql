@@ -0,0 +1,4 @@
+-- Mark assignments made at shift start, which end with the shift. Existing
+-- assignments were all made by admins and keep the default.
+ALTER TABLE driver_vehicle_assignments
+    ADD COLUMN auto_assigned BOOLEAN NOT NULL DEFAULT FALSE AFTER is_active;
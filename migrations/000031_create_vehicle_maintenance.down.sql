@@ -0,0 +1,9 @@
+ALTER TABLE driver_shifts
+    DROP FOREIGN KEY fk_shifts_vehicle,
+    DROP INDEX idx_shifts_vehicle,
+    DROP COLUMN vehicle_id;
+
+ALTER TABLE vehicles DROP COLUMN maintenance_due;
+
+DROP TABLE IF EXISTS vehicle_maintenance_records;
+DROP TABLE IF EXISTS vehicle_maintenance_rules;
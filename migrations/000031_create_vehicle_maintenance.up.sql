@@ -0,0 +1,38 @@
+CREATE TABLE IF NOT EXISTS vehicle_maintenance_rules (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    company_id BIGINT UNSIGNED NOT NULL,
+    service_type VARCHAR(100) NOT NULL,
+    interval_days INT,
+    interval_distance DECIMAL(10, 2),
+    action ENUM('flag', 'maintenance') DEFAULT 'flag',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (company_id) REFERENCES companies(id) ON DELETE CASCADE,
+    UNIQUE KEY unique_rule_service_type (company_id, service_type)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS vehicle_maintenance_records (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    company_id BIGINT UNSIGNED NOT NULL,
+    vehicle_id BIGINT UNSIGNED NOT NULL,
+    service_type VARCHAR(100) NOT NULL,
+    odometer DECIMAL(12, 2),
+    cost DECIMAL(10, 2),
+    serviced_at DATE NOT NULL,
+    notes TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (company_id) REFERENCES companies(id) ON DELETE CASCADE,
+    FOREIGN KEY (vehicle_id) REFERENCES vehicles(id) ON DELETE CASCADE,
+    INDEX idx_maintenance_records_vehicle (vehicle_id, service_type, serviced_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Service types a vehicle is overdue for, kept up to date by the maintenance check
+ALTER TABLE vehicles ADD COLUMN maintenance_due JSON;
+
+-- The vehicle a driver used during a shift, for distance based maintenance
+ALTER TABLE driver_shifts
+    ADD COLUMN vehicle_id BIGINT UNSIGNED NULL,
+    ADD CONSTRAINT fk_shifts_vehicle FOREIGN KEY (vehicle_id) REFERENCES vehicles(id) ON DELETE SET NULL,
+    ADD INDEX idx_shifts_vehicle (vehicle_id, start_time);
@@ -0,0 +1,18 @@
+-- Read state of each admin, so company-wide notifications are read per admin
+CREATE TABLE IF NOT EXISTS notification_reads (
+    notification_id BIGINT UNSIGNED NOT NULL,
+    admin_id BIGINT UNSIGNED NOT NULL,
+    read_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (notification_id, admin_id),
+    FOREIGN KEY (notification_id) REFERENCES notifications(id) ON DELETE CASCADE,
+    FOREIGN KEY (admin_id) REFERENCES company_admins(id) ON DELETE CASCADE,
+    INDEX idx_notification_reads_admin (admin_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Notifications read so far were read for every admin they were shown to
+INSERT INTO notification_reads (notification_id, admin_id)
+SELECT n.id, a.id
+FROM notifications n
+JOIN company_admins a ON a.company_id = n.company_id AND (n.user_id IS NULL OR n.user_id = a.id)
+WHERE n.is_read = TRUE AND n.driver_id IS NULL;
@@ -0,0 +1,4 @@
+-- Phone numbers stay normalized; their original formatting is not kept
+ALTER TABLE clients DROP INDEX idx_clients_name;
+
+DROP TABLE IF EXISTS client_addresses;
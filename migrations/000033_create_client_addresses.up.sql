@@ -0,0 +1,39 @@
+CREATE TABLE IF NOT EXISTS client_addresses (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    client_id BIGINT UNSIGNED NOT NULL,
+    label VARCHAR(100),
+    address TEXT NOT NULL,
+    latitude DECIMAL(10, 8),
+    longitude DECIMAL(11, 8),
+    is_default BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (client_id) REFERENCES clients(id) ON DELETE CASCADE,
+    INDEX idx_client_addresses_client (client_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- The address stored on a client becomes its default saved address
+INSERT INTO client_addresses (client_id, address, latitude, longitude, is_default)
+SELECT id, address, latitude, longitude, TRUE
+FROM clients
+WHERE address IS NOT NULL AND address <> '';
+
+ALTER TABLE clients ADD INDEX idx_clients_name (company_id, name);
+
+-- Store existing phone numbers as their digits and leading '+', like new ones.
+-- A number is left as is when its company already has it normalized, or when
+-- another client with a lower ID has the same number in another format; such
+-- duplicates are meant to be merged.
+UPDATE clients c
+SET c.phone = CONCAT(IF(TRIM(c.phone) LIKE '+%', '+', ''), REGEXP_REPLACE(c.phone, '[^0-9]', ''))
+WHERE REGEXP_REPLACE(c.phone, '[^0-9]', '') <> ''
+  AND c.phone <> CONCAT(IF(TRIM(c.phone) LIKE '+%', '+', ''), REGEXP_REPLACE(c.phone, '[^0-9]', ''))
+  AND NOT EXISTS (
+      SELECT 1 FROM (SELECT id, company_id, phone FROM clients) o
+      WHERE o.company_id = c.company_id
+        AND o.id <> c.id
+        AND CONCAT(IF(TRIM(o.phone) LIKE '+%', '+', ''), REGEXP_REPLACE(o.phone, '[^0-9]', ''))
+            = CONCAT(IF(TRIM(c.phone) LIKE '+%', '+', ''), REGEXP_REPLACE(c.phone, '[^0-9]', ''))
+        AND (o.id < c.id OR o.phone = CONCAT(IF(TRIM(o.phone) LIKE '+%', '+', ''), REGEXP_REPLACE(o.phone, '[^0-9]', '')))
+  );
@@ -0,0 +1,98 @@
+package geocode
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// ErrNotFound is returned when an address cannot be resolved
+var ErrNotFound = errors.New("address not found")
+
+// Location is a point given by its coordinates in degrees
+type Location struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// Geocoder resolves postal addresses to coordinates
+type Geocoder interface {
+	Geocode(ctx context.Context, address string) (*Location, error)
+}
+
+// MemoryGeocoder resolves the addresses it was given. Useful for tests and local development.
+type MemoryGeocoder struct {
+	mu        sync.RWMutex
+	locations map[string]Location
+}
+
+// NewMemoryGeocoder creates a new in-memory geocoder that knows no addresses
+func NewMemoryGeocoder() *MemoryGeocoder {
+	return &MemoryGeocoder{locations: make(map[string]Location)}
+}
+
+// Add makes the geocoder resolve address to loc
+func (g *MemoryGeocoder) Add(address string, loc Location) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.locations[normalize(address)] = loc
+}
+
+// Geocode returns the location added for the address
+func (g *MemoryGeocoder) Geocode(ctx context.Context, address string) (*Location, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	loc, ok := g.locations[normalize(address)]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &loc, nil
+}
+
+// FileGeocoder resolves addresses offline from a JSON file listing known
+// addresses:
+//
+//	[{"address": "12 Main St, Springfield", "latitude": 39.78, "longitude": -89.65}]
+type FileGeocoder struct {
+	*MemoryGeocoder
+}
+
+// NewFileGeocoder loads the addresses listed in the file at path. A missing
+// file resolves no address.
+func NewFileGeocoder(path string) (*FileGeocoder, error) {
+	g := &FileGeocoder{MemoryGeocoder: NewMemoryGeocoder()}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return g, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read geocoding file: %w", err)
+	}
+
+	var entries []struct {
+		Address string `json:"address"`
+		Location
+	}
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse geocoding file: %w", err)
+	}
+	for _, e := range entries {
+		g.Add(e.Address, e.Location)
+	}
+	return g, nil
+}
+
+// normalize reduces an address to lower case words, so lookups ignore case,
+// punctuation and spacing
+func normalize(address string) string {
+	words := strings.FieldsFunc(strings.ToLower(address), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(words, " ")
+}
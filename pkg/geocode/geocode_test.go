@@ -0,0 +1,127 @@
+package geocode
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestNewFileGeocoder(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	tests := []struct {
+		name    string
+		path    string
+		address string
+		want    *Location
+		wantErr bool
+	}{
+		{
+			name:    "missing file resolves nothing",
+			path:    filepath.Join(dir, "missing.json"),
+			address: "12 Main St, Springfield",
+		},
+		{
+			name:    "bad file",
+			path:    write("bad.json", `{"address": "12 Main St"`),
+			wantErr: true,
+		},
+		{
+			name:    "listed address",
+			path:    write("addresses.json", `[{"address": "12 Main St, Springfield", "latitude": 39.78, "longitude": -89.65}]`),
+			address: "12 main st springfield",
+			want:    &Location{Latitude: 39.78, Longitude: -89.65},
+		},
+		{
+			name:    "unlisted address",
+			path:    write("other.json", `[{"address": "12 Main St, Springfield", "latitude": 39.78, "longitude": -89.65}]`),
+			address: "14 Main St, Springfield",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := NewFileGeocoder(tt.path)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("NewFileGeocoder() succeeded, want an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewFileGeocoder() error = %v", err)
+			}
+
+			got, err := g.Geocode(context.Background(), tt.address)
+			if tt.want == nil {
+				if !errors.Is(err, ErrNotFound) {
+					t.Errorf("Geocode() = %v, %v, want ErrNotFound", got, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Geocode() error = %v", err)
+			}
+			if *got != *tt.want {
+				t.Errorf("Geocode() = %v, want %v", *got, *tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		address string
+		want    string
+	}{
+		{"12 Main St", "12 main st"},
+		{"12 MAIN ST.", "12 main st"},
+		{"  12,  Main-St  ", "12 main st"},
+		{"Straße 5, München", "straße 5 münchen"},
+		{"", ""},
+		{"...", ""},
+	}
+
+	for _, tt := range tests {
+		if got := normalize(tt.address); got != tt.want {
+			t.Errorf("normalize(%q) = %q, want %q", tt.address, got, tt.want)
+		}
+	}
+}
+
+func TestMemoryGeocoder(t *testing.T) {
+	g := NewMemoryGeocoder()
+	g.Add("12 Main St, Springfield", Location{Latitude: 1, Longitude: 2})
+
+	tests := []struct {
+		address string
+		want    *Location
+	}{
+		{"12 Main St, Springfield", &Location{Latitude: 1, Longitude: 2}},
+		{"12 main st springfield", &Location{Latitude: 1, Longitude: 2}},
+		{"12 Main St", nil},
+		{"", nil},
+	}
+
+	for _, tt := range tests {
+		got, err := g.Geocode(context.Background(), tt.address)
+		if tt.want == nil {
+			if !errors.Is(err, ErrNotFound) {
+				t.Errorf("Geocode(%q) = %v, %v, want ErrNotFound", tt.address, got, err)
+			}
+			continue
+		}
+		if err != nil || *got != *tt.want {
+			t.Errorf("Geocode(%q) = %v, %v, want %v", tt.address, got, err, *tt.want)
+		}
+	}
+}
@@ -1,6 +1,17 @@
 package hash
 
-import "golang.org/x/crypto/bcrypt"
+import (
+	"sync"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// dummyHash hashes a throwaway password at the default cost. It is built on
+// first use so processes that never log anyone in do not pay for it.
+var dummyHash = sync.OnceValue(func() []byte {
+	bytes, _ := bcrypt.GenerateFromPassword([]byte("dummy password"), bcrypt.DefaultCost)
+	return bytes
+})
 
 // Hash hashes a password using bcrypt
 func Hash(password string) (string, error) {
@@ -14,6 +25,13 @@ func Check(password, hash string) bool {
 	return err == nil
 }
 
+// CheckDummy compares the password with a dummy hash and discards the result.
+// Logins for unknown accounts call it so they take as long as logins with a
+// wrong password, and response times do not reveal which accounts exist.
+func CheckDummy(password string) {
+	_ = bcrypt.CompareHashAndPassword(dummyHash(), []byte(password))
+}
+
 // HashPassword is an alias for Hash
 func HashPassword(password string) (string, error) {
 	return Hash(password)
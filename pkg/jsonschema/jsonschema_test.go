@@ -0,0 +1,210 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+		value  string
+		want   []FieldError
+	}{
+		{
+			name:   "type matches",
+			schema: `{"type": "string"}`,
+			value:  `"hello"`,
+		},
+		{
+			name:   "type mismatch",
+			schema: `{"type": "string"}`,
+			value:  `42`,
+			want:   []FieldError{{Field: "", Message: "must be of type string"}},
+		},
+		{
+			name:   "integer accepts whole numbers",
+			schema: `{"type": "integer"}`,
+			value:  `3`,
+		},
+		{
+			name:   "integer rejects fractions",
+			schema: `{"type": "integer"}`,
+			value:  `3.5`,
+			want:   []FieldError{{Field: "", Message: "must be of type integer"}},
+		},
+		{
+			name:   "null type",
+			schema: `{"type": "null"}`,
+			value:  `null`,
+		},
+		{
+			name:   "unknown type never matches",
+			schema: `{"type": "date"}`,
+			value:  `"2024-01-01"`,
+			want:   []FieldError{{Field: "", Message: "must be of type date"}},
+		},
+		{
+			name:   "enum matches",
+			schema: `{"enum": ["a", "b", 1]}`,
+			value:  `1`,
+		},
+		{
+			name:   "enum mismatch",
+			schema: `{"enum": ["a", "b"]}`,
+			value:  `"c"`,
+			want:   []FieldError{{Field: "", Message: `must be one of "a", "b"`}},
+		},
+		{
+			name:   "pattern matches",
+			schema: `{"type": "string", "pattern": "^[A-Z]{3}$"}`,
+			value:  `"EUR"`,
+		},
+		{
+			name:   "pattern mismatch",
+			schema: `{"type": "string", "pattern": "^[A-Z]{3}$"}`,
+			value:  `"euro"`,
+			want:   []FieldError{{Field: "", Message: "must match ^[A-Z]{3}$"}},
+		},
+		{
+			name:   "invalid pattern",
+			schema: `{"type": "string", "pattern": "("}`,
+			value:  `"x"`,
+			want:   []FieldError{{Field: "", Message: "schema pattern is invalid"}},
+		},
+		{
+			name:   "string length within bounds",
+			schema: `{"type": "string", "minLength": 2, "maxLength": 3}`,
+			value:  `"héé"`,
+		},
+		{
+			name:   "string too short",
+			schema: `{"type": "string", "minLength": 2}`,
+			value:  `"a"`,
+			want:   []FieldError{{Field: "", Message: "must be at least 2 characters"}},
+		},
+		{
+			name:   "string too long",
+			schema: `{"type": "string", "maxLength": 3}`,
+			value:  `"abcd"`,
+			want:   []FieldError{{Field: "", Message: "must not exceed 3 characters"}},
+		},
+		{
+			name:   "number within bounds",
+			schema: `{"type": "number", "minimum": 1, "maximum": 10}`,
+			value:  `10`,
+		},
+		{
+			name:   "number below minimum",
+			schema: `{"type": "number", "minimum": 1.5}`,
+			value:  `1`,
+			want:   []FieldError{{Field: "", Message: "must be at least 1.5"}},
+		},
+		{
+			name:   "number above maximum",
+			schema: `{"type": "number", "maximum": 10}`,
+			value:  `11`,
+			want:   []FieldError{{Field: "", Message: "must be at most 10"}},
+		},
+		{
+			name:   "items are validated with their index",
+			schema: `{"type": "array", "items": {"type": "integer", "minimum": 0}}`,
+			value:  `[1, -1, "x"]`,
+			want: []FieldError{
+				{Field: "[1]", Message: "must be at least 0"},
+				{Field: "[2]", Message: "must be of type integer"},
+			},
+		},
+		{
+			name:   "too few items",
+			schema: `{"type": "array", "minItems": 2}`,
+			value:  `[1]`,
+			want:   []FieldError{{Field: "", Message: "must have at least 2 items"}},
+		},
+		{
+			name:   "too many items",
+			schema: `{"type": "array", "maxItems": 1}`,
+			value:  `[1, 2]`,
+			want:   []FieldError{{Field: "", Message: "must have at most 1 items"}},
+		},
+		{
+			name:   "required properties present",
+			schema: `{"type": "object", "required": ["name"]}`,
+			value:  `{"name": "x"}`,
+		},
+		{
+			name:   "required property missing",
+			schema: `{"type": "object", "required": ["name", "rate"]}`,
+			value:  `{"name": "x"}`,
+			want:   []FieldError{{Field: "rate", Message: "is required"}},
+		},
+		{
+			name:   "additional properties allowed by default",
+			schema: `{"type": "object", "properties": {"a": {"type": "string"}}}`,
+			value:  `{"a": "x", "b": 1}`,
+		},
+		{
+			name:   "additional properties rejected",
+			schema: `{"type": "object", "properties": {"a": {"type": "string"}}, "additionalProperties": false}`,
+			value:  `{"a": "x", "c": 1, "b": 2}`,
+			want: []FieldError{
+				{Field: "b", Message: "is not allowed"},
+				{Field: "c", Message: "is not allowed"},
+			},
+		},
+		{
+			name: "nested paths",
+			schema: `{
+				"type": "object",
+				"properties": {
+					"stops": {
+						"type": "array",
+						"items": {"type": "object", "required": ["name"], "properties": {"name": {"type": "string"}}}
+					}
+				}
+			}`,
+			value: `{"stops": [{"name": "a"}, {}, {"name": 3}]}`,
+			want: []FieldError{
+				{Field: "stops[1].name", Message: "is required"},
+				{Field: "stops[2].name", Message: "must be of type string"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var schema Schema
+			if err := json.Unmarshal([]byte(tt.schema), &schema); err != nil {
+				t.Fatalf("invalid schema: %v", err)
+			}
+			var value interface{}
+			if err := json.Unmarshal([]byte(tt.value), &value); err != nil {
+				t.Fatalf("invalid value: %v", err)
+			}
+
+			got := schema.Validate(value)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateGoValues(t *testing.T) {
+	schema := Schema{
+		Type:     "object",
+		Required: []string{"limit"},
+		Properties: map[string]*Schema{
+			"limit": {Type: "integer", Enum: []interface{}{10, 20}},
+		},
+	}
+
+	if errs := schema.Validate(map[string]interface{}{"limit": 20}); errs != nil {
+		t.Errorf("Validate() = %#v, want no errors", errs)
+	}
+	if errs := schema.Validate(map[string]int{"limit": 30}); len(errs) != 1 || errs[0].Field != "limit" {
+		t.Errorf("Validate() = %#v, want one error on limit", errs)
+	}
+}
@@ -1,7 +1,12 @@
 package jwt
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
+	"fmt"
+	"sort"
+	"strconv"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -12,52 +17,150 @@ var (
 	ErrExpiredToken = errors.New("token has expired")
 )
 
-// Claims represents the JWT claims
+// SubjectType identifies the kind of principal a token was issued to.
+// It is also used as the token audience, so a token issued to one kind of
+// principal is never accepted on routes meant for another.
+type SubjectType string
+
+const (
+	SubjectAdmin    SubjectType = "admin"
+	SubjectPlatform SubjectType = "platform"
+	SubjectDriver   SubjectType = "driver"
+	SubjectAPIKey   SubjectType = "api_key"
+)
+
+// Subject describes the principal a token is issued to
+type Subject struct {
+	Type      SubjectType
+	ID        uint64
+	CompanyID uint64
+	Role      string
+	SessionID uint64
+}
+
+// Claims represents the JWT claims. The subject ID is carried in the
+// registered "sub" claim and the token ID in "jti".
 type Claims struct {
-	UserID uint   `json:"user_id"`
-	Email  string `json:"email"`
+	SubjectType SubjectType `json:"sub_type"`
+	CompanyID   uint64      `json:"company_id,omitempty"`
+	Role        string      `json:"role,omitempty"`
+	SessionID   uint64      `json:"sid,omitempty"`
 	jwt.RegisteredClaims
 }
 
-// Manager handles JWT token operations
-type Manager struct {
-	secret     []byte
-	expiration time.Duration
+// SubjectID returns the numeric subject ID
+func (c *Claims) SubjectID() uint64 {
+	id, _ := strconv.ParseUint(c.Subject, 10, 64)
+	return id
+}
+
+// Config holds token service configuration. When SigningKey is set tokens are
+// signed with it (RS256 or EdDSA) and Secret is ignored; otherwise tokens are
+// signed with HS256 using Secret.
+type Config struct {
+	Secret      string
+	Issuer      string
+	Expiration  time.Duration
+	SigningKey  *Key
+	RetiredKeys []*Key
+	// GracePeriod is how long a retired key keeps verifying tokens after its retirement
+	GracePeriod time.Duration
+}
+
+// Service issues and validates access tokens
+type Service struct {
+	secret      []byte
+	issuer      string
+	expiration  time.Duration
+	signingKey  *Key
+	keys        map[string]*Key
+	gracePeriod time.Duration
 }
 
-// NewManager creates a new JWT manager
-func NewManager(secret string, expiration time.Duration) *Manager {
-	return &Manager{
-		secret:     []byte(secret),
-		expiration: expiration,
+// NewService creates a new token service
+func NewService(cfg Config) (*Service, error) {
+	s := &Service{
+		secret:      []byte(cfg.Secret),
+		issuer:      cfg.Issuer,
+		expiration:  cfg.Expiration,
+		signingKey:  cfg.SigningKey,
+		keys:        make(map[string]*Key),
+		gracePeriod: cfg.GracePeriod,
+	}
+
+	if cfg.SigningKey == nil {
+		if cfg.Secret == "" {
+			return nil, errors.New("either a signing key or a secret is required")
+		}
+		if len(cfg.RetiredKeys) > 0 {
+			return nil, errors.New("retired keys require a signing key")
+		}
+		return s, nil
+	}
+
+	if cfg.SigningKey.RetiredAt != nil {
+		return nil, fmt.Errorf("signing key %s is retired", cfg.SigningKey.ID)
+	}
+	for _, key := range append([]*Key{cfg.SigningKey}, cfg.RetiredKeys...) {
+		if _, exists := s.keys[key.ID]; exists {
+			return nil, fmt.Errorf("duplicate key id %s", key.ID)
+		}
+		if key != cfg.SigningKey && key.RetiredAt == nil {
+			return nil, fmt.Errorf("key %s has no retirement time", key.ID)
+		}
+		s.keys[key.ID] = key
 	}
+
+	return s, nil
+}
+
+// Expiration returns the lifetime of issued tokens
+func (s *Service) Expiration() time.Duration {
+	return s.expiration
 }
 
-// Generate creates a new JWT token for a user
-func (m *Manager) Generate(userID uint, email string) (string, error) {
-	claims := Claims{
-		UserID: userID,
-		Email:  email,
+// Issue creates a signed access token for the subject
+func (s *Service) Issue(subject Subject) (string, *Claims, error) {
+	jti, err := newTokenID()
+	if err != nil {
+		return "", nil, err
+	}
+
+	now := time.Now()
+	claims := &Claims{
+		SubjectType: subject.Type,
+		CompanyID:   subject.CompanyID,
+		Role:        subject.Role,
+		SessionID:   subject.SessionID,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(m.expiration)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-			NotBefore: jwt.NewNumericDate(time.Now()),
+			ID:        jti,
+			Issuer:    s.issuer,
+			Subject:   strconv.FormatUint(subject.ID, 10),
+			Audience:  jwt.ClaimStrings{string(subject.Type)},
+			ExpiresAt: jwt.NewNumericDate(now.Add(s.expiration)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(m.secret)
+	var token string
+	if s.signingKey != nil {
+		t := jwt.NewWithClaims(s.signingKey.method, claims)
+		t.Header["kid"] = s.signingKey.ID
+		token, err = t.SignedString(s.signingKey.private)
+	} else {
+		token, err = jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(s.secret)
+	}
+	if err != nil {
+		return "", nil, err
+	}
+	return token, claims, nil
 }
 
-// Validate verifies a JWT token and returns the claims
-func (m *Manager) Validate(tokenString string) (*Claims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		// Verify signing method
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, ErrInvalidToken
-		}
-		return m.secret, nil
-	})
+// Validate verifies a token issued by this service to the given subject type and returns its claims
+func (s *Service) Validate(tokenString string, subjectType SubjectType) (*Claims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, s.verificationKey,
+		jwt.WithAudience(string(subjectType)), jwt.WithIssuer(s.issuer))
 
 	if err != nil {
 		if errors.Is(err, jwt.ErrTokenExpired) {
@@ -67,49 +170,63 @@ func (m *Manager) Validate(tokenString string) (*Claims, error) {
 	}
 
 	claims, ok := token.Claims.(*Claims)
-	if !ok || !token.Valid {
+	if !ok || !token.Valid || claims.SubjectType != subjectType || claims.SubjectID() == 0 {
 		return nil, ErrInvalidToken
 	}
 
 	return claims, nil
 }
 
-// GenerateToken is a helper function to generate a token with just userID
-func GenerateToken(userID uint64, secret string) (string, error) {
-	claims := Claims{
-		UserID: uint(userID),
-		Email:  "",
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-			NotBefore: jwt.NewNumericDate(time.Now()),
-		},
+// JWKS returns the public keys that currently verify tokens: the signing key
+// and every retired key still inside its grace window
+func (s *Service) JWKS() JWKSet {
+	set := JWKSet{Keys: []JWK{}}
+	if s.signingKey == nil {
+		return set
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(secret))
+	now := time.Now()
+	set.Keys = append(set.Keys, s.signingKey.JWK())
+	for _, key := range s.keys {
+		if key != s.signingKey && s.verifies(key, now) {
+			set.Keys = append(set.Keys, key.JWK())
+		}
+	}
+	sort.Slice(set.Keys[1:], func(i, j int) bool {
+		return set.Keys[i+1].KeyID < set.Keys[j+1].KeyID
+	})
+	return set
 }
 
-// ValidateToken is a helper function to validate a token and return userID
-func ValidateToken(tokenString string, secret string) (uint64, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
+// verificationKey selects the key a token must be verified with. With
+// asymmetric keys configured, the token's kid and algorithm must match a
+// known key; HMAC tokens are rejected.
+func (s *Service) verificationKey(token *jwt.Token) (interface{}, error) {
+	if s.signingKey == nil {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, ErrInvalidToken
 		}
-		return []byte(secret), nil
-	})
-
-	if err != nil {
-		if errors.Is(err, jwt.ErrTokenExpired) {
-			return 0, ErrExpiredToken
-		}
-		return 0, ErrInvalidToken
+		return s.secret, nil
 	}
 
-	claims, ok := token.Claims.(*Claims)
-	if !ok || !token.Valid {
-		return 0, ErrInvalidToken
+	kid, _ := token.Header["kid"].(string)
+	key, ok := s.keys[kid]
+	if !ok || token.Method.Alg() != key.Algorithm() || !s.verifies(key, time.Now()) {
+		return nil, ErrInvalidToken
 	}
+	return key.public, nil
+}
 
-	return uint64(claims.UserID), nil
+// verifies reports whether key may still be used to verify tokens
+func (s *Service) verifies(key *Key, now time.Time) bool {
+	return key.RetiredAt == nil || now.Before(key.RetiredAt.Add(s.gracePeriod))
+}
+
+// newTokenID returns a random identifier for the jti claim
+func newTokenID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
 }
@@ -0,0 +1,135 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Key is an asymmetric signing key identified by its kid. Retired keys are no
+// longer used for signing but still verify tokens until the grace window
+// after RetiredAt has passed.
+type Key struct {
+	ID        string
+	RetiredAt *time.Time
+
+	method  jwt.SigningMethod
+	private crypto.PrivateKey
+	public  crypto.PublicKey
+}
+
+// Algorithm returns the JWS algorithm of the key (RS256 or EdDSA)
+func (k *Key) Algorithm() string {
+	return k.method.Alg()
+}
+
+// ParsePrivateKeyPEM parses a PEM encoded RSA (PKCS#1 or PKCS#8) or
+// Ed25519 (PKCS#8) private key
+func ParsePrivateKeyPEM(kid string, data []byte) (*Key, error) {
+	if kid == "" {
+		return nil, errors.New("key id is required")
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("key %s: no PEM data found", kid)
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		if parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes); err != nil {
+			return nil, fmt.Errorf("key %s: unsupported private key: %w", kid, err)
+		}
+	}
+
+	switch key := parsed.(type) {
+	case *rsa.PrivateKey:
+		return &Key{ID: kid, method: jwt.SigningMethodRS256, private: key, public: &key.PublicKey}, nil
+	case ed25519.PrivateKey:
+		return &Key{ID: kid, method: jwt.SigningMethodEdDSA, private: key, public: key.Public()}, nil
+	default:
+		return nil, fmt.Errorf("key %s: only RSA and Ed25519 keys are supported", kid)
+	}
+}
+
+// LoadKey loads a key from a spec of the form "kid=/path/to/key.pem".
+// Retired keys append the retirement time: "kid=/path/to/key.pem@2006-01-02T15:04:05Z".
+func LoadKey(spec string) (*Key, error) {
+	kid, rest, ok := strings.Cut(strings.TrimSpace(spec), "=")
+	if !ok {
+		return nil, fmt.Errorf("invalid key spec %q: expected kid=path", spec)
+	}
+
+	path, retired, hasRetired := strings.Cut(rest, "@")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("key %s: %w", kid, err)
+	}
+
+	key, err := ParsePrivateKeyPEM(kid, data)
+	if err != nil {
+		return nil, err
+	}
+
+	if hasRetired {
+		retiredAt, err := time.Parse(time.RFC3339, retired)
+		if err != nil {
+			return nil, fmt.Errorf("key %s: invalid retirement time: %w", kid, err)
+		}
+		key.RetiredAt = &retiredAt
+	}
+
+	return key, nil
+}
+
+// JWK is a JSON Web Key holding the public half of a signing key
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	// RSA
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+	// Ed25519
+	Curve string `json:"crv,omitempty"`
+	X     string `json:"x,omitempty"`
+}
+
+// JWKSet is the document served at /.well-known/jwks.json
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWK returns the public JSON Web Key representation of the key
+func (k *Key) JWK() JWK {
+	jwk := JWK{
+		KeyID:     k.ID,
+		Use:       "sig",
+		Algorithm: k.Algorithm(),
+	}
+
+	switch pub := k.public.(type) {
+	case *rsa.PublicKey:
+		jwk.KeyType = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+	case ed25519.PublicKey:
+		jwk.KeyType = "OKP"
+		jwk.Curve = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(pub)
+	}
+
+	return jwk
+}
@@ -0,0 +1,297 @@
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var (
+	testRSAKey, _     = rsa.GenerateKey(rand.Reader, 2048)
+	_, testEdKey, _   = ed25519.GenerateKey(rand.Reader)
+	testECDSAKey, _   = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	testAudience      = SubjectAdmin
+	testSubject       = Subject{Type: testAudience, ID: 1, CompanyID: 2}
+	testRSAPKCS1Block = pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(testRSAKey)})
+)
+
+// pkcs8PEM encodes a private key as a PKCS#8 PEM block
+func pkcs8PEM(t *testing.T, key interface{}) []byte {
+	t.Helper()
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+}
+
+// mustKey parses a private key, retiring it at retiredAt when set
+func mustKey(t *testing.T, kid string, key interface{}, retiredAt *time.Time) *Key {
+	t.Helper()
+	k, err := ParsePrivateKeyPEM(kid, pkcs8PEM(t, key))
+	if err != nil {
+		t.Fatal(err)
+	}
+	k.RetiredAt = retiredAt
+	return k
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
+
+func TestParsePrivateKeyPEM(t *testing.T) {
+	tests := []struct {
+		name    string
+		kid     string
+		data    []byte
+		wantAlg string
+	}{
+		{name: "RSA PKCS#8", kid: "a", data: pkcs8PEM(t, testRSAKey), wantAlg: "RS256"},
+		{name: "RSA PKCS#1", kid: "a", data: testRSAPKCS1Block, wantAlg: "RS256"},
+		{name: "Ed25519", kid: "a", data: pkcs8PEM(t, testEdKey), wantAlg: "EdDSA"},
+		{name: "ECDSA is not supported", kid: "a", data: pkcs8PEM(t, testECDSAKey)},
+		{name: "missing kid", data: pkcs8PEM(t, testEdKey)},
+		{name: "not PEM", kid: "a", data: []byte("not a key")},
+		{name: "not a private key", kid: "a", data: pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte("junk")})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := ParsePrivateKeyPEM(tt.kid, tt.data)
+			if tt.wantAlg == "" {
+				if err == nil {
+					t.Fatalf("ParsePrivateKeyPEM() = %v, want an error", key.Algorithm())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParsePrivateKeyPEM() error = %v", err)
+			}
+			if key.ID != tt.kid || key.Algorithm() != tt.wantAlg {
+				t.Errorf("ParsePrivateKeyPEM() = %s %s, want %s %s", key.ID, key.Algorithm(), tt.kid, tt.wantAlg)
+			}
+		})
+	}
+}
+
+func TestLoadKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "key.pem")
+	if err := os.WriteFile(path, pkcs8PEM(t, testEdKey), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		spec        string
+		wantRetired *time.Time
+		wantErr     bool
+	}{
+		{name: "active key", spec: "k1=" + path},
+		{name: "surrounding spaces", spec: " k1=" + path + " "},
+		{name: "retired key", spec: "k1=" + path + "@2024-01-02T03:04:05Z", wantRetired: timePtr(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))},
+		{name: "invalid retirement time", spec: "k1=" + path + "@yesterday", wantErr: true},
+		{name: "missing kid", spec: path, wantErr: true},
+		{name: "missing file", spec: "k1=" + path + ".missing", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := LoadKey(tt.spec)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("LoadKey() succeeded, want an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadKey() error = %v", err)
+			}
+			if key.ID != "k1" || !reflect.DeepEqual(key.RetiredAt, tt.wantRetired) {
+				t.Errorf("LoadKey() = %s retired at %v, want k1 retired at %v", key.ID, key.RetiredAt, tt.wantRetired)
+			}
+		})
+	}
+}
+
+func TestNewServiceKeys(t *testing.T) {
+	retired := timePtr(time.Now())
+
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr bool
+	}{
+		{name: "secret", cfg: Config{Secret: "s"}},
+		{name: "signing key", cfg: Config{SigningKey: mustKey(t, "new", testEdKey, nil)}},
+		{name: "signing and retired keys", cfg: Config{SigningKey: mustKey(t, "new", testEdKey, nil), RetiredKeys: []*Key{mustKey(t, "old", testRSAKey, retired)}}},
+		{name: "no secret or key", cfg: Config{}, wantErr: true},
+		{name: "retired keys without a signing key", cfg: Config{Secret: "s", RetiredKeys: []*Key{mustKey(t, "old", testRSAKey, retired)}}, wantErr: true},
+		{name: "retired signing key", cfg: Config{SigningKey: mustKey(t, "new", testEdKey, retired)}, wantErr: true},
+		{name: "retired key without retirement time", cfg: Config{SigningKey: mustKey(t, "new", testEdKey, nil), RetiredKeys: []*Key{mustKey(t, "old", testRSAKey, nil)}}, wantErr: true},
+		{name: "duplicate kid", cfg: Config{SigningKey: mustKey(t, "new", testEdKey, nil), RetiredKeys: []*Key{mustKey(t, "new", testRSAKey, retired)}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewService(tt.cfg)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewService() error = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateSelectsKeyByKid(t *testing.T) {
+	now := time.Now()
+	newKey := mustKey(t, "new", testEdKey, nil)
+	rsaSigner := mustKey(t, "old", testRSAKey, nil)
+	expiredSigner := mustKey(t, "expired", testRSAKey, nil)
+
+	s, err := NewService(Config{
+		Issuer:      "test",
+		Expiration:  time.Hour,
+		SigningKey:  newKey,
+		GracePeriod: 2 * time.Hour,
+		RetiredKeys: []*Key{
+			mustKey(t, "old", testRSAKey, timePtr(now.Add(-time.Hour))),
+			mustKey(t, "expired", testRSAKey, timePtr(now.Add(-3*time.Hour))),
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// issue signs tokens the way a service using key did before its retirement
+	issue := func(key *Key) string {
+		other, err := NewService(Config{Issuer: "test", Expiration: time.Hour, SigningKey: key})
+		if err != nil {
+			t.Fatal(err)
+		}
+		token, _, err := other.Issue(testSubject)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return token
+	}
+	// resign signs claims with signer, labelling them with kid
+	resign := func(method jwt.SigningMethod, kid string, signer interface{}) string {
+		claims := &Claims{
+			SubjectType: testAudience,
+			RegisteredClaims: jwt.RegisteredClaims{
+				Issuer:    "test",
+				Subject:   "1",
+				Audience:  jwt.ClaimStrings{string(testAudience)},
+				ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour)),
+			},
+		}
+		token := jwt.NewWithClaims(method, claims)
+		if kid != "" {
+			token.Header["kid"] = kid
+		}
+		signed, err := token.SignedString(signer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return signed
+	}
+	current, _, err := s.Issue(testSubject)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		token string
+		want  error
+	}{
+		{name: "signing key", token: current},
+		{name: "retired key within the grace window", token: issue(rsaSigner)},
+		{name: "retired key past the grace window", token: issue(expiredSigner), want: ErrInvalidToken},
+		{name: "unknown kid", token: resign(jwt.SigningMethodEdDSA, "other", testEdKey), want: ErrInvalidToken},
+		{name: "missing kid", token: resign(jwt.SigningMethodEdDSA, "", testEdKey), want: ErrInvalidToken},
+		{name: "algorithm of another key", token: resign(jwt.SigningMethodRS256, "new", testRSAKey), want: ErrInvalidToken},
+		{name: "HMAC token", token: resign(jwt.SigningMethodHS256, "new", []byte("secret")), want: ErrInvalidToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := s.Validate(tt.token, testAudience)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("Validate() error = %v, want %v", err, tt.want)
+			}
+			if err == nil && claims.SubjectID() != testSubject.ID {
+				t.Errorf("SubjectID() = %d, want %d", claims.SubjectID(), testSubject.ID)
+			}
+		})
+	}
+}
+
+func TestJWKS(t *testing.T) {
+	now := time.Now()
+	s, err := NewService(Config{
+		SigningKey:  mustKey(t, "new", testEdKey, nil),
+		GracePeriod: 2 * time.Hour,
+		RetiredKeys: []*Key{
+			mustKey(t, "old-b", testRSAKey, timePtr(now.Add(-time.Hour))),
+			mustKey(t, "old-a", testRSAKey, timePtr(now.Add(-time.Minute))),
+			mustKey(t, "expired", testRSAKey, timePtr(now.Add(-3*time.Hour))),
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	set := s.JWKS()
+	kids := []string{}
+	for _, k := range set.Keys {
+		kids = append(kids, k.KeyID)
+	}
+	if want := []string{"new", "old-a", "old-b"}; !reflect.DeepEqual(kids, want) {
+		t.Fatalf("JWKS() kids = %v, want %v", kids, want)
+	}
+
+	wantEd := JWK{
+		KeyType:   "OKP",
+		KeyID:     "new",
+		Use:       "sig",
+		Algorithm: "EdDSA",
+		Curve:     "Ed25519",
+		X:         base64.RawURLEncoding.EncodeToString(testEdKey.Public().(ed25519.PublicKey)),
+	}
+	if set.Keys[0] != wantEd {
+		t.Errorf("Ed25519 JWK = %+v, want %+v", set.Keys[0], wantEd)
+	}
+	wantRSA := JWK{
+		KeyType:   "RSA",
+		KeyID:     "old-a",
+		Use:       "sig",
+		Algorithm: "RS256",
+		N:         base64.RawURLEncoding.EncodeToString(testRSAKey.N.Bytes()),
+		E:         "AQAB",
+	}
+	if set.Keys[1] != wantRSA {
+		t.Errorf("RSA JWK = %+v, want %+v", set.Keys[1], wantRSA)
+	}
+
+	hmac, err := NewService(Config{Secret: "s"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if keys := hmac.JWKS().Keys; keys == nil || len(keys) != 0 {
+		t.Errorf("JWKS() with a secret = %v, want an empty key list", keys)
+	}
+}
@@ -0,0 +1,71 @@
+package throttle
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// sweepInterval is how often the memory store discards expired keys
+const sweepInterval = 5 * time.Minute
+
+// MemoryStore keeps failure state in process memory. It is only suitable
+// for a single API instance.
+type MemoryStore struct {
+	mu        sync.Mutex
+	states    map[string]State
+	policies  map[string]Policy
+	lastSweep time.Time
+}
+
+// NewMemoryStore creates a new in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		states:   make(map[string]State),
+		policies: make(map[string]Policy),
+	}
+}
+
+// Get returns the state for the key
+func (m *MemoryStore) Get(ctx context.Context, key string) (State, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.states[key], nil
+}
+
+// Fail records a failure for the key
+func (m *MemoryStore) Fail(ctx context.Context, key string, policy Policy, now time.Time) (State, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s := policy.Fail(m.states[key], now)
+	m.states[key] = s
+	m.policies[key] = policy
+	m.sweep(now)
+	return s, nil
+}
+
+// Reset forgets the key
+func (m *MemoryStore) Reset(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.states, key)
+	delete(m.policies, key)
+	return nil
+}
+
+// sweep discards expired keys so the map does not grow without bound.
+// The caller must hold the lock.
+func (m *MemoryStore) sweep(now time.Time) {
+	if now.Sub(m.lastSweep) < sweepInterval {
+		return
+	}
+	m.lastSweep = now
+
+	for key, s := range m.states {
+		if m.policies[key].Expired(s, now) {
+			delete(m.states, key)
+			delete(m.policies, key)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+// Package throttle counts failures per key (an account, an IP address) and
+// locks a key out with exponential backoff once it fails too often.
+package throttle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrLocked is matched by every LockedError
+var ErrLocked = errors.New("too many failed attempts")
+
+// LockedError reports that a key is locked out until Until
+type LockedError struct {
+	Until time.Time
+}
+
+func (e *LockedError) Error() string {
+	return fmt.Sprintf("too many failed attempts, try again in %s", e.RetryAfter(time.Now()).Round(time.Second))
+}
+
+// Is makes errors.Is(err, ErrLocked) match
+func (e *LockedError) Is(target error) bool {
+	return target == ErrLocked
+}
+
+// RetryAfter returns how long remains until the lockout ends
+func (e *LockedError) RetryAfter(now time.Time) time.Duration {
+	if d := e.Until.Sub(now); d > 0 {
+		return d
+	}
+	return 0
+}
+
+// Policy decides when a key is locked and for how long
+type Policy struct {
+	// Threshold is the number of consecutive failures that triggers a lockout
+	Threshold int
+	// BaseDelay is the first lockout; each further failure doubles it
+	BaseDelay time.Duration
+	// MaxDelay caps the lockout duration
+	MaxDelay time.Duration
+	// Window is how long after the last failure the counter is forgotten
+	Window time.Duration
+}
+
+// State is the failure record kept for a key
+type State struct {
+	Failures    int
+	LastFailure time.Time
+	LockedUntil time.Time
+}
+
+// Locked reports whether the key is locked out at now
+func (s State) Locked(now time.Time) bool {
+	return now.Before(s.LockedUntil)
+}
+
+// Fail returns the state after one more failure at now
+func (p Policy) Fail(s State, now time.Time) State {
+	if !s.LastFailure.IsZero() && now.Sub(s.LastFailure) > p.Window && !s.Locked(now) {
+		s = State{}
+	}
+
+	s.Failures++
+	s.LastFailure = now
+	if s.Failures >= p.Threshold {
+		s.LockedUntil = now.Add(p.delay(s.Failures - p.Threshold))
+	}
+	return s
+}
+
+// delay returns BaseDelay * 2^n, capped at MaxDelay
+func (p Policy) delay(n int) time.Duration {
+	d := p.BaseDelay
+	for i := 0; i < n && d < p.MaxDelay; i++ {
+		d *= 2
+	}
+	if d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+	return d
+}
+
+// Expired reports whether the state no longer affects anything and can be discarded
+func (p Policy) Expired(s State, now time.Time) bool {
+	return !s.Locked(now) && now.Sub(s.LastFailure) > p.Window
+}
+
+// Store persists failure state per key. Implementations must apply Fail
+// atomically so concurrent failures are all counted.
+type Store interface {
+	Get(ctx context.Context, key string) (State, error)
+	Fail(ctx context.Context, key string, policy Policy, now time.Time) (State, error)
+	Reset(ctx context.Context, key string) error
+}
+
+// Limiter applies a policy to keys held in a store
+type Limiter struct {
+	store  Store
+	policy Policy
+}
+
+// NewLimiter creates a new limiter
+func NewLimiter(store Store, policy Policy) *Limiter {
+	return &Limiter{store: store, policy: policy}
+}
+
+// Check returns a *LockedError if the key is currently locked out
+func (l *Limiter) Check(ctx context.Context, key string) error {
+	s, err := l.store.Get(ctx, key)
+	if err != nil {
+		return err
+	}
+	if s.Locked(time.Now()) {
+		return &LockedError{Until: s.LockedUntil}
+	}
+	return nil
+}
+
+// Fail records a failure for the key. It returns the new state and whether
+// this failure locked the key.
+func (l *Limiter) Fail(ctx context.Context, key string) (State, bool, error) {
+	now := time.Now()
+	s, err := l.store.Fail(ctx, key, l.policy, now)
+	if err != nil {
+		return State{}, false, err
+	}
+	return s, s.Failures >= l.policy.Threshold && s.Locked(now), nil
+}
+
+// Reset forgets the failures recorded for the key
+func (l *Limiter) Reset(ctx context.Context, key string) error {
+	return l.store.Reset(ctx, key)
+}
@@ -0,0 +1,171 @@
+package throttle
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+var testPolicy = Policy{
+	Threshold: 3,
+	BaseDelay: time.Minute,
+	MaxDelay:  5 * time.Minute,
+	Window:    15 * time.Minute,
+}
+
+func TestPolicyFail(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		failures     []time.Duration
+		wantFailures int
+		wantLocked   time.Duration
+	}{
+		{
+			name:         "below the threshold",
+			failures:     []time.Duration{0, time.Second},
+			wantFailures: 2,
+		},
+		{
+			name:         "threshold locks for the base delay",
+			failures:     []time.Duration{0, time.Second, 2 * time.Second},
+			wantFailures: 3,
+			wantLocked:   2*time.Second + time.Minute,
+		},
+		{
+			name:         "each further failure doubles the delay",
+			failures:     []time.Duration{0, 0, 0, 0, 0},
+			wantFailures: 5,
+			wantLocked:   4 * time.Minute,
+		},
+		{
+			name:         "delay is capped",
+			failures:     []time.Duration{0, 0, 0, 0, 0, 0, 0, 0},
+			wantFailures: 8,
+			wantLocked:   5 * time.Minute,
+		},
+		{
+			name:         "counter is forgotten after the window",
+			failures:     []time.Duration{0, time.Second, 20 * time.Minute},
+			wantFailures: 1,
+		},
+		{
+			name:         "counter is kept within the window",
+			failures:     []time.Duration{0, 10 * time.Minute, 20 * time.Minute},
+			wantFailures: 3,
+			wantLocked:   21 * time.Minute,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var s State
+			for _, at := range tt.failures {
+				s = testPolicy.Fail(s, start.Add(at))
+			}
+
+			if s.Failures != tt.wantFailures {
+				t.Errorf("Failures = %d, want %d", s.Failures, tt.wantFailures)
+			}
+			var want time.Time
+			if tt.wantLocked > 0 {
+				want = start.Add(tt.wantLocked)
+			}
+			if !s.LockedUntil.Equal(want) {
+				t.Errorf("LockedUntil = %v, want %v", s.LockedUntil, want)
+			}
+		})
+	}
+}
+
+func TestPolicyFailWhileLockedAfterWindow(t *testing.T) {
+	policy := Policy{Threshold: 1, BaseDelay: time.Hour, MaxDelay: time.Hour, Window: time.Minute}
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	s := policy.Fail(State{}, start)
+	s = policy.Fail(s, start.Add(30*time.Minute))
+	if s.Failures != 2 {
+		t.Errorf("Failures = %d, want 2: a locked key must not be reset by the window", s.Failures)
+	}
+}
+
+func TestPolicyExpired(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	locked := State{Failures: 3, LastFailure: start, LockedUntil: start.Add(time.Hour)}
+	failed := State{Failures: 1, LastFailure: start}
+
+	tests := []struct {
+		name  string
+		state State
+		at    time.Duration
+		want  bool
+	}{
+		{"recent failure", failed, time.Minute, false},
+		{"failure past the window", failed, 16 * time.Minute, true},
+		{"locked past the window", locked, 30 * time.Minute, false},
+		{"lockout over past the window", locked, 2 * time.Hour, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := testPolicy.Expired(tt.state, start.Add(tt.at)); got != tt.want {
+				t.Errorf("Expired() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLimiter(t *testing.T) {
+	ctx := context.Background()
+	l := NewLimiter(NewMemoryStore(), testPolicy)
+
+	for i := 1; i <= testPolicy.Threshold; i++ {
+		if err := l.Check(ctx, "a"); err != nil {
+			t.Fatalf("Check() before failure %d error = %v", i, err)
+		}
+		_, locked, err := l.Fail(ctx, "a")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := i == testPolicy.Threshold; locked != want {
+			t.Errorf("Fail() %d locked = %v, want %v", i, locked, want)
+		}
+	}
+
+	err := l.Check(ctx, "a")
+	var lockedErr *LockedError
+	if !errors.Is(err, ErrLocked) || !errors.As(err, &lockedErr) {
+		t.Fatalf("Check() error = %v, want a *LockedError", err)
+	}
+	if d := lockedErr.RetryAfter(time.Now()); d <= 0 || d > testPolicy.BaseDelay {
+		t.Errorf("RetryAfter() = %v, want up to %v", d, testPolicy.BaseDelay)
+	}
+	if err := l.Check(ctx, "b"); err != nil {
+		t.Errorf("Check() of another key error = %v", err)
+	}
+
+	if err := l.Reset(ctx, "a"); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Check(ctx, "a"); err != nil {
+		t.Errorf("Check() after Reset() error = %v", err)
+	}
+}
+
+func TestMemoryStoreSweep(t *testing.T) {
+	ctx := context.Background()
+	m := NewMemoryStore()
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	m.Fail(ctx, "old", testPolicy, start)
+	m.Fail(ctx, "recent", testPolicy, start.Add(time.Hour))
+
+	if _, ok := m.states["old"]; ok {
+		t.Error("expired key was not swept")
+	}
+	if _, ok := m.states["recent"]; !ok {
+		t.Error("recent key was swept")
+	}
+}
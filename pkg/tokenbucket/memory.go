@@ -0,0 +1,55 @@
+package tokenbucket
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// sweepInterval is how often the memory store discards full buckets
+const sweepInterval = 5 * time.Minute
+
+// MemoryStore keeps buckets in process memory. It is only suitable for a
+// single API instance.
+type MemoryStore struct {
+	mu        sync.Mutex
+	buckets   map[string]Bucket
+	rates     map[string]Rate
+	lastSweep time.Time
+}
+
+// NewMemoryStore creates a new in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		buckets: make(map[string]Bucket),
+		rates:   make(map[string]Rate),
+	}
+}
+
+// Take takes a token from the key's bucket
+func (m *MemoryStore) Take(ctx context.Context, key string, rate Rate, now time.Time) (Result, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	b, result := rate.Take(m.buckets[key], now)
+	m.buckets[key] = b
+	m.rates[key] = rate
+	m.sweep(now)
+	return result, nil
+}
+
+// sweep discards full buckets so the map does not grow without bound.
+// The caller must hold the lock.
+func (m *MemoryStore) sweep(now time.Time) {
+	if now.Sub(m.lastSweep) < sweepInterval {
+		return
+	}
+	m.lastSweep = now
+
+	for key, b := range m.buckets {
+		if m.rates[key].Full(b, now) {
+			delete(m.buckets, key)
+			delete(m.rates, key)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+// Package tokenbucket limits how often a key may be used. Each key has a
+// bucket holding up to Limit tokens that refills continuously over Window;
+// every request takes one token.
+package tokenbucket
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// Rate allows Limit requests per Window
+type Rate struct {
+	Limit  int
+	Window time.Duration
+}
+
+// Bucket is the state kept for a key. A zero Bucket is full.
+type Bucket struct {
+	Tokens    float64
+	UpdatedAt time.Time
+}
+
+// Result describes the outcome of taking a token
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// Reset is when the bucket will be full again
+	Reset time.Time
+	// RetryAfter is how long to wait for the next token when not allowed
+	RetryAfter time.Duration
+}
+
+// Take returns the bucket after trying to take one token at now, and the result
+func (r Rate) Take(b Bucket, now time.Time) (Bucket, Result) {
+	b = r.refill(b, now)
+
+	allowed := b.Tokens >= 1
+	if allowed {
+		b.Tokens--
+	}
+
+	result := Result{
+		Allowed:   allowed,
+		Limit:     r.Limit,
+		Remaining: int(math.Floor(b.Tokens)),
+		Reset:     now.Add(r.durationFor(float64(r.Limit) - b.Tokens)),
+	}
+	if !allowed {
+		result.RetryAfter = r.durationFor(1 - b.Tokens)
+	}
+	return b, result
+}
+
+// Full reports whether the bucket has refilled completely at now, so its
+// state no longer matters and can be discarded
+func (r Rate) Full(b Bucket, now time.Time) bool {
+	return r.refill(b, now).Tokens >= float64(r.Limit)
+}
+
+// refill adds the tokens earned since the bucket was last updated. A limit
+// lowered since then caps the bucket at the new limit.
+func (r Rate) refill(b Bucket, now time.Time) Bucket {
+	limit := float64(r.Limit)
+	if b.UpdatedAt.IsZero() {
+		return Bucket{Tokens: limit, UpdatedAt: now}
+	}
+
+	if elapsed := now.Sub(b.UpdatedAt); elapsed > 0 {
+		b.Tokens += elapsed.Seconds() * limit / r.Window.Seconds()
+	}
+	b.Tokens = math.Min(b.Tokens, limit)
+	b.UpdatedAt = now
+	return b
+}
+
+// durationFor returns how long it takes to earn n tokens
+func (r Rate) durationFor(n float64) time.Duration {
+	if n <= 0 {
+		return 0
+	}
+	return time.Duration(math.Ceil(n * float64(r.Window) / float64(r.Limit)))
+}
+
+// Store persists buckets per key. Implementations must apply Take atomically
+// so concurrent requests are all counted.
+type Store interface {
+	Take(ctx context.Context, key string, rate Rate, now time.Time) (Result, error)
+}
@@ -0,0 +1,154 @@
+package tokenbucket
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+var now = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+// tenPerMinute earns a token every 6 seconds
+var tenPerMinute = Rate{Limit: 10, Window: time.Minute}
+
+func TestRateTake(t *testing.T) {
+	tests := []struct {
+		name       string
+		bucket     Bucket
+		want       Result
+		wantTokens float64
+	}{
+		{
+			name:       "new bucket is full",
+			want:       Result{Allowed: true, Limit: 10, Remaining: 9, Reset: now.Add(6 * time.Second)},
+			wantTokens: 9,
+		},
+		{
+			name:       "last token",
+			bucket:     Bucket{Tokens: 1, UpdatedAt: now},
+			want:       Result{Allowed: true, Limit: 10, Remaining: 0, Reset: now.Add(time.Minute)},
+			wantTokens: 0,
+		},
+		{
+			name:       "empty bucket",
+			bucket:     Bucket{Tokens: 0, UpdatedAt: now},
+			want:       Result{Allowed: false, Limit: 10, Remaining: 0, Reset: now.Add(time.Minute), RetryAfter: 6 * time.Second},
+			wantTokens: 0,
+		},
+		{
+			name:       "partly refilled token",
+			bucket:     Bucket{Tokens: 0, UpdatedAt: now.Add(-3 * time.Second)},
+			want:       Result{Allowed: false, Limit: 10, Remaining: 0, Reset: now.Add(57 * time.Second), RetryAfter: 3 * time.Second},
+			wantTokens: 0.5,
+		},
+		{
+			name:       "refilled token",
+			bucket:     Bucket{Tokens: 0, UpdatedAt: now.Add(-6 * time.Second)},
+			want:       Result{Allowed: true, Limit: 10, Remaining: 0, Reset: now.Add(time.Minute)},
+			wantTokens: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, got := tenPerMinute.Take(tt.bucket, now)
+			if got != tt.want {
+				t.Errorf("Take() = %+v, want %+v", got, tt.want)
+			}
+			if b.Tokens != tt.wantTokens || !b.UpdatedAt.Equal(now) {
+				t.Errorf("bucket = %+v, want %v tokens at %v", b, tt.wantTokens, now)
+			}
+		})
+	}
+}
+
+func TestRateRefill(t *testing.T) {
+	tests := []struct {
+		name   string
+		rate   Rate
+		bucket Bucket
+		want   float64
+	}{
+		{
+			name: "zero bucket is full",
+			rate: tenPerMinute,
+			want: 10,
+		},
+		{
+			name:   "tokens are earned over time",
+			rate:   tenPerMinute,
+			bucket: Bucket{Tokens: 2, UpdatedAt: now.Add(-30 * time.Second)},
+			want:   7,
+		},
+		{
+			name:   "bucket does not overflow",
+			rate:   tenPerMinute,
+			bucket: Bucket{Tokens: 8, UpdatedAt: now.Add(-time.Hour)},
+			want:   10,
+		},
+		{
+			name:   "lowered limit caps the bucket",
+			rate:   Rate{Limit: 5, Window: time.Minute},
+			bucket: Bucket{Tokens: 10, UpdatedAt: now},
+			want:   5,
+		},
+		{
+			name:   "clock going backwards earns nothing",
+			rate:   tenPerMinute,
+			bucket: Bucket{Tokens: 2, UpdatedAt: now.Add(time.Minute)},
+			want:   2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.rate.refill(tt.bucket, now)
+			if got.Tokens != tt.want || !got.UpdatedAt.Equal(now) {
+				t.Errorf("refill() = %+v, want %v tokens at %v", got, tt.want, now)
+			}
+		})
+	}
+}
+
+func TestRateDurationFor(t *testing.T) {
+	tests := []struct {
+		tokens float64
+		want   time.Duration
+	}{
+		{-1, 0},
+		{0, 0},
+		{1, 6 * time.Second},
+		{0.5, 3 * time.Second},
+		{10, time.Minute},
+		// Partial nanoseconds round up so callers never retry too early
+		{1e-12, time.Nanosecond},
+	}
+
+	for _, tt := range tests {
+		if got := tenPerMinute.durationFor(tt.tokens); got != tt.want {
+			t.Errorf("durationFor(%v) = %v, want %v", tt.tokens, got, tt.want)
+		}
+	}
+}
+
+func TestMemoryStoreTake(t *testing.T) {
+	ctx := context.Background()
+	m := NewMemoryStore()
+	rate := Rate{Limit: 2, Window: time.Minute}
+
+	for i, want := range []bool{true, true, false} {
+		result, err := m.Take(ctx, "a", rate, now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result.Allowed != want {
+			t.Errorf("Take() %d allowed = %v, want %v", i+1, result.Allowed, want)
+		}
+	}
+	if result, _ := m.Take(ctx, "b", rate, now); !result.Allowed {
+		t.Error("Take() of another key was not allowed")
+	}
+	if result, _ := m.Take(ctx, "a", rate, now.Add(30*time.Second)); !result.Allowed {
+		t.Error("Take() after a refill was not allowed")
+	}
+}